@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"financo/core/scope_accounts/application/commands/create_command"
 	"financo/core/scope_accounts/domain/requests"
-	"financo/core/scope_accounts/infrastructure/broker_handler"
 	"financo/core/scope_accounts/infrastructure/create_account_repository"
 	"financo/services/postgresql_database"
 	"log"
@@ -31,14 +30,7 @@ func create(w http.ResponseWriter, r *http.Request) {
 
 	repo := create_account_repository.NewPostgreSQL(postgresql_database.New())
 
-	broker, err := broker_handler.Instance()
-	if err != nil {
-		log.Println("created broker uninitialized", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
-	}
-
-	res, err := create_command.New(req, repo, broker.CreatedBroker()).Run(r.Context())
+	res, err := create_command.New(req, repo).Run(r.Context())
 	if err != nil {
 		log.Println("command failed", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
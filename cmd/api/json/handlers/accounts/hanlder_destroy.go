@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"financo/core/scope_accounts/application/commands/delete_command"
 	"financo/core/scope_accounts/domain/requests"
-	"financo/core/scope_accounts/infrastructure/broker_handler"
 	"financo/core/scope_accounts/infrastructure/delete_account_repository"
 	"financo/services/postgresql_database"
 	"log"
@@ -25,14 +24,7 @@ func destroy(w http.ResponseWriter, r *http.Request) {
 	req := requests.Delete{ID: id}
 	repo := delete_account_repository.NewPostgreSQL(postgresql_database.New())
 
-	broker, err := broker_handler.Instance()
-	if err != nil {
-		log.Println("created broker uninitialized", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
-	}
-
-	res, err := delete_command.New(req, repo, broker.DeletedBroker()).Run(r.Context())
+	res, err := delete_command.New(req, repo).Run(r.Context())
 	if err != nil {
 		log.Println("command failed", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
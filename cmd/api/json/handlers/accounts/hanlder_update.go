@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"financo/core/scope_accounts/application/commands/update_command"
 	"financo/core/scope_accounts/domain/requests"
-	"financo/core/scope_accounts/infrastructure/broker_handler"
 	"financo/core/scope_accounts/infrastructure/update_account_repository"
 	"financo/services/postgresql_database"
 	"log"
@@ -35,14 +34,7 @@ func update(w http.ResponseWriter, r *http.Request) {
 
 	repo := update_account_repository.NewPostgreSQL(postgresql_database.New())
 
-	broker, err := broker_handler.Instance()
-	if err != nil {
-		log.Println("created broker uninitialized", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
-	}
-
-	comm, err := update_command.New(req, repo, broker.UpdatedBroker())
+	comm, err := update_command.New(req, repo)
 	if err != nil {
 		log.Println("unsupported subcommand", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -0,0 +1,14 @@
+package dead_letters
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", show)
+		r.Delete("/", destroy)
+	})
+}
@@ -0,0 +1,11 @@
+package admin
+
+import (
+	"financo/cmd/api/json/handlers/admin/dead_letters"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(r chi.Router) {
+	r.Route("/dead_letters", dead_letters.Routes)
+}
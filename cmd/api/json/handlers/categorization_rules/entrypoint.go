@@ -0,0 +1,19 @@
+package categorization_rules
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+	r.Post("/", create)
+
+	r.Post("/dry_run", dryRun)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", show)
+		r.Delete("/", destroy)
+		r.Put("/", update)
+		r.Post("/apply", apply)
+	})
+}
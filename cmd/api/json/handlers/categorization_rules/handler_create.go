@@ -0,0 +1,66 @@
+package categorization_rules
+
+import (
+	"encoding/json"
+	"financo/server/categorization_rules/commands/create_command"
+	"financo/server/categorization_rules/types/request"
+	"log"
+	"net/http"
+)
+
+func create(w http.ResponseWriter, r *http.Request) {
+	var req request.Create
+
+	body := r.Body
+	defer func() {
+		err := body.Close()
+		if err != nil {
+			log.Println("failed to close body", err)
+		}
+	}()
+
+	err := json.NewDecoder(body).Decode(&req)
+	if err != nil {
+		log.Println("failed to decode body", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	res, err := create_command.New(req).Run(r.Context())
+	if err != nil {
+		log.Println("command failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
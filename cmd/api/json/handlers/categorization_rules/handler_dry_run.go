@@ -0,0 +1,81 @@
+package categorization_rules
+
+import (
+	"encoding/json"
+	"financo/lib/categorize"
+	"financo/server/categorization_rules/queries/changes_query"
+	"financo/server/categorization_rules/types/request"
+	"log"
+	"net/http"
+)
+
+func dryRun(w http.ResponseWriter, r *http.Request) {
+	var req request.Create
+
+	body := r.Body
+	defer func() {
+		err := body.Close()
+		if err != nil {
+			log.Println("failed to close body", err)
+		}
+	}()
+
+	err := json.NewDecoder(body).Decode(&req)
+	if err != nil {
+		log.Println("failed to decode body", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	err = req.Action.Validate()
+	if err != nil {
+		log.Println("invalid categorization rule", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusBadRequest),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	res, err := changes_query.New(categorize.Rule{
+		Condition: req.Condition,
+		Action:    req.Action,
+	}).Find(r.Context())
+	if err != nil {
+		log.Println("query failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
@@ -0,0 +1,90 @@
+package categorization_rules
+
+import (
+	"encoding/json"
+	"financo/server/categorization_rules/commands/update_command"
+	"financo/server/categorization_rules/types/request"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func update(w http.ResponseWriter, r *http.Request) {
+	var req request.Update
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Println("failed to parse categorization rule id", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	body := r.Body
+	defer func() {
+		err := body.Close()
+		if err != nil {
+			log.Println("failed to close body", err)
+		}
+	}()
+
+	err = json.NewDecoder(body).Decode(&req)
+	if err != nil {
+		log.Println("failed to decode body", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	if id != req.ID {
+		log.Println("ids don't match")
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotAcceptable),
+			http.StatusNotAcceptable,
+		)
+		return
+	}
+
+	res, err := update_command.New(req).Run(r.Context())
+	if err != nil {
+		log.Println("command failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
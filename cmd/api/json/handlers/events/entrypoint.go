@@ -0,0 +1,24 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	shutdown     = make(chan struct{})
+	shutdownOnce sync.Once
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", stream)
+}
+
+// Shutdown ends every open stream, register it with
+// [http.Server.RegisterOnShutdown] so streams don't hold the shutdown back.
+func Shutdown() {
+	shutdownOnce.Do(func() {
+		close(shutdown)
+	})
+}
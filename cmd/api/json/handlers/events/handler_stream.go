@@ -0,0 +1,171 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"financo/core/scope_accounts/infrastructure/broker_handler"
+	events_service "financo/server/events"
+	"financo/server/events/queries/since_query"
+	"financo/server/events/types/response"
+	transactions_service "financo/server/transactions"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	streamBuffer      = 256
+	replayLimit       = 500
+)
+
+func stream(w http.ResponseWriter, r *http.Request) {
+	var lastID int64
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID != "" {
+		var err error
+
+		lastID, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || lastID < 0 {
+			log.Println("invalid Last-Event-ID", lastEventID)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+
+	accounts, err := broker_handler.Instance()
+	if err != nil {
+		log.Println("accounts broker unavailable", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	// The server read and write timeouts would end the stream after a second.
+	rc := http.NewResponseController(w)
+
+	err = errors.Join(rc.SetReadDeadline(time.Time{}), rc.SetWriteDeadline(time.Time{}))
+	if err != nil {
+		log.Println("failed to clear write deadline", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	s, err := events_service.Subscribe(accounts, transactions_service.NewBroker(nil), streamBuffer)
+	if err != nil {
+		log.Println("failed to subscribe", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+	defer func() {
+		if err := s.Close(); err != nil {
+			log.Println("failed to unsubscribe", err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	// The events relayed after Last-Event-ID are replayed from the outbox.
+	// The stream subscribed first, so nothing relayed meanwhile is missed,
+	// and the replayed events are skipped when they arrive from the buses.
+	replayed := make(map[int64]struct{})
+
+	for lastEventID != "" {
+		res, err := since_query.New(lastID, replayLimit).Find(r.Context())
+		if err != nil {
+			log.Println("query failed", err)
+			return
+		}
+
+		for _, event := range res {
+			if err := write(w, event); err != nil {
+				log.Println("failed writing event", err)
+				return
+			}
+
+			replayed[event.ID] = struct{}{}
+			lastID = event.ID
+		}
+
+		if len(res) < replayLimit {
+			break
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		if err := rc.Flush(); err != nil {
+			log.Println("failed flushing events", err)
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-shutdown:
+			return
+		case <-s.Lagged():
+			// The events still buffered are sent, then the client reconnects
+			// and resumes after the last one.
+			for {
+				select {
+				case event := <-s.Events():
+					if err := write(w, event); err != nil {
+						log.Println("failed writing event", err)
+						return
+					}
+				default:
+					_ = rc.Flush()
+					return
+				}
+			}
+		case event := <-s.Events():
+			if _, ok := replayed[event.ID]; ok {
+				continue
+			}
+
+			if err := write(w, event); err != nil {
+				log.Println("failed writing event", err)
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprintf(w, ": ping %s\n\n", time.Now().UTC().Format(time.RFC3339)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func write(w io.Writer, event response.Event) error {
+	data, err := json.Marshal(&event.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+
+	return err
+}
@@ -0,0 +1,13 @@
+package export
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	formatKey = "format"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/ledger", exportLedger)
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"financo/lib/ledger"
+	"financo/server/export/queries/ledger_query"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// exportLedger writes every account and transaction as a plain text
+// accounting journal, the format query parameter selects between ledger and
+// beancount, it defaults to ledger.
+func exportLedger(w http.ResponseWriter, r *http.Request) {
+	var (
+		format = ledger.Ledger
+		buf    bytes.Buffer
+		err    error
+	)
+
+	if r.URL.Query().Has(formatKey) {
+		format, err = ledger.ParseFormat(r.URL.Query().Get(formatKey))
+		if err != nil {
+			log.Println("failed to parse format", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+
+	journal, err := ledger_query.New().Find(r.Context())
+	if err != nil {
+		log.Println("query failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	err = ledger.Write(&buf, format, journal)
+	if err != nil {
+		log.Println("failed to write journal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"financo.%s\"", format))
+
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		log.Println("failed to write response", err)
+	}
+}
@@ -4,4 +4,6 @@ import "github.com/go-chi/chi/v5"
 
 func Routes(r chi.Router) {
 	r.Get("/achievements", achievements)
+	r.Post("/achievements", create)
+	r.Delete("/achievements/{id}", destroy)
 }
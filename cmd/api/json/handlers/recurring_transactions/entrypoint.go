@@ -0,0 +1,20 @@
+package recurring_transactions
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	accountKey = "account"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+	r.Post("/", create)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", show)
+		r.Delete("/", destroy)
+		r.Put("/", update)
+	})
+}
@@ -0,0 +1,73 @@
+package recurring_transactions
+
+import (
+	"encoding/json"
+	"financo/server/recurring_transactions/queries/list_query"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func index(w http.ResponseWriter, r *http.Request) {
+	var (
+		accounts = make([]int64, 0, 10)
+	)
+
+	if r.URL.Query().Has(accountKey) {
+		raw := strings.Split(r.URL.Query().Get(accountKey), ",")
+
+		for i := 0; i < len(raw); i++ {
+			if raw[i] == "" {
+				continue
+			}
+
+			parsed, err := strconv.ParseInt(raw[i], 10, 64)
+			if err != nil {
+				log.Println("failed to parsed id", err)
+				http.Error(
+					w,
+					http.StatusText(http.StatusInternalServerError),
+					http.StatusInternalServerError,
+				)
+				return
+			}
+
+			accounts = append(accounts, parsed)
+		}
+	}
+
+	res, err := list_query.New(accounts).Find(r.Context())
+	if err != nil {
+		log.Println("query failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
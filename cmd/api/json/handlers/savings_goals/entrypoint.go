@@ -2,6 +2,27 @@ package savings_goals
 
 import "github.com/go-chi/chi/v5"
 
+const (
+	monthsKey = "months"
+
+	// The projections average the net inflow of the last 6 months by
+	// default, and at most of the last 5 years.
+	defaultProjectionMonths = 6
+	maxProjectionMonths     = 60
+)
+
 func Routes(r chi.Router) {
 	r.Get("/active", active)
+	r.Get("/projections", projections)
+	r.Post("/", create)
+	r.Put("/reorder", reorder)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", show)
+		r.Delete("/", destroy)
+		r.Put("/", update)
+
+		r.Post("/archive", archive)
+		r.Post("/unarchive", unarchive)
+	})
 }
@@ -0,0 +1,64 @@
+package savings_goals
+
+import (
+	"encoding/json"
+	"financo/server/savings_goals/queries/projections_query"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func projections(w http.ResponseWriter, r *http.Request) {
+	var (
+		months = defaultProjectionMonths
+	)
+
+	if r.URL.Query().Has(monthsKey) {
+		raw, err := strconv.Atoi(r.URL.Query().Get(monthsKey))
+		if err != nil || raw < 1 || raw > maxProjectionMonths {
+			log.Println("invalid months", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+
+		months = raw
+	}
+
+	res, err := projections_query.New(months).Find(r.Context())
+	if err != nil {
+		log.Println("query failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
@@ -0,0 +1,13 @@
+package search
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	queryKey = "q"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+}
@@ -0,0 +1,44 @@
+package summaries
+
+import (
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/currency"
+	"financo/lib/exchange"
+	"financo/server/summaries/queries/converted_query"
+	"financo/server/summaries/types/response"
+	"log"
+	"net/http"
+)
+
+var errInvalidCurrency = errors.New("summaries: invalid currency")
+
+// find runs summary. When the request has an in parameter the result is
+// converted into that currency.
+func find(r *http.Request, summary queries.Query[[]response.Global]) (any, error) {
+	in := r.URL.Query().Get("in")
+	if in == "" {
+		return summary.Find(r.Context())
+	}
+
+	var cur currency.Type
+
+	if err := cur.Scan(in); err != nil {
+		return nil, errors.Join(errInvalidCurrency, err)
+	}
+
+	return converted_query.New(cur, summary).Find(r.Context())
+}
+
+func findFailed(w http.ResponseWriter, err error) {
+	log.Println("query failed", err)
+
+	switch {
+	case errors.Is(err, errInvalidCurrency):
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	case errors.Is(err, exchange.ErrRateNotFound):
+		http.Error(w, http.StatusText(http.StatusUnprocessableEntity), http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -8,14 +8,9 @@ import (
 )
 
 func AvailableCredit(w http.ResponseWriter, r *http.Request) {
-	res, err := available_credit_query.New().Find(r.Context())
+	res, err := find(r, available_credit_query.New())
 	if err != nil {
-		log.Println("query failed", err)
-		http.Error(
-			w,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
+		findFailed(w, err)
 		return
 	}
 
@@ -13,14 +13,9 @@ func Capital(w http.ResponseWriter, r *http.Request) {
 		kinds = []account.Kind{account.CapitalNormal, account.CapitalSavings}
 	)
 
-	res, err := summary_for_kind_query.New(kinds).Find(r.Context())
+	res, err := find(r, summary_for_kind_query.New(kinds))
 	if err != nil {
-		log.Println("query failed", err)
-		http.Error(
-			w,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
+		findFailed(w, err)
 		return
 	}
 
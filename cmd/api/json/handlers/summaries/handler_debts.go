@@ -1,9 +1,13 @@
 package summaries
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"financo/core/domain/queries"
 	"financo/models/account"
 	"financo/server/summaries/queries/summary_for_kind_query"
+	"financo/server/summaries/types/response"
 	"log"
 	"net/http"
 )
@@ -13,23 +17,12 @@ func Debts(w http.ResponseWriter, r *http.Request) {
 		kinds = []account.Kind{account.DebtLoan, account.DebtPersonal, account.DebtCredit}
 	)
 
-	res, err := summary_for_kind_query.New(kinds).Find(r.Context())
+	res, err := find(r, debtsQuery{summary: summary_for_kind_query.New(kinds)})
 	if err != nil {
-		log.Println("query failed", err)
-		http.Error(
-			w,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
+		findFailed(w, err)
 		return
 	}
 
-	for i := 0; i < len(res); i++ {
-		for j := 0; j < len(res[i].Series); j++ {
-			res[i].Series[j].Amount = -res[i].Series[j].Amount
-		}
-	}
-
 	response, err := json.Marshal(res)
 	if err != nil {
 		log.Println("failed json Marshal", err)
@@ -54,3 +47,27 @@ func Debts(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Header().Add("Content-Type", "application/json")
 }
+
+// debtsQuery shows the debt series as positive amounts, before they are
+// converted into another currency.
+type debtsQuery struct {
+	summary queries.Query[[]response.Global]
+}
+
+func (q debtsQuery) Find(ctx context.Context) ([]response.Global, error) {
+	res, err := q.summary.Find(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	for i := 0; i < len(res); i++ {
+		for j := 0; j < len(res[i].Series); j++ {
+			res[i].Series[j].Amount, err = res[i].Series[j].Amount.Negate()
+			if err != nil {
+				return res, errors.Join(errors.New("failed to negate series"), err)
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,23 @@
+package tags
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	executedFromKey  = "executedFrom"
+	executedUntilKey = "executedUntil"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+	r.Post("/", create)
+
+	r.Get("/summary", summary)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", show)
+		r.Delete("/", destroy)
+		r.Put("/", update)
+	})
+}
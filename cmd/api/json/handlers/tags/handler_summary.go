@@ -0,0 +1,81 @@
+package tags
+
+import (
+	"encoding/json"
+	"financo/lib/nullable"
+	"financo/server/tags/queries/summary_query"
+	"log"
+	"net/http"
+	"time"
+)
+
+func summary(w http.ResponseWriter, r *http.Request) {
+	var (
+		from nullable.Type[time.Time]
+		to   nullable.Type[time.Time]
+	)
+
+	if r.URL.Query().Has(executedFromKey) {
+		raw, err := time.Parse(time.RFC3339, r.URL.Query().Get(executedFromKey))
+		if err != nil {
+			log.Println("failed to parsed from", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+
+		from = nullable.New(raw)
+	}
+
+	if r.URL.Query().Has(executedUntilKey) {
+		raw, err := time.Parse(time.RFC3339, r.URL.Query().Get(executedUntilKey))
+		if err != nil {
+			log.Println("failed to parsed to", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+
+		to = nullable.New(raw)
+	}
+
+	res, err := summary_query.New(from, to).Find(r.Context())
+	if err != nil {
+		log.Println("query failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
@@ -11,6 +11,13 @@ const (
 	executedUntilKey = "executedUntil"
 	accountKey       = "account"
 	categoryKey      = "category"
+	tagsKey          = "tags"
+	cursorKey        = "cursor"
+	limitKey         = "limit"
+	sortKey          = "sort"
+	profileKey       = "profile"
+	previewKey       = "preview"
+	formatKey        = "format"
 )
 
 func Routes(r chi.Router) {
@@ -19,9 +26,14 @@ func Routes(r chi.Router) {
 
 	r.Get("/pending", pending)
 
+	r.Post("/import", importStatement)
+
 	r.Route("/{id}", func(r chi.Router) {
 		r.Delete("/", destroy)
 		r.Put("/", Update)
+		r.Put("/tags", tag)
+
+		r.Get("/history", history)
 	})
 
 	r.Route("/for_account", for_account.Routes)
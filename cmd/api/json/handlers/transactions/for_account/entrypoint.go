@@ -7,6 +7,10 @@ const (
 	executedUntilKey = "executedUntil"
 	accountKey       = "account"
 	categoryKey      = "category"
+	tagsKey          = "tags"
+	cursorKey        = "cursor"
+	limitKey         = "limit"
+	sortKey          = "sort"
 )
 
 func Routes(r chi.Router) {
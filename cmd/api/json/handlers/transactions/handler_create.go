@@ -2,6 +2,7 @@ package transactions
 
 import (
 	"encoding/json"
+	"errors"
 	"financo/server/transactions/commands/create_command"
 	"financo/server/transactions/types/request"
 	"log"
@@ -31,6 +32,16 @@ func create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	res, err := create_command.New(req).Run(r.Context())
+	if errors.Is(err, create_command.ErrInvalidSplit) {
+		log.Println("invalid split", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusUnprocessableEntity),
+			http.StatusUnprocessableEntity,
+		)
+		return
+	}
+
 	if err != nil {
 		log.Println("command failed", err)
 		http.Error(
@@ -0,0 +1,117 @@
+package transactions
+
+import (
+	"encoding/json"
+	"financo/lib/statement"
+	"financo/server/transactions/commands/import_command"
+	"financo/server/transactions/types/request"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const (
+	maxStatementSize = 10 << 20
+)
+
+// importStatement imports the bank statement sent as the request body. The
+// import profile, the statement's account, the statement's format and whether
+// it is only a preview are given as query parameters.
+func importStatement(w http.ResponseWriter, r *http.Request) {
+	var (
+		req request.Import
+		err error
+	)
+
+	req.ProfileID, err = strconv.ParseInt(r.URL.Query().Get(profileKey), 10, 64)
+	if err != nil {
+		log.Println("failed to parse profile id", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusBadRequest),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	req.AccountID, err = strconv.ParseInt(r.URL.Query().Get(accountKey), 10, 64)
+	if err != nil {
+		log.Println("failed to parse account id", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusBadRequest),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	req.Format = statement.CSV
+
+	if r.URL.Query().Has(formatKey) {
+		req.Format, err = statement.ParseFormat(r.URL.Query().Get(formatKey))
+		if err != nil {
+			log.Println("failed to parse format", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+
+	if r.URL.Query().Has(previewKey) {
+		req.Preview, err = strconv.ParseBool(r.URL.Query().Get(previewKey))
+		if err != nil {
+			log.Println("failed to parse preview", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxStatementSize)
+	defer func() {
+		err := body.Close()
+		if err != nil {
+			log.Println("failed to close body", err)
+		}
+	}()
+
+	res, err := import_command.New(req, body).Run(r.Context())
+	if err != nil {
+		log.Println("command failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusUnprocessableEntity),
+			http.StatusUnprocessableEntity,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"financo/lib/nullable"
 	"financo/server/transactions/queries/pending_query"
+	"financo/server/transactions/types/request"
 	"log"
 	"net/http"
 	"strconv"
@@ -18,6 +19,7 @@ func pending(w http.ResponseWriter, r *http.Request) {
 
 		from nullable.Type[time.Time]
 		to   nullable.Type[time.Time]
+		tags request.TagFilter
 	)
 
 	if r.URL.Query().Has(executedFromKey) {
@@ -96,7 +98,37 @@ func pending(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	res, err := pending_query.New(from, to, accounts, categories).Find(r.Context())
+	if r.URL.Query().Has(tagsKey) {
+		parsed, err := request.ParseTagFilter(r.URL.Query().Get(tagsKey))
+		if err != nil {
+			log.Println("failed to parse tags", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusBadRequest),
+				http.StatusBadRequest,
+			)
+			return
+		}
+
+		tags = parsed
+	}
+
+	page, err := request.ParsePage(
+		r.URL.Query().Get(cursorKey),
+		r.URL.Query().Get(limitKey),
+		r.URL.Query().Get(sortKey),
+	)
+	if err != nil {
+		log.Println("failed to parse page", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusBadRequest),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	res, err := pending_query.New(from, to, accounts, categories, tags, page).Find(r.Context())
 	if err != nil {
 		log.Println("query failed", err)
 		http.Error(
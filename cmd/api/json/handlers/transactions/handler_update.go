@@ -2,6 +2,7 @@ package transactions
 
 import (
 	"encoding/json"
+	"errors"
 	"financo/server/transactions/commands/update_command"
 	"financo/server/transactions/types/request"
 	"log"
@@ -55,6 +56,16 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	res, err := update_command.New(req).Run(r.Context())
+	if errors.Is(err, update_command.ErrInvalidLeg) {
+		log.Println("invalid leg", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusUnprocessableEntity),
+			http.StatusUnprocessableEntity,
+		)
+		return
+	}
+
 	if err != nil {
 		log.Println("command failed", err)
 		http.Error(
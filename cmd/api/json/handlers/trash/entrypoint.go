@@ -0,0 +1,11 @@
+package trash
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+
+	r.Post("/{kind}/{id}/restore", restore)
+}
@@ -0,0 +1,91 @@
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"financo/models/deletion"
+	"financo/server/trash/commands/restore_command"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func restore(w http.ResponseWriter, r *http.Request) {
+	kind := deletion.Kind(chi.URLParam(r, "kind"))
+	if kind != deletion.Account && kind != deletion.Transaction {
+		log.Println("unsupported trash kind", kind)
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Println("failed to parse record id", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	res, err := restore_command.New(kind, id).Run(r.Context())
+	if errors.Is(err, restore_command.ErrNotFound) {
+		log.Println("deletion not found", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+		return
+	}
+
+	if errors.Is(err, restore_command.ErrBlocked) {
+		log.Println("restore blocked", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusConflict),
+			http.StatusConflict,
+		)
+		return
+	}
+
+	if err != nil {
+		log.Println("command failed", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	resp, err := json.Marshal(&res)
+	if err != nil {
+		log.Println("failed json Marshal", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, err = w.Write(resp)
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+}
@@ -0,0 +1,21 @@
+package webhooks
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+const deliveriesLimit = 50
+
+func Routes(r chi.Router) {
+	r.Get("/", index)
+	r.Post("/", create)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", show)
+		r.Delete("/", destroy)
+		r.Put("/", update)
+
+		r.Get("/deliveries", deliveries)
+		r.Post("/deliveries/{delivery_id}/redeliver", redeliver)
+	})
+}
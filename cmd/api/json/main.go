@@ -3,12 +3,22 @@ package main
 import (
 	"context"
 	"financo/cmd/api/json/handlers/accounts"
+	"financo/cmd/api/json/handlers/admin"
+	"financo/cmd/api/json/handlers/categorization_rules"
 	"financo/cmd/api/json/handlers/currencies"
+	"financo/cmd/api/json/handlers/events"
+	"financo/cmd/api/json/handlers/export"
 	"financo/cmd/api/json/handlers/health"
+	"financo/cmd/api/json/handlers/import_profiles"
 	"financo/cmd/api/json/handlers/my_journey"
+	"financo/cmd/api/json/handlers/recurring_transactions"
 	"financo/cmd/api/json/handlers/savings_goals"
+	"financo/cmd/api/json/handlers/search"
 	"financo/cmd/api/json/handlers/summaries"
+	"financo/cmd/api/json/handlers/tags"
 	"financo/cmd/api/json/handlers/transactions"
+	"financo/cmd/api/json/handlers/trash"
+	"financo/cmd/api/json/handlers/webhooks"
 	"fmt"
 	"log"
 	"net/http"
@@ -19,8 +29,18 @@ import (
 	"time"
 
 	accounts_broker "financo/core/scope_accounts/infrastructure/broker_handler"
+	"financo/lib/message_bus"
+	"financo/server/achievements/evaluator"
+	audit_service "financo/server/audit"
+	dead_letters_service "financo/server/dead_letters"
+	outbox_service "financo/server/outbox"
+	recurring_transactions_service "financo/server/recurring_transactions"
+	"financo/server/savings_goals/funding"
 	transactions_service "financo/server/transactions"
+	transactions_brokers "financo/server/transactions/brokers"
+	webhooks_service "financo/server/webhooks"
 	"financo/services/postgresql_database"
+	"financo/services/redis_database"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -32,25 +52,29 @@ const (
 
 func main() {
 	var (
+		// wg tracks the services publishing messages and consumersWg the bus
+		// workers consuming them, so the buses are drained only once nothing
+		// publishes anymore.
 		wg          = new(sync.WaitGroup)
+		consumersWg = new(sync.WaitGroup)
 		ctx, cancel = context.WithCancel(context.Background())
 
-		pgDBService        = postgresql_database.New()
-		accountsBroker     = accounts_broker.Initialize(wg)
-		transactionsBroker = transactions_service.NewBroker(wg)
-	)
+		pgDBService    = postgresql_database.New()
+		redisDBService = redis_database.New()
 
-	defer func() {
-		if err := accountsBroker.Shutdown(); err != nil {
-			log.Printf("failed to shutdown accounts broker: %s\n", err)
-		}
-	}()
+		accountsBroker     accounts_broker.BrokerHandler
+		transactionsBroker transactions_brokers.Broker
+	)
 
-	defer func() {
-		if err := transactionsBroker.Shutdown(); err != nil {
-			log.Printf("failed to shutdown transactions broker: %s\n", err)
-		}
-	}()
+	// The buses live in memory unless MESSAGE_BUS_DRIVER=redis, which lets
+	// several processes share the messages.
+	if redisDBService.Enabled() {
+		accountsBroker = accounts_broker.InitializeRedis(consumersWg, redisDBService.Dial)
+		transactionsBroker = transactions_service.NewRedisBroker(consumersWg, redisDBService.Dial)
+	} else {
+		accountsBroker = accounts_broker.Initialize(consumersWg)
+		transactionsBroker = transactions_service.NewBroker(consumersWg)
+	}
 
 	defer func() {
 		if err := pgDBService.Close(); err != nil {
@@ -58,9 +82,36 @@ func main() {
 		}
 	}()
 
+	message_bus.SetDeadLetterStore(dead_letters_service.NewStore())
+
+	if err := audit_service.Subscribe(accountsBroker, transactionsBroker); err != nil {
+		log.Printf("failed to subscribe audit log: %s\n", err)
+	}
+
+	if err := evaluator.Subscribe(accountsBroker, transactionsBroker); err != nil {
+		log.Printf("failed to subscribe achievements evaluator: %s\n", err)
+	}
+
+	if err := funding.Subscribe(transactionsBroker); err != nil {
+		log.Printf("failed to subscribe savings goals funding: %s\n", err)
+	}
+
+	if err := webhooks_service.Subscribe(accountsBroker, transactionsBroker); err != nil {
+		log.Printf("failed to subscribe webhooks: %s\n", err)
+	}
+
 	wg.Add(1)
 	go startHTTPServer(ctx, wg)
 
+	wg.Add(1)
+	go recurring_transactions_service.StartMaterializer(ctx, wg)
+
+	wg.Add(1)
+	go outbox_service.StartRelay(ctx, wg, accountsBroker, transactionsBroker)
+
+	wg.Add(1)
+	go webhooks_service.StartDispatcher(ctx, wg)
+
 	// Listen for termination signals
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
@@ -73,6 +124,18 @@ func main() {
 
 	wg.Wait()
 
+	if err := accountsBroker.Shutdown(); err != nil {
+		log.Printf("failed to shutdown accounts broker: %s\n", err)
+	}
+
+	if err := transactionsBroker.Shutdown(); err != nil {
+		log.Printf("failed to shutdown transactions broker: %s\n", err)
+	}
+
+	log.Println("Draining message buses...")
+
+	consumersWg.Wait()
+
 	log.Println("Shutdown complete.")
 }
 
@@ -85,12 +148,22 @@ func startHTTPServer(ctx context.Context, wg *sync.WaitGroup) {
 	router.Use(middleware.Logger)
 
 	router.Route("/accounts", accounts.Routes)
+	router.Route("/admin", admin.Routes)
+	router.Route("/categorization_rules", categorization_rules.Routes)
 	router.Route("/currencies", currencies.Routes)
+	router.Route("/events", events.Routes)
+	router.Route("/export", export.Routes)
 	router.Route("/health", health.Routes)
+	router.Route("/import_profiles", import_profiles.Routes)
 	router.Route("/my_journey", my_journey.Routes)
+	router.Route("/recurring_transactions", recurring_transactions.Routes)
 	router.Route("/savings_goals", savings_goals.Routes)
+	router.Route("/search", search.Routes)
 	router.Route("/summaries", summaries.Routes)
+	router.Route("/tags", tags.Routes)
 	router.Route("/transactions", transactions.Routes)
+	router.Route("/trash", trash.Routes)
+	router.Route("/webhooks", webhooks.Routes)
 
 	// HTTP Server configuration
 	server := &http.Server{
@@ -101,6 +174,10 @@ func startHTTPServer(ctx context.Context, wg *sync.WaitGroup) {
 		WriteTimeout:      1 * time.Second,
 	}
 
+	// Event streams stay open until the client leaves, they are ended on
+	// shutdown so the server doesn't wait for them.
+	server.RegisterOnShutdown(events.Shutdown)
+
 	// Start the HTTP server in a different goroutine
 	go func() {
 		log.Println("Starting HTTP server...")
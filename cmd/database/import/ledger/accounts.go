@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/scope_accounts/application/commands/create_command"
+	"financo/core/scope_accounts/domain/requests"
+	"financo/core/scope_accounts/infrastructure/create_account_repository"
+	"financo/lib/color"
+	"financo/lib/currency"
+	"financo/lib/icon"
+	"financo/lib/ledger"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/services/postgresql_database"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultColor color.Type = "#34baeb"
+
+// mapped is the financo account a journal account is imported into. History
+// is the system historic child of capital and debt accounts, opening balances
+// are imported against it.
+type mapped struct {
+	ID       int64
+	Kind     account.Kind
+	Currency currency.Type
+	History  int64
+}
+
+type resolver struct {
+	conn      *sql.Conn
+	timestamp time.Time
+	accounts  map[string]mapped
+	failed    map[string]error
+	created   int
+}
+
+func newResolver(conn *sql.Conn) *resolver {
+	return &resolver{
+		conn:      conn,
+		timestamp: time.Now().UTC(),
+		accounts:  make(map[string]mapped, 50),
+		failed:    make(map[string]error),
+	}
+}
+
+// resolveAll maps every account used by journal. Accounts that can't be
+// mapped are kept in failed, so the entries using them are reported.
+func (r *resolver) resolveAll(ctx context.Context, journal ledger.Journal) error {
+	currencies := accountCurrencies(journal)
+
+	for _, name := range accountNames(journal) {
+		if isOpening(name) {
+			continue
+		}
+
+		record, err := r.resolve(ctx, name, currencies[name])
+		if errors.Is(err, errUnmappable) {
+			r.failed[name] = err
+			continue
+		}
+
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to map account %s", name), err)
+		}
+
+		r.accounts[name] = record
+	}
+
+	return nil
+}
+
+var errUnmappable = errors.New("account can't be mapped")
+
+// resolve finds or creates the financo account for the journal account name.
+//
+//   - Assets are capital accounts, savings when the name mentions savings.
+//   - Liabilities are debt accounts, credit when the name mentions a card or
+//     credit, loans otherwise.
+//   - Income and Expenses are external accounts, the first component below
+//     the root is the account and the rest of them its child.
+//
+// Capital and debt accounts flatten every component below the root into the
+// account name.
+func (r *resolver) resolve(ctx context.Context, name string, code string) (mapped, error) {
+	var (
+		components = strings.Split(name, ":")
+		root       = components[0]
+		rest       = components[1:]
+		kind       account.Kind
+	)
+
+	switch root {
+	default:
+		return mapped{}, fmt.Errorf("%w: unsupported root %s", errUnmappable, root)
+	case "Assets":
+		kind = account.CapitalNormal
+
+		if strings.Contains(name, "Saving") {
+			kind = account.CapitalSavings
+		}
+	case "Liabilities":
+		kind = account.DebtLoan
+
+		if strings.Contains(name, "Card") || strings.Contains(name, "Credit") {
+			kind = account.DebtCredit
+		}
+	case "Income":
+		kind = account.ExternalIncome
+	case "Expenses":
+		kind = account.ExternalExpense
+	}
+
+	var cur currency.Type
+
+	if err := cur.Scan(code); err != nil {
+		return mapped{}, fmt.Errorf("%w: unsupported currency \"%s\"", errUnmappable, code)
+	}
+
+	if len(rest) == 0 {
+		rest = []string{root}
+	}
+
+	if !account.IsExternal(kind) || len(rest) == 1 {
+		return r.findOrCreate(ctx, kind, cur, label(rest))
+	}
+
+	parent, err := r.findOrCreate(ctx, kind, cur, label(rest[:1]))
+	if err != nil {
+		return parent, err
+	}
+
+	return r.findOrCreateChild(ctx, parent, label(rest[1:]))
+}
+
+func (r *resolver) findOrCreate(ctx context.Context, kind account.Kind, cur currency.Type, name string) (mapped, error) {
+	record, err := r.find(ctx, nullable.Type[int64]{}, kind, name)
+	if err == nil {
+		return r.withHistory(ctx, record)
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return record, err
+	}
+
+	res, err := create_command.New(
+		requests.Create{
+			Kind:     kind,
+			Currency: cur,
+			Name:     name,
+			Color:    defaultColor,
+			Icon:     icon.Base,
+		},
+		create_account_repository.NewPostgreSQL(postgresql_database.New()),
+	).Run(ctx)
+	if err != nil {
+		return mapped{}, err
+	}
+
+	r.created++
+
+	return r.withHistory(ctx, mapped{ID: res.ID, Kind: kind, Currency: cur})
+}
+
+// findOrCreateChild creates children the same way accounts update does, they
+// take the kind, currency and color of their parent.
+func (r *resolver) findOrCreateChild(ctx context.Context, parent mapped, name string) (mapped, error) {
+	record, err := r.find(ctx, nullable.New(parent.ID), parent.Kind, name)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return record, err
+	}
+
+	child := requests.UpdateChildToAccountRecord(
+		account.Record{ID: parent.ID, Kind: parent.Kind, Currency: parent.Currency, Color: defaultColor},
+		requests.UpdateChild{Name: name, Icon: icon.Base},
+		r.timestamp,
+	)
+
+	err = r.conn.QueryRowContext(
+		ctx,
+		`
+		INSERT INTO accounts(
+			parent_id,
+			kind,
+			currency,
+			name,
+			description,
+			color,
+			icon,
+			capital,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+		`,
+		child.ParentID,
+		child.Kind,
+		child.Currency,
+		child.Name,
+		child.Description,
+		child.Color,
+		child.Icon,
+		child.Capital,
+		child.CreatedAt,
+		child.UpdatedAt,
+	).Scan(&child.ID)
+	if err != nil {
+		return mapped{}, err
+	}
+
+	r.created++
+
+	return mapped{ID: child.ID, Kind: child.Kind, Currency: child.Currency}, nil
+}
+
+func (r *resolver) find(
+	ctx context.Context,
+	parentID nullable.Type[int64],
+	kind account.Kind,
+	name string,
+) (mapped, error) {
+	var record mapped
+
+	err := r.conn.QueryRowContext(
+		ctx,
+		`
+		SELECT
+			id,
+			kind,
+			currency
+		FROM accounts
+		WHERE deleted_at IS NULL
+			AND parent_id IS NOT DISTINCT FROM $1
+			AND kind = $2
+			AND name = $3
+		ORDER BY id
+		LIMIT 1
+		`,
+		parentID,
+		kind,
+		name,
+	).Scan(&record.ID, &record.Kind, &record.Currency)
+
+	return record, err
+}
+
+func (r *resolver) withHistory(ctx context.Context, record mapped) (mapped, error) {
+	if account.IsExternal(record.Kind) {
+		return record, nil
+	}
+
+	err := r.conn.QueryRowContext(
+		ctx,
+		`
+		SELECT id
+		FROM accounts
+		WHERE deleted_at IS NULL
+			AND parent_id = $1
+			AND kind = $2
+		ORDER BY id
+		LIMIT 1
+		`,
+		record.ID,
+		account.SystemHistoric,
+	).Scan(&record.History)
+	if err != nil {
+		return record, errors.Join(errors.New("failed to find history account"), err)
+	}
+
+	return record, nil
+}
+
+// accountNames returns every account declared or used by journal in the
+// order they appear.
+func accountNames(journal ledger.Journal) []string {
+	var (
+		out  = make([]string, 0, len(journal.Accounts))
+		seen = make(map[string]bool, len(journal.Accounts))
+	)
+
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+
+	for _, acc := range journal.Accounts {
+		add(acc.Name)
+	}
+
+	for _, t := range journal.Transactions {
+		for _, posting := range t.Postings {
+			add(posting.Account)
+		}
+	}
+
+	return out
+}
+
+// accountCurrencies returns the currency of every account, the declared one
+// or the currency of its first posting.
+func accountCurrencies(journal ledger.Journal) map[string]string {
+	out := make(map[string]string, len(journal.Accounts))
+
+	for _, acc := range journal.Accounts {
+		if acc.Currency != "" {
+			out[acc.Name] = acc.Currency
+		}
+	}
+
+	for _, t := range journal.Transactions {
+		for _, posting := range t.Postings {
+			if _, ok := out[posting.Account]; !ok && posting.Currency != "" {
+				out[posting.Account] = posting.Currency
+			}
+		}
+	}
+
+	return out
+}
+
+func isOpening(name string) bool {
+	return strings.HasPrefix(name, "Equity:Opening")
+}
+
+func label(components []string) string {
+	return strings.ReplaceAll(strings.Join(components, " "), "-", " ")
+}
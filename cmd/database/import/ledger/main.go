@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/ledger"
+	"financo/lib/outbox"
+	"financo/models/transaction"
+	"financo/server/transactions/commands/create_command"
+	"financo/server/transactions/types/message"
+	"financo/services/postgresql_database"
+	"flag"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+func main() {
+	var (
+		ctx   = context.Background()
+		start = time.Now()
+
+		path string
+	)
+
+	flag.StringVar(&path, "file", "", "path to the ledger, hledger or beancount journal")
+	flag.Parse()
+
+	if path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("import: failed to open journal:\n\t err: %v\n", err)
+	}
+	defer file.Close()
+
+	journal, err := ledger.Parse(file)
+	if err != nil {
+		log.Fatalf("import: failed to parse journal:\n\t err: %v\n", err)
+	}
+
+	pgDBService := postgresql_database.New()
+	defer pgDBService.Close()
+
+	conn, err := pgDBService.Conn(ctx)
+	if err != nil {
+		log.Fatalf("import: failed to connect to database:\n\t err: %v\n", err)
+	}
+	defer conn.Close()
+
+	resolver := newResolver(conn)
+
+	err = resolver.resolveAll(ctx, journal)
+	if err != nil {
+		log.Fatalf("import: failed to import accounts:\n\t err: %v\n", err)
+	}
+
+	records, unmapped := mapTransactions(resolver, journal)
+
+	records, err = persist(ctx, conn, records)
+	if err != nil {
+		log.Fatalf("import: failed to import transactions:\n\t err: %v\n", err)
+	}
+
+	report(resolver, unmapped)
+
+	log.Printf(
+		"journal imported, %d accounts created, %d transactions, %d entries unmapped (took %s)\n",
+		resolver.created,
+		len(records),
+		len(unmapped),
+		time.Since(start),
+	)
+}
+
+type unmappedEntry struct {
+	Line int
+	Err  error
+}
+
+func mapTransactions(r *resolver, journal ledger.Journal) ([]transaction.Record, []unmappedEntry) {
+	var (
+		timestamp = time.Now().UTC()
+		records   = make([]transaction.Record, 0, len(journal.Transactions))
+		unmapped  = make([]unmappedEntry, 0)
+	)
+
+	for _, t := range journal.Transactions {
+		out, err := r.records(t, timestamp)
+		if err != nil {
+			unmapped = append(unmapped, unmappedEntry{Line: t.Line, Err: err})
+			continue
+		}
+
+		records = append(records, out...)
+	}
+
+	return records, unmapped
+}
+
+// persist inserts every record in a single database transaction, so a
+// failing insert doesn't leave the journal half imported.
+func persist(ctx context.Context, conn *sql.Conn, records []transaction.Record) ([]transaction.Record, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return records, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	for i, record := range records {
+		record, err = create_command.Prepare(ctx, tx, record)
+		if err != nil {
+			return records, errors.Join(errors.New("failed to prepare record"), err, tx.Rollback())
+		}
+
+		records[i], err = create_command.Persist(ctx, tx, record)
+		if err != nil {
+			return records, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+		}
+
+		err = outbox.Write(ctx, tx, message.CreatedTopic, message.Created{Record: records[i]})
+		if err != nil {
+			return records, errors.Join(errors.New("failed to write created message"), err, tx.Rollback())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return records, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return records, nil
+}
+
+func report(r *resolver, unmapped []unmappedEntry) {
+	names := make([]string, 0, len(r.failed))
+
+	for name := range r.failed {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		log.Printf("\taccount %s skipped: %v\n", name, r.failed[name])
+	}
+
+	for _, entry := range unmapped {
+		log.Printf("\tline %d skipped: %v\n", entry.Line, entry.Err)
+	}
+}
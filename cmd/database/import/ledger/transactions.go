@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"financo/lib/ledger"
+	"financo/lib/nullable"
+	"financo/models/transaction"
+	"fmt"
+	"time"
+)
+
+type leg struct {
+	account mapped
+	amount  int64
+}
+
+// records maps a journal transaction into financo transactions. Entries with
+// two postings become one transaction from the negative posting to the
+// positive one. Multi-leg entries in a single currency with only one posting
+// on one of the sides are split into one transaction per posting on the other
+// side.
+//
+// Opening balance postings are imported against the history account of the
+// account they open.
+//
+// It returns an error describing why the entry can't be mapped.
+func (r *resolver) records(t ledger.Transaction, timestamp time.Time) ([]transaction.Record, error) {
+	var (
+		legs    = make([]leg, 0, len(t.Postings))
+		opening int64
+	)
+
+	for _, posting := range t.Postings {
+		if posting.Amount == 0 {
+			continue
+		}
+
+		if isOpening(posting.Account) {
+			opening += posting.Amount
+			continue
+		}
+
+		if err, ok := r.failed[posting.Account]; ok {
+			return nil, fmt.Errorf("%s: %w", posting.Account, err)
+		}
+
+		acc := r.accounts[posting.Account]
+
+		if posting.Currency != "" && posting.Currency != string(acc.Currency) {
+			return nil, fmt.Errorf("%s: posting in %s on a %s account", posting.Account, posting.Currency, acc.Currency)
+		}
+
+		legs = append(legs, leg{account: acc, amount: posting.Amount})
+	}
+
+	if opening != 0 {
+		if len(legs) != 1 || legs[0].account.History == 0 {
+			return nil, errors.New("opening balance must open a single capital or debt account")
+		}
+
+		history := legs[0].account
+
+		history.ID = history.History
+		legs = append(legs, leg{account: history, amount: -legs[0].amount})
+	}
+
+	var (
+		negatives = make([]leg, 0, len(legs))
+		positives = make([]leg, 0, len(legs))
+		single    = true
+	)
+
+	for _, l := range legs {
+		if l.account.Currency != legs[0].account.Currency {
+			single = false
+		}
+
+		if l.amount < 0 {
+			negatives = append(negatives, l)
+		} else {
+			positives = append(positives, l)
+		}
+	}
+
+	if len(negatives) == 0 || len(positives) == 0 {
+		return nil, errors.New("entry doesn't move money between accounts")
+	}
+
+	pair := func(source leg, target leg) transaction.Record {
+		record := transaction.Record{
+			ID:           -1,
+			SourceID:     source.account.ID,
+			TargetID:     target.account.ID,
+			SourceAmount: -source.amount,
+			TargetAmount: target.amount,
+			IssuedAt:     t.Date,
+			CreatedAt:    timestamp,
+			UpdatedAt:    timestamp,
+		}
+
+		if t.Notes != "" {
+			record.Notes = nullable.New(t.Notes)
+		}
+
+		if !t.Pending {
+			record.ExecutedAt = nullable.New(t.Date)
+		}
+
+		return record
+	}
+
+	out := make([]transaction.Record, 0, len(legs)-1)
+
+	switch {
+	case len(negatives) == 1 && len(positives) == 1:
+		out = append(out, pair(negatives[0], positives[0]))
+	case !single:
+		return nil, fmt.Errorf("multi-leg entry with %d postings in several currencies", len(legs))
+	case len(negatives) == 1:
+		for _, target := range positives {
+			out = append(out, pair(leg{account: negatives[0].account, amount: -target.amount}, target))
+		}
+	case len(positives) == 1:
+		for _, source := range negatives {
+			out = append(out, pair(source, leg{account: positives[0].account, amount: -source.amount}))
+		}
+	default:
+		return nil, fmt.Errorf("multi-leg entry with %d sources and %d targets", len(negatives), len(positives))
+	}
+
+	for _, record := range out {
+		if record.SourceID == record.TargetID {
+			return nil, errors.New("entry moves money into the same account")
+		}
+	}
+
+	return out, nil
+}
@@ -80,7 +80,7 @@ var (
 				Name:        "This is not even my final form",
 				Description: nullable.New("Take the emergency fund to cover 3 months of expenses."),
 				Settings: savings_goal.Settings{
-					Position: 1,
+					Position: 2,
 					Target:   6_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
@@ -99,7 +99,7 @@ var (
 				Name:        "Inner Peace",
 				Description: nullable.New("Your emergency fund gives you 6 months of runway."),
 				Settings: savings_goal.Settings{
-					Position: 2,
+					Position: 3,
 					Target:   12_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
@@ -118,7 +118,7 @@ var (
 				Name:        "Harmony within, Hurricane without",
 				Description: nullable.New("Now your emergency fund covers for a year's worth of expenses."),
 				Settings: savings_goal.Settings{
-					Position: 3,
+					Position: 4,
 					Target:   24_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
@@ -137,7 +137,7 @@ var (
 				Name:        "Upgrades for my Desktop",
 				Description: nullable.Type[string]{},
 				Settings: savings_goal.Settings{
-					Position: 4,
+					Position: 5,
 					Target:   1_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
@@ -156,7 +156,7 @@ var (
 				Name:        "Investment for the Studio",
 				Description: nullable.New("Buying some hardware to create games better."),
 				Settings: savings_goal.Settings{
-					Position: 5,
+					Position: 6,
 					Target:   6_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
@@ -175,7 +175,7 @@ var (
 				Name:        "Honeymoon",
 				Description: nullable.New("A little treat for my spouse."),
 				Settings: savings_goal.Settings{
-					Position: 6,
+					Position: 7,
 					Target:   20_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
@@ -194,7 +194,7 @@ var (
 				Name:        "To the forest!",
 				Description: nullable.New("For that mountain cabin."),
 				Settings: savings_goal.Settings{
-					Position: 6,
+					Position: 8,
 					Target:   100_000_00,
 					Saved:    0,
 					Currency: currency.EUR,
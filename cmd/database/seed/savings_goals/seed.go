@@ -45,6 +45,12 @@ func SeedSavingsGoals(ctx context.Context, conn *sql.Conn, timestamp time.Time)
 		goal.CreatedAt = timestamp
 		goal.UpdatedAt = timestamp
 
+		// Achieved goals keep holding what they saved, the active ones are
+		// funded from what is left, like the funding of the API does.
+		if goal.AchievedAt.Valid && !goal.DeletedAt.Valid {
+			savings[goal.Settings.Currency] -= goal.Settings.Saved
+		}
+
 		if !goal.AchievedAt.Valid && !goal.DeletedAt.Valid && saved > 0 {
 			if saved >= goal.Settings.Target {
 				goal.Settings.Saved = goal.Settings.Target
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"financo/lib/exchange"
+	"financo/server/exchange_rates/commands/import_command"
+	"financo/services/postgresql_database"
+	"flag"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		ctx   = context.Background()
+		start = time.Now()
+
+		path string
+	)
+
+	flag.StringVar(&path, "file", "", "path to the ECB eurofxref-hist XML or CSV file")
+	flag.Parse()
+
+	if path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("import: failed to open rates:\n\t err: %v\n", err)
+	}
+	defer file.Close()
+
+	rates, err := exchange.ParseECB(file)
+	if err != nil {
+		log.Fatalf("import: failed to parse rates:\n\t err: %v\n", err)
+	}
+
+	pgDBService := postgresql_database.New()
+	defer pgDBService.Close()
+
+	res, err := import_command.New(rates).Run(ctx)
+	if err != nil {
+		log.Fatalf("import: failed to import rates:\n\t err: %v\n", err)
+	}
+
+	log.Printf("exchange rates imported, %d rates, %d skipped (took %s)\n", res.Imported, res.Skipped, time.Since(start))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"financo/lib/ledger"
+	"financo/server/export/queries/ledger_query"
+	"financo/services/postgresql_database"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		ctx   = context.Background()
+		start = time.Now()
+
+		format string
+		path   string
+		out    io.Writer = os.Stdout
+	)
+
+	flag.StringVar(&format, "format", "ledger", "journal format: ledger, hledger or beancount")
+	flag.StringVar(&path, "output", "", "file the journal is written to, defaults to stdout")
+	flag.Parse()
+
+	parsed, err := ledger.ParseFormat(format)
+	if err != nil {
+		log.Fatalf("export: %v\n", err)
+	}
+
+	pgDBService := postgresql_database.New()
+	defer pgDBService.Close()
+
+	journal, err := ledger_query.New().Find(ctx)
+	if err != nil {
+		log.Fatalf("export: failed to build journal:\n\t err: %v\n", err)
+	}
+
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			log.Fatalf("export: failed to create output:\n\t err: %v\n", err)
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	err = ledger.Write(out, parsed, journal)
+	if err != nil {
+		log.Fatalf("export: failed to write journal:\n\t err: %v\n", err)
+	}
+
+	log.Printf(
+		"exported %d accounts and %d transactions (took %s)\n",
+		len(journal.Accounts),
+		len(journal.Transactions),
+		time.Since(start),
+	)
+}
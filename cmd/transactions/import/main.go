@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"financo/lib/statement"
+	"financo/server/transactions/commands/import_command"
+	"financo/server/transactions/types/request"
+	"financo/services/postgresql_database"
+	"flag"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		ctx   = context.Background()
+		start = time.Now()
+
+		req    request.Import
+		path   string
+		format string
+	)
+
+	flag.Int64Var(&req.ProfileID, "profile", 0, "import profile used to parse the statement")
+	flag.Int64Var(&req.AccountID, "account", 0, "account the statement belongs to")
+	flag.BoolVar(&req.Preview, "preview", false, "validate the statement without importing it")
+	flag.StringVar(&path, "file", "", "path to the statement")
+	flag.StringVar(&format, "format", "csv", "statement format: csv, ofx, qfx or camt053")
+	flag.Parse()
+
+	if path == "" || req.ProfileID == 0 || req.AccountID == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	parsed, err := statement.ParseFormat(format)
+	if err != nil {
+		log.Fatalf("import: %v\n", err)
+	}
+
+	req.Format = parsed
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("import: failed to open statement:\n\t err: %v\n", err)
+	}
+	defer file.Close()
+
+	pgDBService := postgresql_database.New()
+	defer pgDBService.Close()
+
+	res, err := import_command.New(req, file).Run(ctx)
+	if err != nil {
+		log.Fatalf("import: failed to import statement:\n\t err: %v\n", err)
+	}
+
+	for i, entry := range res.Entries {
+		log.Printf(
+			"\t%d: %s %d -> %d %d %s\n",
+			i+1,
+			entry.IssuedAt.Format(time.DateOnly),
+			entry.SourceID,
+			entry.TargetID,
+			entry.SourceAmount,
+			entry.Notes.Val,
+		)
+	}
+
+	if req.Preview {
+		log.Printf("statement previewed, %d entries, %d duplicated (took %s)\n", len(res.Entries), res.Duplicated, time.Since(start))
+		return
+	}
+
+	log.Printf("statement imported, %d transactions, %d duplicated (took %s)\n", len(res.Transactions), res.Duplicated, time.Since(start))
+}
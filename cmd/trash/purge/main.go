@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"financo/server/trash/commands/purge_command"
+	"financo/services/postgresql_database"
+	"flag"
+	"log"
+	"time"
+)
+
+func main() {
+	var (
+		ctx   = context.Background()
+		start = time.Now()
+
+		retention time.Duration
+	)
+
+	flag.DurationVar(&retention, "retention", 30*24*time.Hour, "how long deleted records are kept before being purged")
+	flag.Parse()
+
+	pgDBService := postgresql_database.New()
+	defer pgDBService.Close()
+
+	res, err := purge_command.New(retention).Run(ctx)
+	if err != nil {
+		log.Fatalf("purge: failed to purge trash:\n\t err: %v\n", err)
+	}
+
+	for _, failure := range res.Failed {
+		log.Printf("purge: failed to purge deletion %d:\n\t err: %s\n", failure.ID, failure.Error)
+	}
+
+	log.Printf("trash purged, %d deletions, %d failed (took %s)\n", res.Purged, len(res.Failed), time.Since(start))
+}
@@ -3,8 +3,6 @@ package create_command
 import (
 	"context"
 	"financo/core/domain/commands"
-	"financo/core/scope_accounts/domain/brokers"
-	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
 	"financo/core/scope_accounts/domain/requests"
 	"financo/core/scope_accounts/domain/responses"
@@ -12,20 +10,17 @@ import (
 )
 
 type command struct {
-	req    requests.Create
-	repo   repositories.CreateAccountRepository
-	broker brokers.CreatedBroker
+	req  requests.Create
+	repo repositories.CreateAccountRepository
 }
 
 func New(
 	req requests.Create,
 	repo repositories.CreateAccountRepository,
-	broker brokers.CreatedBroker,
 ) commands.Command[responses.Created] {
 	return &command{
-		req:    req,
-		repo:   repo,
-		broker: broker,
+		req:  req,
+		repo: repo,
 	}
 }
 
@@ -43,10 +38,5 @@ func (c *command) Run(ctx context.Context) (responses.Created, error) {
 		return responses.Created{}, err
 	}
 
-	err = c.broker.Publish(messages.Created{Record: record})
-	if err != nil {
-		return responses.Created{}, err
-	}
-
 	return responses.Created{ID: record.ID, Name: record.Name}, nil
 }
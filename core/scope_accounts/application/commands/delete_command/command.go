@@ -3,28 +3,23 @@ package delete_command
 import (
 	"context"
 	"financo/core/domain/commands"
-	"financo/core/scope_accounts/domain/brokers"
-	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
 	"financo/core/scope_accounts/domain/requests"
 	"financo/core/scope_accounts/domain/responses"
 )
 
 type command struct {
-	req    requests.Delete
-	repo   repositories.DeleteAccountRepository
-	broker brokers.DeletedBroker
+	req  requests.Delete
+	repo repositories.DeleteAccountRepository
 }
 
 func New(
 	req requests.Delete,
 	repo repositories.DeleteAccountRepository,
-	broker brokers.DeletedBroker,
 ) commands.Command[responses.Deleted] {
 	return &command{
-		req:    req,
-		repo:   repo,
-		broker: broker,
+		req:  req,
+		repo: repo,
 	}
 }
 
@@ -34,12 +29,5 @@ func (c *command) Run(ctx context.Context) (responses.Deleted, error) {
 		return responses.Deleted{}, err
 	}
 
-	res := responses.Deleted{ID: record.ID, Name: record.Name}
-
-	err = c.broker.Publish(messages.Deleted{Record: record})
-	if err != nil {
-		return res, err
-	}
-
-	return res, nil
+	return responses.Deleted{ID: record.ID, Name: record.Name}, nil
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"financo/core/domain/commands"
-	"financo/core/scope_accounts/domain/brokers"
-	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
 	"financo/core/scope_accounts/domain/requests"
 	"financo/core/scope_accounts/domain/responses"
@@ -15,20 +13,17 @@ import (
 )
 
 type command struct {
-	req    requests.Update
-	repo   repositories.UpdateAccountWithHistoryRepository
-	broker brokers.UpdatedBroker
+	req  requests.Update
+	repo repositories.UpdateAccountWithHistoryRepository
 }
 
 func New(
 	req requests.Update,
 	repo repositories.UpdateAccountWithHistoryRepository,
-	broker brokers.UpdatedBroker,
 ) commands.Command[responses.Detailed] {
 	return &command{
-		req:    req,
-		repo:   repo,
-		broker: broker,
+		req:  req,
+		repo: repo,
 	}
 }
 
@@ -60,6 +55,7 @@ func (c *command) Run(ctx context.Context) (responses.Detailed, error) {
 	}
 
 	res, err = c.repo.SaveWithHistory(ctx, repositories.SaveAccountWithHistoryArgs{
+		Previous:    records.Record,
 		Record:      record,
 		History:     records.History,
 		Transaction: history,
@@ -68,13 +64,5 @@ func (c *command) Run(ctx context.Context) (responses.Detailed, error) {
 		return res, err
 	}
 
-	err = c.broker.Publish(messages.Updated{
-		Previous: records.Record,
-		Current:  record,
-	})
-	if err != nil {
-		return res, err
-	}
-
 	return res, nil
 }
@@ -5,8 +5,6 @@ import (
 	"context"
 	"errors"
 	"financo/core/domain/commands"
-	"financo/core/scope_accounts/domain/brokers"
-	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
 	"financo/core/scope_accounts/domain/requests"
 	"financo/core/scope_accounts/domain/responses"
@@ -16,20 +14,17 @@ import (
 )
 
 type command struct {
-	req    requests.Update
-	repo   repositories.UpdateAccountWithChildrenRepository
-	broker brokers.UpdatedBroker
+	req  requests.Update
+	repo repositories.UpdateAccountWithChildrenRepository
 }
 
 func New(
 	req requests.Update,
 	repo repositories.UpdateAccountWithChildrenRepository,
-	broker brokers.UpdatedBroker,
 ) commands.Command[responses.Detailed] {
 	return &command{
-		req:    req,
-		repo:   repo,
-		broker: broker,
+		req:  req,
+		repo: repo,
 	}
 }
 
@@ -77,6 +72,7 @@ func (c *command) Run(ctx context.Context) (responses.Detailed, error) {
 	}
 
 	res, err = c.repo.SaveWithChildren(ctx, repositories.SaveAccountWithChildrenArgs{
+		Previous: records.Record,
 		Record:   record,
 		Children: children,
 	})
@@ -84,13 +80,5 @@ func (c *command) Run(ctx context.Context) (responses.Detailed, error) {
 		return res, err
 	}
 
-	err = c.broker.Publish(messages.Updated{
-		Previous: records.Record,
-		Current:  record,
-	})
-	if err != nil {
-		return res, err
-	}
-
 	return res, nil
 }
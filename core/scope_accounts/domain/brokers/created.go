@@ -6,6 +6,7 @@ import (
 )
 
 type CreatedBroker interface {
-	Subscribe(consumer message_bus.Consumer[messages.Created]) error
+	Subscribe(consumer message_bus.Consumer[messages.Created], opts ...message_bus.Option) error
+	Unsubscribe(name string) error
 	Publish(message messages.Created) error
 }
@@ -6,6 +6,7 @@ import (
 )
 
 type DeletedBroker interface {
-	Subscribe(consumer message_bus.Consumer[messages.Deleted]) error
+	Subscribe(consumer message_bus.Consumer[messages.Deleted], opts ...message_bus.Option) error
+	Unsubscribe(name string) error
 	Publish(message messages.Deleted) error
 }
@@ -6,6 +6,7 @@ import (
 )
 
 type UpdatedBroker interface {
-	Subscribe(consumer message_bus.Consumer[messages.Updated]) error
+	Subscribe(consumer message_bus.Consumer[messages.Updated], opts ...message_bus.Option) error
+	Unsubscribe(name string) error
 	Publish(message messages.Updated) error
 }
@@ -2,8 +2,24 @@ package messages
 
 import (
 	"financo/models/account"
+	"strconv"
 )
 
+// CreatedTopic is the outbox topic of [Created] messages
+const CreatedTopic = "account_created"
+
 type Created struct {
 	Record account.Record
+	// RequestID identifies the HTTP request that produced the message, it is
+	// empty for background jobs.
+	RequestID string
+	// OutboxID is the id of the outbox message the message was relayed
+	// from, it orders the messages of every topic.
+	OutboxID int64
+}
+
+// AccountKey returns the id of the created account, so the messages of an
+// account can be consumed in the order they were published.
+func (m Created) AccountKey() string {
+	return strconv.FormatInt(m.Record.ID, 10)
 }
@@ -2,8 +2,20 @@ package messages
 
 import (
 	"financo/models/account"
+	"strconv"
 )
 
+// DeletedTopic is the outbox topic of [Deleted] messages
+const DeletedTopic = "account_deleted"
+
 type Deleted struct {
-	Record account.Record
+	Record    account.Record
+	RequestID string
+	OutboxID  int64
+}
+
+// AccountKey returns the id of the deleted account, so the messages of an
+// account can be consumed in the order they were published.
+func (m Deleted) AccountKey() string {
+	return strconv.FormatInt(m.Record.ID, 10)
 }
@@ -2,9 +2,21 @@ package messages
 
 import (
 	"financo/models/account"
+	"strconv"
 )
 
+// UpdatedTopic is the outbox topic of [Updated] messages
+const UpdatedTopic = "account_updated"
+
 type Updated struct {
-	Previous account.Record
-	Current  account.Record
+	Previous  account.Record
+	Current   account.Record
+	RequestID string
+	OutboxID  int64
+}
+
+// AccountKey returns the id of the updated account, so the messages of an
+// account can be consumed in the order they were published.
+func (m Updated) AccountKey() string {
+	return strconv.FormatInt(m.Current.ID, 10)
 }
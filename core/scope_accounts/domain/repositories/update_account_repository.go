@@ -20,12 +20,14 @@ type AccountWithChildren struct {
 }
 
 type SaveAccountWithHistoryArgs struct {
+	Previous    account.Record
 	Record      account.Record
 	History     account.Record
 	Transaction nullable.Type[transaction.Record]
 }
 
 type SaveAccountWithChildrenArgs struct {
+	Previous account.Record
 	Record   account.Record
 	Children []account.Record
 }
@@ -7,6 +7,7 @@ import (
 	"financo/core/scope_accounts/infrastructure/created_broker"
 	"financo/core/scope_accounts/infrastructure/deleted_broker"
 	"financo/core/scope_accounts/infrastructure/updated_broker"
+	"financo/lib/message_bus"
 	"fmt"
 	"sync"
 )
@@ -56,6 +57,27 @@ func Initialize(wg *sync.WaitGroup) BrokerHandler {
 	return instance
 }
 
+// InitializeRedis is like [Initialize] but the brokers are backed by Redis
+// streams, so every process using them shares the messages.
+func InitializeRedis(wg *sync.WaitGroup, dial message_bus.Dialer) BrokerHandler {
+	if instance != nil {
+		return instance
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	instance = &handler{
+		ctx:           ctx,
+		cancel:        cancel,
+		wg:            wg,
+		createdBroker: created_broker.NewRedis(ctx, wg, dial),
+		deletedBroker: deleted_broker.NewRedis(ctx, wg, dial),
+		updatedBroker: updated_broker.NewRedis(ctx, wg, dial),
+	}
+
+	return instance
+}
+
 func Instance() (BrokerHandler, error) {
 	if instance == nil {
 		return nil, ErrUninitialized
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"financo/core/domain/databases"
+	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
 	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
 	"financo/models/account"
 )
 
@@ -51,6 +54,14 @@ func (r *repository) Save(ctx context.Context, args repositories.CreateAccountSa
 		}
 	}
 
+	err = outbox.Write(ctx, tx, messages.CreatedTopic, messages.Created{
+		Record:    record,
+		RequestID: request_id.FromContext(ctx),
+	})
+	if err != nil {
+		return record, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return record, err
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"financo/core/domain/databases"
+	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
 	"financo/models/account"
+	"financo/models/deletion"
 	"time"
 )
 
@@ -36,12 +40,17 @@ func (r *repository) SoftDelete(ctx context.Context, id int64) (account.Record,
 	}
 	defer tx.Rollback()
 
-	deleted, err := r.softDeleteAccounts(ctx, tx, id)
+	deletionID, err := r.createDeletion(ctx, tx, id)
 	if err != nil {
 		return record, err
 	}
 
-	err = r.softDeleteTransactions(ctx, tx, deleted)
+	deleted, err := r.softDeleteAccounts(ctx, tx, deletionID, id)
+	if err != nil {
+		return record, err
+	}
+
+	err = r.softDeleteTransactions(ctx, tx, deletionID, deleted)
 	if err != nil {
 		return record, err
 	}
@@ -51,10 +60,32 @@ func (r *repository) SoftDelete(ctx context.Context, id int64) (account.Record,
 		return record, err
 	}
 
-	return record, nil
+	err = outbox.Write(ctx, tx, messages.DeletedTopic, messages.Deleted{
+		Record:    record,
+		RequestID: request_id.FromContext(ctx),
+	})
+	if err != nil {
+		return record, err
+	}
+
+	return record, tx.Commit()
 }
 
-func (r *repository) softDeleteAccounts(ctx context.Context, tx *sql.Tx, id int64) ([]int64, error) {
+func (r *repository) createDeletion(ctx context.Context, tx *sql.Tx, id int64) (int64, error) {
+	var deletionID int64
+
+	err := tx.QueryRowContext(
+		ctx,
+		"INSERT INTO deletions (kind, record_id, deleted_at) VALUES ($1, $2, $3) RETURNING id",
+		deletion.Account,
+		id,
+		r.timestamp,
+	).Scan(&deletionID)
+
+	return deletionID, err
+}
+
+func (r *repository) softDeleteAccounts(ctx context.Context, tx *sql.Tx, deletionID, id int64) ([]int64, error) {
 	var (
 		output  = make([]int64, 0, 10)
 		deleted int64
@@ -64,12 +95,13 @@ func (r *repository) softDeleteAccounts(ctx context.Context, tx *sql.Tx, id int6
 		ctx,
 		`
 		UPDATE accounts
-		SET deleted_at = $1, updated_at = $1
+		SET deleted_at = $1, updated_at = $1, deletion_id = $3
 		WHERE (id = $2 OR parent_id = $2) AND deleted_at IS NULL
 		RETURNING id
 		`,
 		r.timestamp,
 		id,
+		deletionID,
 	)
 	if err != nil {
 		return output, err
@@ -88,16 +120,43 @@ func (r *repository) softDeleteAccounts(ctx context.Context, tx *sql.Tx, id int6
 	return output, nil
 }
 
-func (r *repository) softDeleteTransactions(ctx context.Context, tx *sql.Tx, ids []int64) error {
+// softDeleteTransactions deletes the transactions of the accounts, splits are
+// deleted as a whole along with every leg of them.
+func (r *repository) softDeleteTransactions(ctx context.Context, tx *sql.Tx, deletionID int64, ids []int64) error {
 	_, err := tx.ExecContext(
 		ctx,
 		`
 		UPDATE transactions
-		SET deleted_at = $1, updated_at = $1
-		WHERE (source_id = ANY ($2) OR target_id = ANY ($2)) AND deleted_at IS NULL
+		SET deleted_at = $1, updated_at = $1, deletion_id = $3
+		WHERE deleted_at IS NULL
+			AND (
+				source_id = ANY ($2)
+				OR target_id = ANY ($2)
+				OR split_id IN (
+					SELECT split_id
+					FROM transactions
+					WHERE (source_id = ANY ($2) OR target_id = ANY ($2)) AND split_id IS NOT NULL
+				)
+			)
 		`,
 		r.timestamp,
 		ids,
+		deletionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`
+		UPDATE transaction_splits
+		SET deleted_at = $1, updated_at = $1
+		WHERE deleted_at IS NULL
+			AND id IN (SELECT split_id FROM transactions WHERE deletion_id = $2)
+		`,
+		r.timestamp,
+		deletionID,
 	)
 
 	return err
@@ -0,0 +1,16 @@
+package deleted_broker
+
+import (
+	"context"
+	"financo/core/scope_accounts/domain/brokers"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/lib/message_bus"
+	"sync"
+)
+
+// NewRedis returns a broker backed by a Redis stream, so every process using
+// it shares the messages, see [message_bus.NewRedis]. The bus is closed once
+// ctx is canceled.
+func NewRedis(ctx context.Context, wg *sync.WaitGroup, dial message_bus.Dialer) brokers.DeletedBroker {
+	return newBroker(ctx, wg, message_bus.NewRedis[messages.Deleted](wg, messages.DeletedTopic, dial))
+}
@@ -3,9 +3,12 @@ package update_account_repository
 import (
 	"context"
 	"financo/core/domain/databases"
+	"financo/core/scope_accounts/domain/messages"
 	"financo/core/scope_accounts/domain/repositories"
 	"financo/core/scope_accounts/domain/responses"
 	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
 	"financo/models/account"
 	"financo/models/transaction"
 )
@@ -123,6 +126,15 @@ func (r *repository) SaveWithChildren(
 		}
 	}
 
+	err = outbox.Write(ctx, tx, messages.UpdatedTopic, messages.Updated{
+		Previous:  args.Previous,
+		Current:   args.Record,
+		RequestID: request_id.FromContext(ctx),
+	})
+	if err != nil {
+		return res, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return res, err
@@ -170,6 +182,15 @@ func (r *repository) SaveWithHistory(
 		return res, err
 	}
 
+	err = outbox.Write(ctx, tx, messages.UpdatedTopic, messages.Updated{
+		Previous:  args.Previous,
+		Current:   args.Record,
+		RequestID: request_id.FromContext(ctx),
+	})
+	if err != nil {
+		return res, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return res, err
@@ -0,0 +1,64 @@
+package updated_broker
+
+import (
+	"context"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/lib/message_bus"
+	"fmt"
+	"log"
+	"sync"
+)
+
+type broker struct {
+	ctx context.Context
+	wg  *sync.WaitGroup
+	bus message_bus.Bus[messages.Updated]
+}
+
+// newBroker returns a broker publishing to bus, the bus is closed once ctx is
+// canceled so its workers stop before wg is done.
+func newBroker(ctx context.Context, wg *sync.WaitGroup, bus message_bus.Bus[messages.Updated]) *broker {
+	b := &broker{
+		ctx: ctx,
+		wg:  wg,
+		bus: bus,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		if err := b.bus.Close(); err != nil {
+			log.Printf("updated_broker: failed to close bus: %s\n", err)
+		}
+	}()
+
+	return b
+}
+
+func (b *broker) Subscribe(consumer message_bus.Consumer[messages.Updated], opts ...message_bus.Option) error {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	select {
+	case <-b.ctx.Done():
+		return fmt.Errorf("updated_broker: failed to subscribe: %s", b.ctx.Err())
+	default:
+		return b.bus.Subscribe(consumer, opts...)
+	}
+}
+
+func (b *broker) Unsubscribe(name string) error {
+	return b.bus.Unsubscribe(name)
+}
+
+func (b *broker) Publish(message messages.Updated) error {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	select {
+	case <-b.ctx.Done():
+		return fmt.Errorf("updated_broker: failed to publish: %s", b.ctx.Err())
+	default:
+		return b.bus.Publish(message)
+	}
+}
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    source_id BIGINT CONSTRAINT recurring_transaction_source_reference REFERENCES accounts (id),
+    target_id BIGINT CONSTRAINT recurring_transaction_target_reference REFERENCES accounts (id),
+    source_amount BIGINT NOT NULL,
+    target_amount BIGINT NOT NULL,
+    notes TEXT,
+    frequency VARCHAR NOT NULL,
+    frequency_interval INT NOT NULL DEFAULT 1,
+    frequency_day INT NOT NULL DEFAULT 0,
+    starts_at DATE NOT NULL,
+    ends_at DATE,
+    materialized_until DATE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX recurring_transaction_source_reference_index ON recurring_transactions (source_id);
+
+CREATE INDEX recurring_transaction_target_reference_index ON recurring_transactions (target_id);
+
+CREATE INDEX recurring_transaction_materialized_until_index ON recurring_transactions (materialized_until);
+
+CREATE INDEX recurring_transaction_deleted_at_on_recurring_transactions_index ON recurring_transactions (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX recurring_transaction_source_reference_index;
+
+DROP INDEX recurring_transaction_target_reference_index;
+
+DROP INDEX recurring_transaction_materialized_until_index;
+
+DROP INDEX recurring_transaction_deleted_at_on_recurring_transactions_index;
+
+DROP TABLE IF EXISTS recurring_transactions;
+-- +goose StatementEnd
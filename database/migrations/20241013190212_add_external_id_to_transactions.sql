@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions
+    ADD COLUMN statement_account_id BIGINT CONSTRAINT transaction_statement_account_reference REFERENCES accounts (id),
+    ADD COLUMN external_id VARCHAR;
+
+CREATE UNIQUE INDEX transaction_external_id_per_account_index ON transactions (statement_account_id, external_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX transaction_external_id_per_account_index;
+
+ALTER TABLE transactions
+    DROP COLUMN external_id,
+    DROP COLUMN statement_account_id;
+-- +goose StatementEnd
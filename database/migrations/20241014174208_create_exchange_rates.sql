@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    date DATE NOT NULL,
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL CONSTRAINT exchange_rate_positive_rate CHECK (rate > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX exchange_rate_date_from_to_on_exchange_rates_index ON exchange_rates (date, from_currency, to_currency);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX exchange_rate_date_from_to_on_exchange_rates_index;
+
+DROP TABLE IF EXISTS exchange_rates;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    source_id BIGINT NOT NULL CONSTRAINT transaction_split_source_reference REFERENCES accounts (id),
+    source_amount BIGINT NOT NULL,
+    notes TEXT,
+    issued_at DATE NOT NULL,
+    executed_at DATE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX transaction_split_source_reference_index ON transaction_splits (source_id);
+
+ALTER TABLE transactions
+    ADD COLUMN split_id BIGINT CONSTRAINT transaction_split_reference REFERENCES transaction_splits (id);
+
+CREATE INDEX transaction_split_reference_index ON transactions (split_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX transaction_split_reference_index;
+
+ALTER TABLE transactions
+    DROP COLUMN split_id;
+
+DROP INDEX transaction_split_source_reference_index;
+
+DROP TABLE IF EXISTS transaction_splits;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name TEXT NOT NULL,
+    color VARCHAR(7) NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX tag_name_on_tags_index ON tags (lower(name)) WHERE deleted_at IS NULL;
+
+CREATE INDEX tag_deleted_at_on_tags_index ON tags (deleted_at);
+
+CREATE TABLE IF NOT EXISTS transaction_tags (
+    transaction_id BIGINT NOT NULL CONSTRAINT transaction_tag_transaction_reference REFERENCES transactions (id),
+    tag_id BIGINT NOT NULL CONSTRAINT transaction_tag_tag_reference REFERENCES tags (id),
+    PRIMARY KEY (transaction_id, tag_id)
+);
+
+CREATE INDEX transaction_tag_tag_reference_index ON transaction_tags (tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX transaction_tag_tag_reference_index;
+
+DROP TABLE IF EXISTS transaction_tags;
+
+DROP INDEX tag_deleted_at_on_tags_index;
+
+DROP INDEX tag_name_on_tags_index;
+
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name TEXT NOT NULL,
+    position INT NOT NULL,
+    condition JSONB NOT NULL,
+    action JSONB NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX categorization_rule_position_on_categorization_rules_index ON categorization_rules (position);
+
+CREATE INDEX categorization_rule_deleted_at_on_categorization_rules_index ON categorization_rules (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX categorization_rule_deleted_at_on_categorization_rules_index;
+
+DROP INDEX categorization_rule_position_on_categorization_rules_index;
+
+DROP TABLE IF EXISTS categorization_rules;
+-- +goose StatementEnd
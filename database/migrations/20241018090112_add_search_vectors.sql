@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions
+    ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', coalesce(notes, ''))) STORED;
+
+CREATE INDEX transaction_search_on_transactions_index ON transactions USING GIN (search);
+
+ALTER TABLE accounts
+    ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX account_search_on_accounts_index ON accounts USING GIN (search);
+
+ALTER TABLE achievements
+    ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX achievement_search_on_achievements_index ON achievements USING GIN (search);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX achievement_search_on_achievements_index;
+
+ALTER TABLE achievements
+    DROP COLUMN search;
+
+DROP INDEX account_search_on_accounts_index;
+
+ALTER TABLE accounts
+    DROP COLUMN search;
+
+DROP INDEX transaction_search_on_transactions_index;
+
+ALTER TABLE transactions
+    DROP COLUMN search;
+-- +goose StatementEnd
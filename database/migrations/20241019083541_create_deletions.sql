@@ -0,0 +1,77 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS deletions (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    kind VARCHAR(16) NOT NULL,
+    record_id BIGINT NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX deletion_kind_record_id_on_deletions_index ON deletions (kind, record_id);
+
+CREATE INDEX deletion_deleted_at_on_deletions_index ON deletions (deleted_at);
+
+ALTER TABLE accounts
+    ADD COLUMN deletion_id BIGINT CONSTRAINT account_deletion_reference REFERENCES deletions (id);
+
+CREATE INDEX account_deletion_reference_index ON accounts (deletion_id);
+
+ALTER TABLE transactions
+    ADD COLUMN deletion_id BIGINT CONSTRAINT transaction_deletion_reference REFERENCES deletions (id);
+
+CREATE INDEX transaction_deletion_reference_index ON transactions (deletion_id);
+
+-- Every account deleted before this migration becomes its own unit, children
+-- deleted at the same time as their parent belong to the parent unit.
+INSERT INTO deletions (kind, record_id, deleted_at)
+SELECT 'account', acc.id, acc.deleted_at
+FROM accounts acc
+LEFT JOIN accounts parent ON parent.id = acc.parent_id
+WHERE acc.deleted_at IS NOT NULL
+    AND (parent.id IS NULL OR parent.deleted_at IS DISTINCT FROM acc.deleted_at);
+
+UPDATE accounts acc
+SET deletion_id = d.id
+FROM deletions d
+WHERE d.kind = 'account'
+    AND acc.deleted_at = d.deleted_at
+    AND (acc.id = d.record_id OR acc.parent_id = d.record_id);
+
+UPDATE transactions tr
+SET deletion_id = acc.deletion_id
+FROM accounts acc
+WHERE tr.deletion_id IS NULL
+    AND acc.deletion_id IS NOT NULL
+    AND tr.deleted_at = acc.deleted_at
+    AND (tr.source_id = acc.id OR tr.target_id = acc.id);
+
+INSERT INTO deletions (kind, record_id, deleted_at)
+SELECT 'transaction', id, deleted_at
+FROM transactions
+WHERE deleted_at IS NOT NULL AND deletion_id IS NULL;
+
+UPDATE transactions tr
+SET deletion_id = d.id
+FROM deletions d
+WHERE d.kind = 'transaction' AND d.record_id = tr.id AND tr.deletion_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX transaction_deletion_reference_index;
+
+ALTER TABLE transactions
+    DROP COLUMN deletion_id;
+
+DROP INDEX account_deletion_reference_index;
+
+ALTER TABLE accounts
+    DROP COLUMN deletion_id;
+
+DROP INDEX deletion_deleted_at_on_deletions_index;
+
+DROP INDEX deletion_kind_record_id_on_deletions_index;
+
+DROP TABLE IF EXISTS deletions;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    entity VARCHAR(16) NOT NULL,
+    record_id BIGINT NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    changes JSONB NOT NULL,
+    request_id TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_entry_entity_record_id_on_audit_entries_index ON audit_entries (entity, record_id, occurred_at);
+
+CREATE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_entries_append_only
+    BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER audit_entries_append_only ON audit_entries;
+
+DROP FUNCTION audit_entries_append_only;
+
+DROP INDEX audit_entry_entity_record_id_on_audit_entries_index;
+
+DROP TABLE IF EXISTS audit_entries;
+-- +goose StatementEnd
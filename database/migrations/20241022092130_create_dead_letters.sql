@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    bus VARCHAR(64) NOT NULL,
+    subscription VARCHAR(128) NOT NULL,
+    payload JSONB NOT NULL,
+    error TEXT NOT NULL,
+    attempts INT NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX dead_letter_failed_at_on_dead_letters_index ON dead_letters (failed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX dead_letter_failed_at_on_dead_letters_index;
+
+DROP TABLE IF EXISTS dead_letters;
+-- +goose StatementEnd
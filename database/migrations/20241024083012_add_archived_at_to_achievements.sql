@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE achievements
+    ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Positions were never enforced, so the active savings goals are renumbered
+-- per currency, keeping their order, before the unique index is built.
+UPDATE achievements a
+SET settings = jsonb_set(a.settings, '{position}', to_jsonb(r.position))
+FROM (
+    SELECT
+        id,
+        ROW_NUMBER() OVER (
+            PARTITION BY settings->>'currency'
+            ORDER BY (settings->>'position')::INT, id
+        ) AS position
+    FROM achievements
+    WHERE kind = 'savings_goal'
+        AND achieved_at IS NULL
+        AND deleted_at IS NULL
+) r
+WHERE a.id = r.id;
+
+CREATE UNIQUE INDEX savings_goal_position_on_achievements_index ON achievements ((settings->>'currency'), ((settings->>'position')::INT))
+    WHERE kind = 'savings_goal' AND achieved_at IS NULL AND deleted_at IS NULL AND archived_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX savings_goal_position_on_achievements_index;
+
+ALTER TABLE achievements
+    DROP COLUMN archived_at;
+-- +goose StatementEnd
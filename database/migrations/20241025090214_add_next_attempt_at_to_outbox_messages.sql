@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox_messages
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+
+DROP INDEX outbox_message_pending_on_outbox_messages_index;
+
+CREATE INDEX outbox_message_pending_on_outbox_messages_index ON outbox_messages (next_attempt_at, id) WHERE delivered_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX outbox_message_pending_on_outbox_messages_index;
+
+CREATE INDEX outbox_message_pending_on_outbox_messages_index ON outbox_messages (id) WHERE delivered_at IS NULL;
+
+ALTER TABLE outbox_messages
+    DROP COLUMN next_attempt_at;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE audit_entries
+    ADD COLUMN outbox_id BIGINT;
+
+CREATE UNIQUE INDEX audit_entry_outbox_id_on_audit_entries_index ON audit_entries (outbox_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX audit_entry_outbox_id_on_audit_entries_index;
+
+ALTER TABLE audit_entries
+    DROP COLUMN outbox_id;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP INDEX outbox_message_pending_on_outbox_messages_index;
+
+CREATE INDEX outbox_message_pending_on_outbox_messages_index ON outbox_messages (id) WHERE delivered_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX outbox_message_pending_on_outbox_messages_index;
+
+CREATE INDEX outbox_message_pending_on_outbox_messages_index ON outbox_messages (next_attempt_at, id) WHERE delivered_at IS NULL;
+-- +goose StatementEnd
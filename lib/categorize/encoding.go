@@ -0,0 +1,53 @@
+package categorize
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Scan takes the json value returned by the SQL database and maps it to
+// [Condition]. So [Condition] satisfies the [sql.Scanner] interface.
+func (c *Condition) Scan(value any) error {
+	return scanJSON(value, c)
+}
+
+// Value returns the json encoding of [Condition] to be stored in the SQL
+// database. So [Condition] satisfies the [driver.Valuer] interface.
+func (c Condition) Value() (driver.Value, error) {
+	return valueJSON(c)
+}
+
+// Scan takes the json value returned by the SQL database and maps it to
+// [Action]. So [Action] satisfies the [sql.Scanner] interface.
+func (a *Action) Scan(value any) error {
+	return scanJSON(value, a)
+}
+
+// Value returns the json encoding of [Action] to be stored in the SQL
+// database. So [Action] satisfies the [driver.Valuer] interface.
+func (a Action) Value() (driver.Value, error) {
+	return valueJSON(a)
+}
+
+func scanJSON(value any, v any) error {
+	data, ok := value.([]uint8)
+	if !ok {
+		return errors.New("categorize: invalid column type")
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Join(errors.New("categorize: can't be mapped"), err)
+	}
+
+	return nil
+}
+
+func valueJSON(v any) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Join(errors.New("categorize: can't be marshaled"), err)
+	}
+
+	return []uint8(b), nil
+}
@@ -0,0 +1,146 @@
+// Package categorize matches transactions against user defined rules to pick
+// their counter account, tags and notes.
+package categorize
+
+import (
+	"errors"
+	"financo/lib/nullable"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrEmptyCondition = errors.New("categorize: rule condition is empty")
+	ErrEmptyAction    = errors.New("categorize: rule action is empty")
+	ErrInvalidRange   = errors.New("categorize: minimum amount is greater than the maximum amount")
+)
+
+// Condition is satisfied by the transactions matching all of its fields, the
+// ones left empty match every transaction.
+type Condition struct {
+	// NotesContains matches the notes containing the text, case insensitive.
+	NotesContains string `json:"notesContains"`
+	// NotesPattern matches the notes with a regular expression.
+	NotesPattern string `json:"notesPattern"`
+	// MinAmount and MaxAmount bound the source amount, both inclusive.
+	MinAmount nullable.Type[int64] `json:"minAmount"`
+	MaxAmount nullable.Type[int64] `json:"maxAmount"`
+	SourceID  nullable.Type[int64] `json:"sourceID"`
+}
+
+// Action describes the changes applied to a matching transaction.
+type Action struct {
+	TargetID nullable.Type[int64]  `json:"targetID"`
+	Tags     []int64               `json:"tags"`
+	Notes    nullable.Type[string] `json:"notes"`
+}
+
+// Rule applies Action to the transactions satisfying Condition.
+type Rule struct {
+	ID        int64
+	Condition Condition
+	Action    Action
+}
+
+// Candidate holds the fields of a transaction rules match on.
+type Candidate struct {
+	SourceID int64
+	Amount   int64
+	Notes    string
+}
+
+// Validate checks that the condition matches something.
+//
+// It returns an error if the condition is empty, if NotesPattern isn't a
+// valid regular expression or if the amount range is empty.
+func (c Condition) Validate() error {
+	if c.NotesContains == "" && c.NotesPattern == "" && !c.MinAmount.Valid && !c.MaxAmount.Valid && !c.SourceID.Valid {
+		return ErrEmptyCondition
+	}
+
+	if c.NotesPattern != "" {
+		if _, err := regexp.Compile(c.NotesPattern); err != nil {
+			return fmt.Errorf("categorize: invalid notes pattern: %w", err)
+		}
+	}
+
+	if c.MinAmount.Valid && c.MaxAmount.Valid && c.MinAmount.Val > c.MaxAmount.Val {
+		return ErrInvalidRange
+	}
+
+	return nil
+}
+
+// Validate checks that the action changes something.
+func (a Action) Validate() error {
+	if !a.TargetID.Valid && len(a.Tags) == 0 && !a.Notes.Valid {
+		return ErrEmptyAction
+	}
+
+	return nil
+}
+
+// Engine matches candidates against a list of rules, in order.
+type Engine struct {
+	rules    []Rule
+	patterns []*regexp.Regexp
+}
+
+// NewEngine compiles rules, the first one matching a candidate wins.
+//
+// It returns an error if the condition of any of the rules is invalid.
+func NewEngine(rules []Rule) (Engine, error) {
+	engine := Engine{
+		rules:    rules,
+		patterns: make([]*regexp.Regexp, len(rules)),
+	}
+
+	for i, rule := range rules {
+		if err := rule.Condition.Validate(); err != nil {
+			return engine, fmt.Errorf("rule %d: %w", rule.ID, err)
+		}
+
+		if rule.Condition.NotesPattern != "" {
+			engine.patterns[i] = regexp.MustCompile(rule.Condition.NotesPattern)
+		}
+	}
+
+	return engine, nil
+}
+
+// Match returns the first rule matching candidate.
+func (e Engine) Match(candidate Candidate) (Rule, bool) {
+	for i, rule := range e.rules {
+		if matches(rule.Condition, e.patterns[i], candidate) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+func matches(c Condition, pattern *regexp.Regexp, candidate Candidate) bool {
+	if c.SourceID.Valid && c.SourceID.Val != candidate.SourceID {
+		return false
+	}
+
+	if c.MinAmount.Valid && candidate.Amount < c.MinAmount.Val {
+		return false
+	}
+
+	if c.MaxAmount.Valid && candidate.Amount > c.MaxAmount.Val {
+		return false
+	}
+
+	if c.NotesContains != "" &&
+		!strings.Contains(strings.ToLower(candidate.Notes), strings.ToLower(c.NotesContains)) {
+		return false
+	}
+
+	if pattern != nil && !pattern.MatchString(candidate.Notes) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,129 @@
+package categorize
+
+import (
+	"financo/lib/nullable"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		wantErr   bool
+	}{
+		{
+			name:      "notes contains",
+			condition: Condition{NotesContains: "spotify"},
+		},
+		{
+			name:      "amount range",
+			condition: Condition{MinAmount: nullable.New[int64](100), MaxAmount: nullable.New[int64](100)},
+		},
+		{
+			name:      "empty",
+			condition: Condition{},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid pattern",
+			condition: Condition{NotesPattern: "(spotify"},
+			wantErr:   true,
+		},
+		{
+			name:      "empty range",
+			condition: Condition{MinAmount: nullable.New[int64](200), MaxAmount: nullable.New[int64](100)},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.condition.Validate()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEngineMatch(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{
+			ID:        1,
+			Condition: Condition{NotesContains: "SPOTIFY", SourceID: nullable.New[int64](10)},
+		},
+		{
+			ID:        2,
+			Condition: Condition{NotesPattern: `^(?i)rent \d{4}$`},
+		},
+		{
+			ID:        3,
+			Condition: Condition{NotesContains: "coffee", MaxAmount: nullable.New[int64](500)},
+		},
+		{
+			ID:        4,
+			Condition: Condition{MinAmount: nullable.New[int64](100000)},
+		},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		wantID    int64
+		wantOK    bool
+	}{
+		{
+			name:      "substring is case insensitive",
+			candidate: Candidate{SourceID: 10, Amount: 999, Notes: "Spotify AB 1234"},
+			wantID:    1,
+			wantOK:    true,
+		},
+		{
+			name:      "source must match",
+			candidate: Candidate{SourceID: 11, Amount: 999, Notes: "Spotify AB 1234"},
+		},
+		{
+			name:      "pattern",
+			candidate: Candidate{SourceID: 11, Amount: 120000, Notes: "RENT 2024"},
+			wantID:    2,
+			wantOK:    true,
+		},
+		{
+			name:      "amount bounds are inclusive",
+			candidate: Candidate{SourceID: 11, Amount: 500, Notes: "coffee shop"},
+			wantID:    3,
+			wantOK:    true,
+		},
+		{
+			name:      "amount out of range falls through",
+			candidate: Candidate{SourceID: 11, Amount: 100000, Notes: "coffee beans"},
+			wantID:    4,
+			wantOK:    true,
+		},
+		{
+			name:      "no match",
+			candidate: Candidate{SourceID: 11, Amount: 501, Notes: "coffee"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := engine.Match(tt.candidate)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantID, rule.ID)
+		})
+	}
+}
+
+func TestNewEngineInvalidRule(t *testing.T) {
+	_, err := NewEngine([]Rule{{ID: 7, Condition: Condition{NotesPattern: "["}}})
+
+	assert.Error(t, err)
+}
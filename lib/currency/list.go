@@ -0,0 +1,174 @@
+package currency
+
+// List is every ISO 4217 currency with a minor unit, sorted by code. Funds
+// are included, precious metals and other codes without minor unit aren't.
+var List = []Entry{
+	{Code: "AED", Numeric: "784", Exponent: 2, Name: "UAE Dirham", Symbol: "د.إ"},
+	{Code: "AFN", Numeric: "971", Exponent: 2, Name: "Afghani", Symbol: "؋"},
+	{Code: "ALL", Numeric: "008", Exponent: 2, Name: "Lek", Symbol: "L"},
+	{Code: "AMD", Numeric: "051", Exponent: 2, Name: "Armenian Dram", Symbol: "֏"},
+	{Code: "ANG", Numeric: "532", Exponent: 2, Name: "Netherlands Antillean Guilder", Symbol: "ƒ"},
+	{Code: "AOA", Numeric: "973", Exponent: 2, Name: "Kwanza", Symbol: "Kz"},
+	{Code: "ARS", Numeric: "032", Exponent: 2, Name: "Argentine Peso", Symbol: "$"},
+	{Code: "AUD", Numeric: "036", Exponent: 2, Name: "Australian Dollar", Symbol: "A$"},
+	{Code: "AWG", Numeric: "533", Exponent: 2, Name: "Aruban Florin", Symbol: "ƒ"},
+	{Code: "AZN", Numeric: "944", Exponent: 2, Name: "Azerbaijan Manat", Symbol: "₼"},
+	{Code: "BAM", Numeric: "977", Exponent: 2, Name: "Convertible Mark", Symbol: "KM"},
+	{Code: "BBD", Numeric: "052", Exponent: 2, Name: "Barbados Dollar", Symbol: "$"},
+	{Code: "BDT", Numeric: "050", Exponent: 2, Name: "Taka", Symbol: "৳"},
+	{Code: "BGN", Numeric: "975", Exponent: 2, Name: "Bulgarian Lev", Symbol: "лв"},
+	{Code: "BHD", Numeric: "048", Exponent: 3, Name: "Bahraini Dinar", Symbol: "BD"},
+	{Code: "BIF", Numeric: "108", Exponent: 0, Name: "Burundi Franc", Symbol: "FBu"},
+	{Code: "BMD", Numeric: "060", Exponent: 2, Name: "Bermudian Dollar", Symbol: "$"},
+	{Code: "BND", Numeric: "096", Exponent: 2, Name: "Brunei Dollar", Symbol: "$"},
+	{Code: "BOB", Numeric: "068", Exponent: 2, Name: "Boliviano", Symbol: "Bs."},
+	{Code: "BOV", Numeric: "984", Exponent: 2, Name: "Mvdol", Symbol: "BOV"},
+	{Code: "BRL", Numeric: "986", Exponent: 2, Name: "Brazilian Real", Symbol: "R$"},
+	{Code: "BSD", Numeric: "044", Exponent: 2, Name: "Bahamian Dollar", Symbol: "$"},
+	{Code: "BTN", Numeric: "064", Exponent: 2, Name: "Ngultrum", Symbol: "Nu."},
+	{Code: "BWP", Numeric: "072", Exponent: 2, Name: "Pula", Symbol: "P"},
+	{Code: "BYN", Numeric: "933", Exponent: 2, Name: "Belarusian Ruble", Symbol: "Br"},
+	{Code: "BZD", Numeric: "084", Exponent: 2, Name: "Belize Dollar", Symbol: "BZ$"},
+	{Code: "CAD", Numeric: "124", Exponent: 2, Name: "Canadian Dollar", Symbol: "CA$"},
+	{Code: "CDF", Numeric: "976", Exponent: 2, Name: "Congolese Franc", Symbol: "FC"},
+	{Code: "CHE", Numeric: "947", Exponent: 2, Name: "WIR Euro", Symbol: "CHE"},
+	{Code: "CHF", Numeric: "756", Exponent: 2, Name: "Swiss Franc", Symbol: "CHF"},
+	{Code: "CHW", Numeric: "948", Exponent: 2, Name: "WIR Franc", Symbol: "CHW"},
+	{Code: "CLF", Numeric: "990", Exponent: 4, Name: "Unidad de Fomento", Symbol: "UF"},
+	{Code: "CLP", Numeric: "152", Exponent: 0, Name: "Chilean Peso", Symbol: "$"},
+	{Code: "CNY", Numeric: "156", Exponent: 2, Name: "Yuan Renminbi", Symbol: "¥"},
+	{Code: "COP", Numeric: "170", Exponent: 2, Name: "Colombian Peso", Symbol: "$"},
+	{Code: "COU", Numeric: "970", Exponent: 2, Name: "Unidad de Valor Real", Symbol: "COU"},
+	{Code: "CRC", Numeric: "188", Exponent: 2, Name: "Costa Rican Colon", Symbol: "₡"},
+	{Code: "CUC", Numeric: "931", Exponent: 2, Name: "Peso Convertible", Symbol: "CUC$"},
+	{Code: "CUP", Numeric: "192", Exponent: 2, Name: "Cuban Peso", Symbol: "$"},
+	{Code: "CVE", Numeric: "132", Exponent: 2, Name: "Cabo Verde Escudo", Symbol: "$"},
+	{Code: "CZK", Numeric: "203", Exponent: 2, Name: "Czech Koruna", Symbol: "Kč"},
+	{Code: "DJF", Numeric: "262", Exponent: 0, Name: "Djibouti Franc", Symbol: "Fdj"},
+	{Code: "DKK", Numeric: "208", Exponent: 2, Name: "Danish Krone", Symbol: "kr"},
+	{Code: "DOP", Numeric: "214", Exponent: 2, Name: "Dominican Peso", Symbol: "RD$"},
+	{Code: "DZD", Numeric: "012", Exponent: 2, Name: "Algerian Dinar", Symbol: "DA"},
+	{Code: "EGP", Numeric: "818", Exponent: 2, Name: "Egyptian Pound", Symbol: "E£"},
+	{Code: "ERN", Numeric: "232", Exponent: 2, Name: "Nakfa", Symbol: "Nfk"},
+	{Code: "ETB", Numeric: "230", Exponent: 2, Name: "Ethiopian Birr", Symbol: "Br"},
+	{Code: "EUR", Numeric: "978", Exponent: 2, Name: "Euro", Symbol: "€"},
+	{Code: "FJD", Numeric: "242", Exponent: 2, Name: "Fiji Dollar", Symbol: "FJ$"},
+	{Code: "FKP", Numeric: "238", Exponent: 2, Name: "Falkland Islands Pound", Symbol: "£"},
+	{Code: "GBP", Numeric: "826", Exponent: 2, Name: "Pound Sterling", Symbol: "£"},
+	{Code: "GEL", Numeric: "981", Exponent: 2, Name: "Lari", Symbol: "₾"},
+	{Code: "GHS", Numeric: "936", Exponent: 2, Name: "Ghana Cedi", Symbol: "₵"},
+	{Code: "GIP", Numeric: "292", Exponent: 2, Name: "Gibraltar Pound", Symbol: "£"},
+	{Code: "GMD", Numeric: "270", Exponent: 2, Name: "Dalasi", Symbol: "D"},
+	{Code: "GNF", Numeric: "324", Exponent: 0, Name: "Guinean Franc", Symbol: "FG"},
+	{Code: "GTQ", Numeric: "320", Exponent: 2, Name: "Quetzal", Symbol: "Q"},
+	{Code: "GYD", Numeric: "328", Exponent: 2, Name: "Guyana Dollar", Symbol: "$"},
+	{Code: "HKD", Numeric: "344", Exponent: 2, Name: "Hong Kong Dollar", Symbol: "HK$"},
+	{Code: "HNL", Numeric: "340", Exponent: 2, Name: "Lempira", Symbol: "L"},
+	{Code: "HTG", Numeric: "332", Exponent: 2, Name: "Gourde", Symbol: "G"},
+	{Code: "HUF", Numeric: "348", Exponent: 2, Name: "Forint", Symbol: "Ft"},
+	{Code: "IDR", Numeric: "360", Exponent: 2, Name: "Rupiah", Symbol: "Rp"},
+	{Code: "ILS", Numeric: "376", Exponent: 2, Name: "New Israeli Sheqel", Symbol: "₪"},
+	{Code: "INR", Numeric: "356", Exponent: 2, Name: "Indian Rupee", Symbol: "₹"},
+	{Code: "IQD", Numeric: "368", Exponent: 3, Name: "Iraqi Dinar", Symbol: "IQD"},
+	{Code: "IRR", Numeric: "364", Exponent: 2, Name: "Iranian Rial", Symbol: "﷼"},
+	{Code: "ISK", Numeric: "352", Exponent: 0, Name: "Iceland Krona", Symbol: "kr"},
+	{Code: "JMD", Numeric: "388", Exponent: 2, Name: "Jamaican Dollar", Symbol: "J$"},
+	{Code: "JOD", Numeric: "400", Exponent: 3, Name: "Jordanian Dinar", Symbol: "JD"},
+	{Code: "JPY", Numeric: "392", Exponent: 0, Name: "Yen", Symbol: "¥"},
+	{Code: "KES", Numeric: "404", Exponent: 2, Name: "Kenyan Shilling", Symbol: "KSh"},
+	{Code: "KGS", Numeric: "417", Exponent: 2, Name: "Som", Symbol: "сом"},
+	{Code: "KHR", Numeric: "116", Exponent: 2, Name: "Riel", Symbol: "៛"},
+	{Code: "KMF", Numeric: "174", Exponent: 0, Name: "Comorian Franc", Symbol: "CF"},
+	{Code: "KPW", Numeric: "408", Exponent: 2, Name: "North Korean Won", Symbol: "₩"},
+	{Code: "KRW", Numeric: "410", Exponent: 0, Name: "Won", Symbol: "₩"},
+	{Code: "KWD", Numeric: "414", Exponent: 3, Name: "Kuwaiti Dinar", Symbol: "KD"},
+	{Code: "KYD", Numeric: "136", Exponent: 2, Name: "Cayman Islands Dollar", Symbol: "CI$"},
+	{Code: "KZT", Numeric: "398", Exponent: 2, Name: "Tenge", Symbol: "₸"},
+	{Code: "LAK", Numeric: "418", Exponent: 2, Name: "Lao Kip", Symbol: "₭"},
+	{Code: "LBP", Numeric: "422", Exponent: 2, Name: "Lebanese Pound", Symbol: "LL"},
+	{Code: "LKR", Numeric: "144", Exponent: 2, Name: "Sri Lanka Rupee", Symbol: "Rs"},
+	{Code: "LRD", Numeric: "430", Exponent: 2, Name: "Liberian Dollar", Symbol: "L$"},
+	{Code: "LSL", Numeric: "426", Exponent: 2, Name: "Loti", Symbol: "L"},
+	{Code: "LYD", Numeric: "434", Exponent: 3, Name: "Libyan Dinar", Symbol: "LD"},
+	{Code: "MAD", Numeric: "504", Exponent: 2, Name: "Moroccan Dirham", Symbol: "DH"},
+	{Code: "MDL", Numeric: "498", Exponent: 2, Name: "Moldovan Leu", Symbol: "L"},
+	{Code: "MGA", Numeric: "969", Exponent: 2, Name: "Malagasy Ariary", Symbol: "Ar"},
+	{Code: "MKD", Numeric: "807", Exponent: 2, Name: "Denar", Symbol: "ден"},
+	{Code: "MMK", Numeric: "104", Exponent: 2, Name: "Kyat", Symbol: "K"},
+	{Code: "MNT", Numeric: "496", Exponent: 2, Name: "Tugrik", Symbol: "₮"},
+	{Code: "MOP", Numeric: "446", Exponent: 2, Name: "Pataca", Symbol: "MOP$"},
+	{Code: "MRU", Numeric: "929", Exponent: 2, Name: "Ouguiya", Symbol: "UM"},
+	{Code: "MUR", Numeric: "480", Exponent: 2, Name: "Mauritius Rupee", Symbol: "Rs"},
+	{Code: "MVR", Numeric: "462", Exponent: 2, Name: "Rufiyaa", Symbol: "Rf"},
+	{Code: "MWK", Numeric: "454", Exponent: 2, Name: "Malawi Kwacha", Symbol: "MK"},
+	{Code: "MXN", Numeric: "484", Exponent: 2, Name: "Mexican Peso", Symbol: "MX$"},
+	{Code: "MXV", Numeric: "979", Exponent: 2, Name: "Mexican Unidad de Inversion (UDI)", Symbol: "MXV"},
+	{Code: "MYR", Numeric: "458", Exponent: 2, Name: "Malaysian Ringgit", Symbol: "RM"},
+	{Code: "MZN", Numeric: "943", Exponent: 2, Name: "Mozambique Metical", Symbol: "MT"},
+	{Code: "NAD", Numeric: "516", Exponent: 2, Name: "Namibia Dollar", Symbol: "N$"},
+	{Code: "NGN", Numeric: "566", Exponent: 2, Name: "Naira", Symbol: "₦"},
+	{Code: "NIO", Numeric: "558", Exponent: 2, Name: "Cordoba Oro", Symbol: "C$"},
+	{Code: "NOK", Numeric: "578", Exponent: 2, Name: "Norwegian Krone", Symbol: "kr"},
+	{Code: "NPR", Numeric: "524", Exponent: 2, Name: "Nepalese Rupee", Symbol: "Rs"},
+	{Code: "NZD", Numeric: "554", Exponent: 2, Name: "New Zealand Dollar", Symbol: "NZ$"},
+	{Code: "OMR", Numeric: "512", Exponent: 3, Name: "Rial Omani", Symbol: "OMR"},
+	{Code: "PAB", Numeric: "590", Exponent: 2, Name: "Balboa", Symbol: "B/."},
+	{Code: "PEN", Numeric: "604", Exponent: 2, Name: "Sol", Symbol: "S/"},
+	{Code: "PGK", Numeric: "598", Exponent: 2, Name: "Kina", Symbol: "K"},
+	{Code: "PHP", Numeric: "608", Exponent: 2, Name: "Philippine Peso", Symbol: "₱"},
+	{Code: "PKR", Numeric: "586", Exponent: 2, Name: "Pakistan Rupee", Symbol: "Rs"},
+	{Code: "PLN", Numeric: "985", Exponent: 2, Name: "Zloty", Symbol: "zł"},
+	{Code: "PYG", Numeric: "600", Exponent: 0, Name: "Guarani", Symbol: "₲"},
+	{Code: "QAR", Numeric: "634", Exponent: 2, Name: "Qatari Rial", Symbol: "QR"},
+	{Code: "RON", Numeric: "946", Exponent: 2, Name: "Romanian Leu", Symbol: "lei"},
+	{Code: "RSD", Numeric: "941", Exponent: 2, Name: "Serbian Dinar", Symbol: "дин."},
+	{Code: "RUB", Numeric: "643", Exponent: 2, Name: "Russian Ruble", Symbol: "₽"},
+	{Code: "RWF", Numeric: "646", Exponent: 0, Name: "Rwanda Franc", Symbol: "FRw"},
+	{Code: "SAR", Numeric: "682", Exponent: 2, Name: "Saudi Riyal", Symbol: "SR"},
+	{Code: "SBD", Numeric: "090", Exponent: 2, Name: "Solomon Islands Dollar", Symbol: "SI$"},
+	{Code: "SCR", Numeric: "690", Exponent: 2, Name: "Seychelles Rupee", Symbol: "Rs"},
+	{Code: "SDG", Numeric: "938", Exponent: 2, Name: "Sudanese Pound", Symbol: "SDG"},
+	{Code: "SEK", Numeric: "752", Exponent: 2, Name: "Swedish Krona", Symbol: "kr"},
+	{Code: "SGD", Numeric: "702", Exponent: 2, Name: "Singapore Dollar", Symbol: "S$"},
+	{Code: "SHP", Numeric: "654", Exponent: 2, Name: "Saint Helena Pound", Symbol: "£"},
+	{Code: "SLE", Numeric: "925", Exponent: 2, Name: "Leone", Symbol: "Le"},
+	{Code: "SLL", Numeric: "694", Exponent: 2, Name: "Leone (old)", Symbol: "Le"},
+	{Code: "SOS", Numeric: "706", Exponent: 2, Name: "Somali Shilling", Symbol: "Sh"},
+	{Code: "SRD", Numeric: "968", Exponent: 2, Name: "Surinam Dollar", Symbol: "$"},
+	{Code: "SSP", Numeric: "728", Exponent: 2, Name: "South Sudanese Pound", Symbol: "£"},
+	{Code: "STN", Numeric: "930", Exponent: 2, Name: "Dobra", Symbol: "Db"},
+	{Code: "SVC", Numeric: "222", Exponent: 2, Name: "El Salvador Colon", Symbol: "₡"},
+	{Code: "SYP", Numeric: "760", Exponent: 2, Name: "Syrian Pound", Symbol: "£S"},
+	{Code: "SZL", Numeric: "748", Exponent: 2, Name: "Lilangeni", Symbol: "E"},
+	{Code: "THB", Numeric: "764", Exponent: 2, Name: "Baht", Symbol: "฿"},
+	{Code: "TJS", Numeric: "972", Exponent: 2, Name: "Somoni", Symbol: "SM"},
+	{Code: "TMT", Numeric: "934", Exponent: 2, Name: "Turkmenistan New Manat", Symbol: "m"},
+	{Code: "TND", Numeric: "788", Exponent: 3, Name: "Tunisian Dinar", Symbol: "DT"},
+	{Code: "TOP", Numeric: "776", Exponent: 2, Name: "Pa'anga", Symbol: "T$"},
+	{Code: "TRY", Numeric: "949", Exponent: 2, Name: "Turkish Lira", Symbol: "₺"},
+	{Code: "TTD", Numeric: "780", Exponent: 2, Name: "Trinidad and Tobago Dollar", Symbol: "TT$"},
+	{Code: "TWD", Numeric: "901", Exponent: 2, Name: "New Taiwan Dollar", Symbol: "NT$"},
+	{Code: "TZS", Numeric: "834", Exponent: 2, Name: "Tanzanian Shilling", Symbol: "TSh"},
+	{Code: "UAH", Numeric: "980", Exponent: 2, Name: "Hryvnia", Symbol: "₴"},
+	{Code: "UGX", Numeric: "800", Exponent: 0, Name: "Uganda Shilling", Symbol: "USh"},
+	{Code: "USD", Numeric: "840", Exponent: 2, Name: "US Dollar", Symbol: "$"},
+	{Code: "USN", Numeric: "997", Exponent: 2, Name: "US Dollar (Next day)", Symbol: "USN"},
+	{Code: "UYI", Numeric: "940", Exponent: 0, Name: "Uruguay Peso en Unidades Indexadas (UI)", Symbol: "UYI"},
+	{Code: "UYU", Numeric: "858", Exponent: 2, Name: "Peso Uruguayo", Symbol: "$U"},
+	{Code: "UYW", Numeric: "927", Exponent: 4, Name: "Unidad Previsional", Symbol: "UYW"},
+	{Code: "UZS", Numeric: "860", Exponent: 2, Name: "Uzbekistan Sum", Symbol: "soʻm"},
+	{Code: "VED", Numeric: "926", Exponent: 2, Name: "Bolívar Soberano", Symbol: "Bs.D"},
+	{Code: "VES", Numeric: "928", Exponent: 2, Name: "Bolívar Soberano", Symbol: "Bs.S"},
+	{Code: "VND", Numeric: "704", Exponent: 0, Name: "Dong", Symbol: "₫"},
+	{Code: "VUV", Numeric: "548", Exponent: 0, Name: "Vatu", Symbol: "VT"},
+	{Code: "WST", Numeric: "882", Exponent: 2, Name: "Tala", Symbol: "WS$"},
+	{Code: "XAF", Numeric: "950", Exponent: 0, Name: "CFA Franc BEAC", Symbol: "FCFA"},
+	{Code: "XCD", Numeric: "951", Exponent: 2, Name: "East Caribbean Dollar", Symbol: "EC$"},
+	{Code: "XOF", Numeric: "952", Exponent: 0, Name: "CFA Franc BCEAO", Symbol: "CFA"},
+	{Code: "XPF", Numeric: "953", Exponent: 0, Name: "CFP Franc", Symbol: "₣"},
+	{Code: "YER", Numeric: "886", Exponent: 2, Name: "Yemeni Rial", Symbol: "﷼"},
+	{Code: "ZAR", Numeric: "710", Exponent: 2, Name: "Rand", Symbol: "R"},
+	{Code: "ZMW", Numeric: "967", Exponent: 2, Name: "Zambian Kwacha", Symbol: "ZK"},
+	{Code: "ZWG", Numeric: "924", Exponent: 2, Name: "Zimbabwe Gold", Symbol: "ZiG"},
+	{Code: "ZWL", Numeric: "932", Exponent: 2, Name: "Zimbabwe Dollar", Symbol: "Z$"},
+}
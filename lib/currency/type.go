@@ -8,13 +8,17 @@ import (
 	"strings"
 )
 
-// [ ] TODO: refactor to be able to communicate more data to the frontend
-
+// Type is an ISO 4217 currency code.
 type Type string
 
+// Entry describes a currency of [List]. Amounts are stored as an int64 in
+// minor units, Exponent is the number of decimals of the minor unit.
 type Entry struct {
-	Name string `json:"name"`
-	Code string `json:"code"`
+	Code     string `json:"code"`
+	Numeric  string `json:"numeric"`
+	Exponent int    `json:"exponent"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
 }
 
 var (
@@ -24,15 +28,60 @@ var (
 	CHF Type = "CHF"
 	GBP Type = "GBP"
 
-	List = []Entry{
-		{Name: "Canadian Dollar", Code: "CAD"},
-		{Name: "US Dollar", Code: "USD"},
-		{Name: "Euro", Code: "EUR"},
-		{Name: "Swiss Franc", Code: "CHF"},
-		{Name: "Pound Sterling", Code: "GBP"},
-	}
+	registry = func() map[string]Entry {
+		out := make(map[string]Entry, len(List))
+
+		for _, entry := range List {
+			out[entry.Code] = entry
+		}
+
+		return out
+	}()
 )
 
+// DefaultExponent is the exponent assumed for codes missing from [List].
+const DefaultExponent = 2
+
+// Lookup returns the [Entry] of the given code. It is case insensitive.
+func Lookup(code string) (Entry, bool) {
+	entry, ok := registry[strings.ToUpper(strings.TrimSpace(code))]
+
+	return entry, ok
+}
+
+// Parse returns the [Type] of the given code.
+//
+// It returns an error if code isn't in [List].
+func Parse(code string) (Type, error) {
+	entry, ok := Lookup(code)
+	if !ok {
+		return "", fmt.Errorf("currency: invalid currency \"%s\"", code)
+	}
+
+	return Type(entry.Code), nil
+}
+
+// Exponent returns the number of decimals of the minor unit of the currency
+// code, or [DefaultExponent] if code isn't in [List].
+func Exponent(code string) int {
+	entry, ok := Lookup(code)
+	if !ok {
+		return DefaultExponent
+	}
+
+	return entry.Exponent
+}
+
+// Entry returns the [Entry] of [Type].
+func (t Type) Entry() (Entry, bool) {
+	return Lookup(string(t))
+}
+
+// Exponent returns the number of decimals of the minor unit of [Type].
+func (t Type) Exponent() int {
+	return Exponent(string(t))
+}
+
 // UnmarshalJSON receives a buffer b, and ensures that the provided value is a
 // valid [Type]. So [Type] satisfies the [json.Unmarshaler] interface.
 //
@@ -45,48 +94,27 @@ func (t *Type) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	switch strings.ToUpper(s) {
-	default:
-		return fmt.Errorf("currency: invalid currency \"%s\"", s)
-	case "CAD":
-		*t = CAD
-	case "USD":
-		*t = USD
-	case "EUR":
-		*t = EUR
-	case "CHF":
-		*t = CHF
-	case "GBP":
-		*t = GBP
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
 	}
 
+	*t = parsed
+
 	return nil
 }
 
-// UnmarshalJSON receives a buffer b, and ensures that the provided value is a
-// valid [Type]. So [Type] satisfies the [json.Unmarshaler] interface.
+// MarshalJSON returns the json encoding of [Type]. So [Type] satisfies the
+// [json.Marshaler] interface.
 //
-// It returns an error if the buffer can't be unmarshal into an string or the
-// provided value is not a supported [Type].
+// It returns an error if [Type] is an unsupported value or if json encoding
+// fails.
 func (t Type) MarshalJSON() ([]byte, error) {
-	var s string
-
-	switch t {
-	default:
+	if _, ok := registry[string(t)]; !ok {
 		return []byte{}, fmt.Errorf("currency: invalid currency \"%s\"", string(t))
-	case CAD:
-		s = "CAD"
-	case USD:
-		s = "USD"
-	case EUR:
-		s = "EUR"
-	case CHF:
-		s = "CHF"
-	case GBP:
-		s = "GBP"
 	}
 
-	return json.Marshal(s)
+	return json.Marshal(string(t))
 }
 
 // Scan takes the value returned by the SQL database and maps it to [Type].
@@ -99,21 +127,13 @@ func (t *Type) Scan(value any) error {
 		return errors.New("currency: invalid column type")
 	}
 
-	switch strings.ToUpper(s) {
-	default:
-		return fmt.Errorf("currency: invalid currency \"%s\"", value)
-	case "CAD":
-		*t = CAD
-	case "USD":
-		*t = USD
-	case "EUR":
-		*t = EUR
-	case "CHF":
-		*t = CHF
-	case "GBP":
-		*t = GBP
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
 	}
 
+	*t = parsed
+
 	return nil
 }
 
@@ -122,22 +142,9 @@ func (t *Type) Scan(value any) error {
 //
 // It returns an error if [Type] is an unsupported value.
 func (t Type) Value() (driver.Value, error) {
-	var s string
-
-	switch t {
-	default:
-		return s, fmt.Errorf("currency: invalid currency \"%s\"", string(t))
-	case CAD:
-		s = "CAD"
-	case USD:
-		s = "USD"
-	case EUR:
-		s = "EUR"
-	case CHF:
-		s = "CHF"
-	case GBP:
-		s = "GBP"
+	if _, ok := registry[string(t)]; !ok {
+		return "", fmt.Errorf("currency: invalid currency \"%s\"", string(t))
 	}
 
-	return s, nil
+	return string(t), nil
 }
@@ -0,0 +1,52 @@
+package currency
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	for i, entry := range List {
+		assert.Len(t, entry.Code, 3)
+		assert.Len(t, entry.Numeric, 3)
+		assert.NotEmpty(t, entry.Name)
+		assert.NotEmpty(t, entry.Symbol)
+
+		if i > 0 {
+			assert.Less(t, List[i-1].Code, entry.Code)
+		}
+	}
+}
+
+func TestExponent(t *testing.T) {
+	assert.Equal(t, 2, EUR.Exponent())
+	assert.Equal(t, 0, Type("JPY").Exponent())
+	assert.Equal(t, 3, Exponent("bhd"))
+	assert.Equal(t, DefaultExponent, Exponent("XAU"))
+}
+
+func TestTypeJSON(t *testing.T) {
+	var c Type
+
+	assert.NoError(t, json.Unmarshal([]byte(`"jpy"`), &c))
+	assert.Equal(t, Type("JPY"), c)
+	assert.Error(t, json.Unmarshal([]byte(`"ABC"`), &c))
+
+	b, err := json.Marshal(CHF)
+	assert.NoError(t, err)
+	assert.Equal(t, `"CHF"`, string(b))
+
+	_, err = json.Marshal(Type("ABC"))
+	assert.Error(t, err)
+}
+
+func TestTypeScan(t *testing.T) {
+	var c Type
+
+	assert.NoError(t, c.Scan("usd"))
+	assert.Equal(t, USD, c)
+	assert.Error(t, c.Scan("ABC"))
+	assert.Error(t, c.Scan(12))
+}
@@ -0,0 +1,63 @@
+// Package cursor encodes the position of a row in a keyset paginated listing
+// into an opaque string.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Direction tells if a [Cursor] points to the rows after or before it.
+type Direction string
+
+const (
+	After  Direction = "after"
+	Before Direction = "before"
+)
+
+var ErrInvalid = errors.New("cursor: invalid cursor")
+
+// Cursor is the keyset of a row, a date and its ID.
+type Cursor struct {
+	Key       time.Time `json:"k"`
+	ID        int64     `json:"i"`
+	Direction Direction `json:"d"`
+}
+
+// New returns a [Cursor] pointing to the rows after or before the given
+// keyset.
+func New(key time.Time, id int64, direction Direction) Cursor {
+	return Cursor{Key: key.UTC(), ID: id, Direction: direction}
+}
+
+// Encode returns the opaque representation of [Cursor], safe to be used in
+// URLs.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a [Cursor] returned by [Cursor.Encode].
+//
+// It returns [ErrInvalid] if raw wasn't created by [Cursor.Encode].
+func Decode(raw string) (Cursor, error) {
+	var c Cursor
+
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return c, ErrInvalid
+	}
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, ErrInvalid
+	}
+
+	if c.Direction != After && c.Direction != Before {
+		return c, ErrInvalid
+	}
+
+	return c, nil
+}
@@ -0,0 +1,36 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	c := New(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), 42, Before)
+
+	decoded, err := Decode(c.Encode())
+
+	assert.NoError(t, err)
+	assert.Equal(t, c, decoded)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "not base64", raw: "%%%"},
+		{name: "not json", raw: "bm90IGpzb24"},
+		{name: "unknown direction", raw: New(time.Now(), 1, "sideways").Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.raw)
+
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Change holds the JSON encoding of a field before and after a change. From
+// is null when the field didn't exist before and To when it no longer exists.
+type Change struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// Changes maps the name of every changed field to its [Change].
+type Changes map[string]Change
+
+var null = json.RawMessage("null")
+
+// Compute returns the fields whose JSON encoding differs between previous and
+// current. A nil previous or current is treated as an object without fields,
+// so Compute(nil, record) lists every field of a created record.
+//
+// It returns an error if any of the values can't be encoded as a JSON object.
+func Compute(previous, current any) (Changes, error) {
+	from, err := fields(previous)
+	if err != nil {
+		return nil, errors.Join(errors.New("diff: previous can't be encoded"), err)
+	}
+
+	to, err := fields(current)
+	if err != nil {
+		return nil, errors.Join(errors.New("diff: current can't be encoded"), err)
+	}
+
+	changes := make(Changes)
+
+	for key, value := range from {
+		next, ok := to[key]
+		if !ok {
+			next = null
+		}
+
+		if !bytes.Equal(value, next) {
+			changes[key] = Change{From: value, To: next}
+		}
+	}
+
+	for key, value := range to {
+		if _, ok := from[key]; !ok && !bytes.Equal(value, null) {
+			changes[key] = Change{From: null, To: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(v any) (map[string]json.RawMessage, error) {
+	output := make(map[string]json.RawMessage)
+
+	if v == nil {
+		return output, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return output, err
+	}
+
+	err = json.Unmarshal(b, &output)
+
+	return output, err
+}
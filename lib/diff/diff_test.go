@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"encoding/json"
+	"financo/lib/nullable"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	ID    int64
+	Name  string
+	Notes nullable.Type[string]
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous any
+		current  any
+		want     Changes
+	}{
+		{
+			name:     "created",
+			previous: nil,
+			current:  record{ID: 1, Name: "Cash"},
+			want: Changes{
+				"ID":   {From: null, To: json.RawMessage("1")},
+				"Name": {From: null, To: json.RawMessage(`"Cash"`)},
+			},
+		},
+		{
+			name:     "updated",
+			previous: record{ID: 1, Name: "Cash"},
+			current:  record{ID: 1, Name: "Wallet", Notes: nullable.New("daily")},
+			want: Changes{
+				"Name":  {From: json.RawMessage(`"Cash"`), To: json.RawMessage(`"Wallet"`)},
+				"Notes": {From: null, To: json.RawMessage(`"daily"`)},
+			},
+		},
+		{
+			name:     "unchanged",
+			previous: record{ID: 1, Name: "Cash"},
+			current:  record{ID: 1, Name: "Cash"},
+			want:     Changes{},
+		},
+		{
+			name:     "removed",
+			previous: record{ID: 1, Name: "Cash"},
+			current:  nil,
+			want: Changes{
+				"ID":   {From: json.RawMessage("1"), To: null},
+				"Name": {From: json.RawMessage(`"Cash"`), To: null},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compute(tt.previous, tt.current)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComputeInvalid(t *testing.T) {
+	_, err := Compute([]int{1}, nil)
+
+	assert.Error(t, err)
+}
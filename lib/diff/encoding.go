@@ -0,0 +1,33 @@
+package diff
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Scan takes the json value returned by the SQL database and maps it to
+// [Changes]. So [Changes] satisfies the [sql.Scanner] interface.
+func (c *Changes) Scan(value any) error {
+	data, ok := value.([]uint8)
+	if !ok {
+		return errors.New("diff: invalid column type")
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return errors.Join(errors.New("diff: can't be mapped"), err)
+	}
+
+	return nil
+}
+
+// Value returns the json encoding of [Changes] to be stored in the SQL
+// database. So [Changes] satisfies the [driver.Valuer] interface.
+func (c Changes) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.Join(errors.New("diff: can't be marshaled"), err)
+	}
+
+	return []uint8(b), nil
+}
@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ECBBase is the currency every ECB reference rate is quoted against.
+const ECBBase = "EUR"
+
+// ParseECB reads the ECB euro foreign exchange reference rates, either the
+// XML (eurofxref-hist.xml) or the CSV (eurofxref-hist.csv) file. The format is
+// detected from the content.
+//
+// Missing rates, written as N/A in the CSV file, are skipped.
+//
+// It returns an error if the file can't be parsed.
+func ParseECB(r io.Reader) ([]Rate, error) {
+	reader := bufio.NewReader(r)
+
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return nil, errors.Join(errors.New("exchange: empty ECB file"), err)
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n', 0xef, 0xbb, 0xbf:
+			_, _ = reader.ReadByte()
+		case '<':
+			return parseECBXML(reader)
+		default:
+			return parseECBCSV(reader)
+		}
+	}
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+func parseECBXML(r io.Reader) ([]Rate, error) {
+	var envelope ecbEnvelope
+
+	err := xml.NewDecoder(r).Decode(&envelope)
+	if err != nil {
+		return nil, errors.Join(errors.New("exchange: invalid ECB XML"), err)
+	}
+
+	out := make([]Rate, 0, len(envelope.Days)*30)
+
+	for _, day := range envelope.Days {
+		date, err := time.Parse(time.DateOnly, day.Time)
+		if err != nil {
+			return out, fmt.Errorf("exchange: invalid date \"%s\"", day.Time)
+		}
+
+		for _, rate := range day.Rates {
+			value, err := strconv.ParseFloat(rate.Rate, 64)
+			if err != nil {
+				return out, fmt.Errorf("exchange: invalid rate \"%s\" on %s", rate.Rate, day.Time)
+			}
+
+			out = append(out, Rate{Date: date, From: ECBBase, To: rate.Currency, Rate: value})
+		}
+	}
+
+	return out, nil
+}
+
+func parseECBCSV(r io.Reader) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Join(errors.New("exchange: invalid ECB CSV header"), err)
+	}
+
+	if len(header) == 0 || strings.TrimSpace(header[0]) != "Date" {
+		return nil, errors.New("exchange: ECB CSV must start with a Date column")
+	}
+
+	out := make([]Rate, 0, 1000)
+
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return out, errors.Join(fmt.Errorf("exchange: invalid ECB CSV line %d", line), err)
+		}
+
+		date, err := time.Parse(time.DateOnly, strings.TrimSpace(row[0]))
+		if err != nil {
+			return out, fmt.Errorf("exchange: invalid date \"%s\" on line %d", row[0], line)
+		}
+
+		for i := 1; i < len(row) && i < len(header); i++ {
+			var (
+				code = strings.TrimSpace(header[i])
+				raw  = strings.TrimSpace(row[i])
+			)
+
+			if code == "" || raw == "" || raw == "N/A" {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return out, fmt.Errorf("exchange: invalid rate \"%s\" on line %d", raw, line)
+			}
+
+			out = append(out, Rate{Date: date, From: ECBBase, To: code, Rate: value})
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package exchange
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseECB(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Rate
+		wantErr bool
+	}{
+		{
+			name: "xml",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-10-11">
+			<Cube currency="USD" rate="1.0937"/>
+			<Cube currency="CHF" rate="0.9383"/>
+		</Cube>
+		<Cube time="2024-10-10">
+			<Cube currency="USD" rate="1.0938"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`,
+			want: []Rate{
+				{Date: date(2024, time.October, 11), From: "EUR", To: "USD", Rate: 1.0937},
+				{Date: date(2024, time.October, 11), From: "EUR", To: "CHF", Rate: 0.9383},
+				{Date: date(2024, time.October, 10), From: "EUR", To: "USD", Rate: 1.0938},
+			},
+		},
+		{
+			name: "csv",
+			data: "Date,USD,CYP,GBP,\n2024-10-11,1.0937,N/A,0.83713,\n2024-10-10,1.0938,N/A,0.83705,\n",
+			want: []Rate{
+				{Date: date(2024, time.October, 11), From: "EUR", To: "USD", Rate: 1.0937},
+				{Date: date(2024, time.October, 11), From: "EUR", To: "GBP", Rate: 0.83713},
+				{Date: date(2024, time.October, 10), From: "EUR", To: "USD", Rate: 1.0938},
+				{Date: date(2024, time.October, 10), From: "EUR", To: "GBP", Rate: 0.83705},
+			},
+		},
+		{
+			name:    "invalid csv rate",
+			data:    "Date,USD\n2024-10-11,one\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown file",
+			data:    "currency;rate\nUSD;1.09\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseECB(strings.NewReader(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
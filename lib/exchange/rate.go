@@ -0,0 +1,144 @@
+// Package exchange keeps historical exchange rates and converts amounts
+// between currencies with the rate effective on a given date.
+package exchange
+
+import (
+	"errors"
+	"financo/lib/currency"
+	"financo/lib/money"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+var ErrRateNotFound = errors.New("exchange: rate not found")
+
+// Rate is the amount of To that one unit of From buys on Date.
+type Rate struct {
+	Date time.Time
+	From string
+	To   string
+	Rate float64
+}
+
+type pair struct {
+	from string
+	to   string
+}
+
+// Table is a set of historical rates. The rate effective on a date is the last
+// one published on or before it, so weekends and bank holidays use the rate of
+// the previous business day.
+type Table struct {
+	rates map[pair][]Rate
+}
+
+// NewTable returns a [Table] holding rates.
+func NewTable(rates []Rate) Table {
+	t := Table{rates: make(map[pair][]Rate, 10)}
+
+	for _, rate := range rates {
+		if rate.Rate <= 0 {
+			continue
+		}
+
+		p := pair{from: rate.From, to: rate.To}
+		rate.Date = truncate(rate.Date)
+
+		t.rates[p] = append(t.rates[p], rate)
+	}
+
+	for _, list := range t.rates {
+		sort.Slice(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+	}
+
+	return t
+}
+
+// Rate returns the rate from one currency to another effective on date. When
+// the pair isn't in [Table] the inverse pair is used, and then any currency
+// both of them are quoted against, as the ECB quotes everything against the
+// Euro.
+//
+// It returns [ErrRateNotFound] if no rate is effective on date.
+func (t Table) Rate(from string, to string, date time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	date = truncate(date)
+
+	if rate, ok := t.effective(pair{from: from, to: to}, date); ok {
+		return rate, nil
+	}
+
+	if rate, ok := t.effective(pair{from: to, to: from}, date); ok {
+		return 1 / rate, nil
+	}
+
+	for p := range t.rates {
+		if p.to != from {
+			continue
+		}
+
+		base, ok := t.effective(p, date)
+		if !ok {
+			continue
+		}
+
+		quote, ok := t.effective(pair{from: p.from, to: to}, date)
+		if !ok {
+			continue
+		}
+
+		return quote / base, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s to %s on %s", ErrRateNotFound, from, to, date.Format(time.DateOnly))
+}
+
+// Convert converts amount, expressed in minor units of from, into minor units
+// of to, taking into account the exponent of both currencies. The result is
+// rounded half away from zero.
+//
+// It returns [ErrRateNotFound] if no rate is effective on date.
+func (t Table) Convert(amount int64, from string, to string, date time.Time) (int64, error) {
+	rate, err := t.Rate(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+
+	exponent := currency.Exponent(to) - currency.Exponent(from)
+
+	return int64(math.Round(float64(amount) * rate * math.Pow10(exponent))), nil
+}
+
+// Exchange converts m into the currency to with the rate effective on date.
+//
+// It returns [ErrRateNotFound] if no rate is effective on date.
+func (t Table) Exchange(m money.Money, to currency.Type, date time.Time) (money.Money, error) {
+	amount, err := t.Convert(m.Amount(), string(m.Currency()), string(to), date)
+	if err != nil {
+		return money.New(0, to), err
+	}
+
+	return money.New(amount, to), nil
+}
+
+func (t Table) effective(p pair, date time.Time) (float64, bool) {
+	list := t.rates[p]
+
+	i := sort.Search(len(list), func(i int) bool { return list[i].Date.After(date) })
+	if i == 0 {
+		return 0, false
+	}
+
+	return list[i-1].Rate, true
+}
+
+func truncate(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
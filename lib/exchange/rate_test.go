@@ -0,0 +1,119 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTableConvert(t *testing.T) {
+	table := NewTable([]Rate{
+		{Date: date(2024, time.October, 11), From: "EUR", To: "USD", Rate: 1.10},
+		{Date: date(2024, time.October, 11), From: "EUR", To: "CHF", Rate: 0.94},
+		{Date: date(2024, time.October, 14), From: "EUR", To: "USD", Rate: 1.09},
+		{Date: date(2024, time.October, 14), From: "EUR", To: "CHF", Rate: 0.9},
+		{Date: date(2024, time.October, 14), From: "EUR", To: "JPY", Rate: 163.5},
+		{Date: date(2024, time.October, 14), From: "EUR", To: "BHD", Rate: 0.411},
+	})
+
+	tests := []struct {
+		name    string
+		amount  int64
+		from    string
+		to      string
+		date    time.Time
+		want    int64
+		wantErr error
+	}{
+		{
+			name:   "same currency",
+			amount: 1_000_00,
+			from:   "USD",
+			to:     "USD",
+			date:   date(2020, time.January, 1),
+			want:   1_000_00,
+		},
+		{
+			name:   "direct rate",
+			amount: 100_00,
+			from:   "EUR",
+			to:     "USD",
+			date:   date(2024, time.October, 14),
+			want:   109_00,
+		},
+		{
+			name:   "weekend uses the previous business day",
+			amount: 100_00,
+			from:   "EUR",
+			to:     "USD",
+			date:   date(2024, time.October, 13),
+			want:   110_00,
+		},
+		{
+			name:   "inverse rate",
+			amount: 110_00,
+			from:   "USD",
+			to:     "EUR",
+			date:   date(2024, time.October, 12),
+			want:   100_00,
+		},
+		{
+			name:   "cross rate through the euro",
+			amount: 109_00,
+			from:   "USD",
+			to:     "CHF",
+			date:   date(2024, time.October, 15),
+			want:   90_00,
+		},
+		{
+			name:   "currency without minor unit",
+			amount: 100_00,
+			from:   "EUR",
+			to:     "JPY",
+			date:   date(2024, time.October, 14),
+			want:   16_350,
+		},
+		{
+			name:   "currency with three decimals",
+			amount: 1_635,
+			from:   "JPY",
+			to:     "BHD",
+			date:   date(2024, time.October, 14),
+			want:   4_110,
+		},
+		{
+			name:    "before the first rate",
+			amount:  100_00,
+			from:    "EUR",
+			to:      "USD",
+			date:    date(2024, time.October, 10),
+			wantErr: ErrRateNotFound,
+		},
+		{
+			name:    "unknown currency",
+			amount:  100_00,
+			from:    "EUR",
+			to:      "GBP",
+			date:    date(2024, time.October, 14),
+			wantErr: ErrRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.Convert(tt.amount, tt.from, tt.to, tt.date)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
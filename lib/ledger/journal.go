@@ -0,0 +1,89 @@
+// Package ledger reads and writes plain text accounting journals used by
+// ledger, hledger and beancount.
+package ledger
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Journal is the list of accounts and transactions written by [Write].
+type Journal struct {
+	Accounts     []Account
+	Transactions []Transaction
+}
+
+// Account is an account declaration. Currency is optional, when present
+// beancount only allows postings in that currency.
+type Account struct {
+	Name     string
+	Currency string
+	OpenedAt time.Time
+}
+
+// Transaction is a dated list of postings that must balance.
+type Transaction struct {
+	Date     time.Time
+	Pending  bool
+	Notes    string
+	Postings []Posting
+	// Line is the line the transaction starts at when it was parsed.
+	Line int
+}
+
+// Posting moves Amount, expressed in minor units, in or out of Account. When
+// Price is not zero the posting is annotated with its total price in
+// PriceCurrency using "@@".
+type Posting struct {
+	Account       string
+	Amount        int64
+	Currency      string
+	Price         int64
+	PriceCurrency string
+}
+
+// AccountName joins the given components into a colon separated account
+// name. Every component is sanitized, so it only contains letters, digits and
+// dashes and starts with an upper case letter or a digit, as beancount
+// requires.
+func AccountName(components ...string) string {
+	var (
+		parts = make([]string, 0, len(components))
+	)
+
+	for _, component := range components {
+		if part := sanitize(component); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ":")
+}
+
+func sanitize(component string) string {
+	var (
+		b    strings.Builder
+		dash bool
+	)
+
+	for _, r := range strings.TrimSpace(component) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if b.Len() == 0 {
+				r = unicode.ToUpper(r)
+			}
+
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	return b.String()
+}
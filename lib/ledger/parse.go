@@ -0,0 +1,475 @@
+package ledger
+
+import (
+	"bufio"
+	"errors"
+	"financo/lib/currency"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrUnbalanced = errors.New("ledger: transaction doesn't balance")
+
+	dateLayouts = []string{"2006-01-02", "2006/01/02", "2006.01.02"}
+	symbols     = map[string]string{"$": "USD", "€": "EUR", "£": "GBP"}
+	ignored     = map[string]bool{
+		"close":     true,
+		"balance":   true,
+		"pad":       true,
+		"price":     true,
+		"note":      true,
+		"event":     true,
+		"document":  true,
+		"commodity": true,
+		"custom":    true,
+		"query":     true,
+	}
+)
+
+// Parse reads a ledger, hledger or beancount journal from r. Account
+// declarations (ledger's account and beancount's open directives) and
+// transactions are parsed, every other directive is ignored.
+//
+// Amounts are converted into minor units of their currency. A posting
+// without amount is given the amount that balances the transaction, one
+// posting per currency if needed. Unit prices (@) are converted into total
+// prices (@@) and unbalanced virtual postings, written between parenthesis,
+// are dropped.
+//
+// It returns an error pointing to the first line that can't be parsed.
+func Parse(r io.Reader) (Journal, error) {
+	var (
+		journal = Journal{
+			Accounts:     make([]Account, 0, 50),
+			Transactions: make([]Transaction, 0, 200),
+		}
+		scanner = bufio.NewScanner(r)
+		current *parsedTransaction
+		line    int
+	)
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+
+		transaction, err := current.finish()
+		if err != nil {
+			return fmt.Errorf("ledger: line %d: %w", current.Line, err)
+		}
+
+		journal.Transactions = append(journal.Transactions, transaction)
+		current = nil
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if text == "" {
+			if err := flush(); err != nil {
+				return journal, err
+			}
+
+			continue
+		}
+
+		if text[0] == ' ' || text[0] == '\t' {
+			if current == nil {
+				continue
+			}
+
+			if err := current.addPosting(strings.TrimSpace(text)); err != nil {
+				return journal, fmt.Errorf("ledger: line %d: %w", line, err)
+			}
+
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return journal, err
+		}
+
+		switch {
+		case strings.HasPrefix(text, "account "):
+			journal.Accounts = append(journal.Accounts, Account{
+				Name: strings.TrimSpace(stripComment(strings.TrimPrefix(text, "account "))),
+			})
+		case text[0] >= '0' && text[0] <= '9':
+			account, transaction, err := parseDated(text)
+			if err != nil {
+				return journal, fmt.Errorf("ledger: line %d: %w", line, err)
+			}
+
+			if account != nil {
+				journal.Accounts = append(journal.Accounts, *account)
+			}
+
+			if transaction != nil {
+				transaction.Line = line
+				current = transaction
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return journal, errors.Join(errors.New("ledger: failed to read journal"), err)
+	}
+
+	return journal, flush()
+}
+
+type parsedTransaction struct {
+	Transaction
+
+	elided []string
+}
+
+// parseDated parses a line starting with a date, it is either a beancount
+// directive or a transaction header.
+func parseDated(text string) (*Account, *parsedTransaction, error) {
+	var (
+		fields = strings.Fields(text)
+		raw    = fields[0]
+	)
+
+	// ledger's auxiliary dates are ignored.
+	if i := strings.Index(raw, "="); i >= 0 {
+		raw = raw[:i]
+	}
+
+	date, err := parseDate(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rest := strings.TrimSpace(strings.TrimPrefix(text, fields[0]))
+	keyword := ""
+
+	if len(fields) > 1 {
+		keyword = fields[1]
+	}
+
+	switch {
+	case keyword == "open":
+		if len(fields) < 3 {
+			return nil, nil, errors.New("open directive without account")
+		}
+
+		account := &Account{Name: fields[2], OpenedAt: date}
+
+		if len(fields) > 3 && !strings.HasPrefix(fields[3], "\"") && !strings.Contains(fields[3], ",") {
+			account.Currency = fields[3]
+		}
+
+		return account, nil, nil
+	case ignored[keyword]:
+		return nil, nil, nil
+	}
+
+	transaction := &parsedTransaction{
+		Transaction: Transaction{
+			Date:     date,
+			Postings: make([]Posting, 0, 2),
+		},
+	}
+
+	switch keyword {
+	case "*", "txn":
+		rest = strings.TrimSpace(rest[len(keyword):])
+	case "!":
+		transaction.Pending = true
+		rest = strings.TrimSpace(rest[len(keyword):])
+	}
+
+	// ledger's transaction codes are ignored.
+	if strings.HasPrefix(rest, "(") {
+		if i := strings.Index(rest, ")"); i >= 0 {
+			rest = strings.TrimSpace(rest[i+1:])
+		}
+	}
+
+	transaction.Notes = parseNotes(rest)
+
+	return nil, transaction, nil
+}
+
+// parseNotes joins beancount's quoted payee and narration, or returns
+// ledger's payee without its comment.
+func parseNotes(rest string) string {
+	if !strings.HasPrefix(rest, "\"") {
+		return strings.TrimSpace(stripComment(rest))
+	}
+
+	var (
+		parts   = make([]string, 0, 2)
+		b       strings.Builder
+		quoted  bool
+		escaped bool
+	)
+
+	for _, r := range rest {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			if quoted && b.Len() > 0 {
+				parts = append(parts, b.String())
+				b.Reset()
+			}
+
+			quoted = !quoted
+		case quoted:
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (t *parsedTransaction) addPosting(text string) error {
+	if strings.HasPrefix(text, ";") || strings.HasPrefix(text, "#") {
+		return nil
+	}
+
+	// beancount's metadata.
+	if first := strings.Fields(text)[0]; strings.HasSuffix(first, ":") && unicode.IsLower([]rune(first)[0]) {
+		return nil
+	}
+
+	if strings.HasPrefix(text, "* ") || strings.HasPrefix(text, "! ") {
+		text = strings.TrimSpace(text[2:])
+	}
+
+	text = strings.TrimSpace(stripComment(text))
+	account, amount := splitPosting(text)
+
+	if strings.HasPrefix(account, "(") && strings.HasSuffix(account, ")") {
+		return nil
+	}
+
+	account = strings.TrimSuffix(strings.TrimPrefix(account, "["), "]")
+
+	// ledger's balance assertions and beancount's costs are ignored.
+	if i := strings.Index(amount, "="); i >= 0 {
+		amount = strings.TrimSpace(amount[:i])
+	}
+
+	if i := strings.Index(amount, "{"); i >= 0 {
+		if j := strings.Index(amount, "}"); j > i {
+			amount = strings.TrimSpace(amount[:i] + amount[j+1:])
+		}
+	}
+
+	if amount == "" {
+		t.elided = append(t.elided, account)
+		return nil
+	}
+
+	posting := Posting{Account: account}
+	price := ""
+	unit := false
+
+	if i := strings.Index(amount, "@@"); i >= 0 {
+		price = amount[i+2:]
+		amount = amount[:i]
+	} else if i := strings.Index(amount, "@"); i >= 0 {
+		price = amount[i+1:]
+		amount = amount[:i]
+		unit = true
+	}
+
+	value, code, err := parseAmount(amount)
+	if err != nil {
+		return err
+	}
+
+	posting.Amount = value.round(currency.Exponent(code))
+	posting.Currency = code
+
+	if price != "" {
+		total, code, err := parseAmount(price)
+		if err != nil {
+			return err
+		}
+
+		if unit {
+			total = total.mul(value.abs())
+		}
+
+		posting.Price = total.abs().round(currency.Exponent(code))
+		posting.PriceCurrency = code
+	}
+
+	t.Postings = append(t.Postings, posting)
+
+	return nil
+}
+
+// finish fills the elided posting and checks that the transaction balances.
+func (t *parsedTransaction) finish() (Transaction, error) {
+	var (
+		sums       = make(map[string]int64, 2)
+		currencies = make([]string, 0, 2)
+	)
+
+	for _, posting := range t.Postings {
+		amount, currency := posting.Amount, posting.Currency
+
+		if posting.Price != 0 {
+			amount, currency = posting.Price, posting.PriceCurrency
+
+			if posting.Amount < 0 {
+				amount = -amount
+			}
+		}
+
+		if _, ok := sums[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+
+		sums[currency] += amount
+	}
+
+	switch len(t.elided) {
+	case 0:
+		// Two postings in different currencies without price balance with
+		// an implicit conversion.
+		if len(currencies) == 2 && len(t.Postings) == 2 {
+			return t.Transaction, nil
+		}
+
+		for _, currency := range currencies {
+			if sums[currency] != 0 {
+				return t.Transaction, ErrUnbalanced
+			}
+		}
+	case 1:
+		for _, currency := range currencies {
+			if sums[currency] != 0 {
+				t.Postings = append(t.Postings, Posting{
+					Account:  t.elided[0],
+					Amount:   -sums[currency],
+					Currency: currency,
+				})
+			}
+		}
+	default:
+		return t.Transaction, errors.New("more than one posting without amount")
+	}
+
+	return t.Transaction, nil
+}
+
+// splitPosting splits a posting into its account and its amount, they are
+// separated by a tab or at least two spaces.
+func splitPosting(text string) (string, string) {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\t' || (text[i] == ' ' && i+1 < len(text) && text[i+1] == ' ') {
+			return text[:i], strings.TrimSpace(text[i:])
+		}
+	}
+
+	// beancount allows a single space before the amount, ledger account names
+	// can contain single spaces so the rest must look like an amount.
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] == ' ' && strings.ContainsAny(text[i+1:i+2], "+-.0123456789$€£") {
+			return text[:i], strings.TrimSpace(text[i:])
+		}
+	}
+
+	return text, ""
+}
+
+func stripComment(text string) string {
+	if i := strings.Index(text, ";"); i >= 0 {
+		return text[:i]
+	}
+
+	return text
+}
+
+func parseDate(raw string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, raw); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date \"%s\"", raw)
+}
+
+type decimal struct {
+	*big.Rat
+}
+
+func (d decimal) mul(o decimal) decimal {
+	return decimal{new(big.Rat).Mul(d.Rat, o.Rat)}
+}
+
+func (d decimal) abs() decimal {
+	return decimal{new(big.Rat).Abs(d.Rat)}
+}
+
+// round returns the decimal in minor units of a currency with the given
+// exponent, rounding half away from zero.
+func (d decimal) round(exponent int) int64 {
+	var (
+		scale  = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+		scaled = new(big.Rat).Mul(d.Rat, new(big.Rat).SetInt(scale))
+		num    = new(big.Int).Abs(scaled.Num())
+		den    = scaled.Denom()
+		q, m   = new(big.Int).QuoRem(num, den, new(big.Int))
+	)
+
+	if new(big.Int).Mul(m, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if scaled.Sign() < 0 {
+		q.Neg(q)
+	}
+
+	return q.Int64()
+}
+
+// parseAmount parses amounts like "-12.50 EUR", "EUR -12.50" or "$-12.50".
+func parseAmount(raw string) (decimal, string, error) {
+	var (
+		number    strings.Builder
+		commodity strings.Builder
+	)
+
+	for _, r := range strings.TrimSpace(raw) {
+		switch {
+		case unicode.IsDigit(r) || r == '.' || r == '-' || r == '+':
+			number.WriteRune(r)
+		case r == ',' || r == ' ' || r == '"':
+		default:
+			commodity.WriteRune(r)
+		}
+	}
+
+	value, ok := new(big.Rat).SetString(number.String())
+	if !ok {
+		return decimal{}, "", fmt.Errorf("invalid amount \"%s\"", strings.TrimSpace(raw))
+	}
+
+	currency := commodity.String()
+
+	if code, ok := symbols[currency]; ok {
+		currency = code
+	}
+
+	return decimal{value}, currency, nil
+}
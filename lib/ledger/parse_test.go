@@ -0,0 +1,149 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Journal
+		wantErr bool
+	}{
+		{
+			name: "beancount",
+			data: `option "title" "Personal"
+
+2024-01-01 open Assets:Bank EUR
+2024-01-01 open Expenses:Travel
+2024-01-01 close Assets:Old
+
+2024-03-05 ! "Hotel" "Two \"nights\""
+  id: "abc"
+  Expenses:Travel  110.00 USD @@ 100.00 EUR ; comment
+  Assets:Bank
+
+2024-03-06 balance Assets:Bank  -100.00 EUR
+`,
+			want: Journal{
+				Accounts: []Account{
+					{Name: "Assets:Bank", Currency: "EUR", OpenedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+					{Name: "Expenses:Travel", OpenedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				},
+				Transactions: []Transaction{
+					{
+						Date:    time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+						Pending: true,
+						Notes:   "Hotel Two \"nights\"",
+						Postings: []Posting{
+							{Account: "Expenses:Travel", Amount: 11000, Currency: "USD", Price: 10000, PriceCurrency: "EUR"},
+							{Account: "Assets:Bank", Amount: -10000, Currency: "EUR"},
+						},
+						Line: 7,
+					},
+				},
+			},
+		},
+		{
+			name: "ledger",
+			data: `; personal journal
+account Assets:Checking Account
+
+2024/10/01 * (42) Grocery Store ; weekly
+    Expenses:Food    $12.50
+    Expenses:Household  3 @ $2.005
+    (Budget:Food)    $-12.50
+    Assets:Checking Account
+
+2024/10/02 Salary
+    Assets:Checking Account  1,500.00 USD
+    Income:Salary           -1,500.00 USD
+`,
+			want: Journal{
+				Accounts: []Account{
+					{Name: "Assets:Checking Account"},
+				},
+				Transactions: []Transaction{
+					{
+						Date:  time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC),
+						Notes: "Grocery Store",
+						Postings: []Posting{
+							{Account: "Expenses:Food", Amount: 1250, Currency: "USD"},
+							{Account: "Expenses:Household", Amount: 300, Currency: "", Price: 602, PriceCurrency: "USD"},
+							{Account: "Assets:Checking Account", Amount: -1852, Currency: "USD"},
+						},
+						Line: 4,
+					},
+					{
+						Date:  time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC),
+						Notes: "Salary",
+						Postings: []Posting{
+							{Account: "Assets:Checking Account", Amount: 150000, Currency: "USD"},
+							{Account: "Income:Salary", Amount: -150000, Currency: "USD"},
+						},
+						Line: 10,
+					},
+				},
+			},
+		},
+		{
+			name: "unbalanced transaction",
+			data: `2024-01-01 * "Broken"
+  Expenses:Food  10.00 EUR
+  Assets:Bank   -9.00 EUR
+  Assets:Cash   -2.00 EUR
+`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid amount",
+			data:    "2024-01-01 * \"Broken\"\n  Expenses:Food  ten EUR\n  Assets:Bank\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseWrittenJournal(t *testing.T) {
+	var b strings.Builder
+
+	journal := Journal{
+		Accounts: []Account{
+			{Name: "Assets:Bank", Currency: "EUR", OpenedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Transactions: []Transaction{
+			{
+				Date:  time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Notes: "Opening",
+				Postings: []Posting{
+					{Account: "Assets:Bank", Amount: 50000, Currency: "EUR"},
+					{Account: "Equity:Opening-Balances", Amount: -50000, Currency: "EUR"},
+				},
+				Line: 3,
+			},
+		},
+	}
+
+	assert.NoError(t, Write(&b, Beancount, journal))
+
+	got, err := Parse(strings.NewReader(b.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, journal, got)
+}
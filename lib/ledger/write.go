@@ -0,0 +1,149 @@
+package ledger
+
+import (
+	"bufio"
+	"financo/lib/currency"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	quoted = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+	spaces = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+)
+
+// Format is the plain text accounting dialect written by [Write].
+type Format string
+
+const (
+	// Ledger is understood by both ledger and hledger.
+	Ledger    Format = "ledger"
+	Beancount Format = "beancount"
+)
+
+// ParseFormat maps raw to a [Format], hledger is accepted as an alias of
+// [Ledger].
+//
+// It returns an error if raw is not a supported [Format].
+func ParseFormat(raw string) (Format, error) {
+	switch strings.ToLower(raw) {
+	default:
+		return "", fmt.Errorf("ledger: invalid format \"%s\"", raw)
+	case "ledger", "hledger":
+		return Ledger, nil
+	case "beancount":
+		return Beancount, nil
+	}
+}
+
+// Write writes journal to w in the given [Format]. Accounts are declared
+// first, followed by the transactions in the given order.
+//
+// It returns an error if format is not supported or writing fails.
+func Write(w io.Writer, format Format, journal Journal) error {
+	var (
+		buf = bufio.NewWriter(w)
+	)
+
+	if format != Ledger && format != Beancount {
+		return fmt.Errorf("ledger: invalid format \"%s\"", format)
+	}
+
+	for _, account := range journal.Accounts {
+		writeAccount(buf, format, account)
+	}
+
+	for _, transaction := range journal.Transactions {
+		buf.WriteString("\n")
+		writeTransaction(buf, format, transaction)
+	}
+
+	return buf.Flush()
+}
+
+func writeAccount(buf *bufio.Writer, format Format, account Account) {
+	switch format {
+	case Beancount:
+		fmt.Fprintf(buf, "%s open %s", account.OpenedAt.Format(time.DateOnly), account.Name)
+
+		if account.Currency != "" {
+			fmt.Fprintf(buf, " %s", account.Currency)
+		}
+
+		buf.WriteString("\n")
+	default:
+		fmt.Fprintf(buf, "account %s\n", account.Name)
+	}
+}
+
+func writeTransaction(buf *bufio.Writer, format Format, transaction Transaction) {
+	var (
+		flag   = "*"
+		indent = "    "
+		width  = 0
+	)
+
+	if transaction.Pending {
+		flag = "!"
+	}
+
+	switch format {
+	case Beancount:
+		indent = "  "
+		fmt.Fprintf(buf, "%s %s \"%s\"\n", transaction.Date.Format(time.DateOnly), flag, quoted.Replace(notes(transaction)))
+	default:
+		fmt.Fprintf(buf, "%s %s %s\n", transaction.Date.Format(time.DateOnly), flag, notes(transaction))
+	}
+
+	for _, posting := range transaction.Postings {
+		width = max(width, len(posting.Account))
+	}
+
+	for _, posting := range transaction.Postings {
+		fmt.Fprintf(
+			buf,
+			"%s%-*s  %s %s",
+			indent,
+			width,
+			posting.Account,
+			FormatAmount(posting.Amount, currency.Exponent(posting.Currency)),
+			posting.Currency,
+		)
+
+		if posting.Price != 0 {
+			fmt.Fprintf(buf, " @@ %s %s", FormatAmount(posting.Price, currency.Exponent(posting.PriceCurrency)), posting.PriceCurrency)
+		}
+
+		buf.WriteString("\n")
+	}
+}
+
+func notes(transaction Transaction) string {
+	return strings.TrimSpace(spaces.Replace(transaction.Notes))
+}
+
+// FormatAmount writes an amount expressed in minor units of a currency with
+// the given exponent as a decimal number.
+func FormatAmount(amount int64, exponent int) string {
+	var (
+		sign  = ""
+		scale = int64(1)
+	)
+
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	for i := 0; i < exponent; i++ {
+		scale *= 10
+	}
+
+	if exponent <= 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, exponent, amount%scale)
+}
@@ -0,0 +1,92 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountName(t *testing.T) {
+	assert.Equal(t, "Assets:Main-bank:Credit-Card", AccountName("Assets", "main bank", " Credit  Card!"))
+	assert.Equal(t, "Expenses:9-to-5", AccountName("Expenses", "", "9 to 5"))
+	assert.Equal(t, "Equity:Opening-Balances", AccountName("Equity", "Opening Balances"))
+}
+
+func TestWrite(t *testing.T) {
+	journal := Journal{
+		Accounts: []Account{
+			{Name: "Assets:Bank", Currency: "EUR", OpenedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "Expenses:Travel", Currency: "USD", OpenedAt: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		Transactions: []Transaction{
+			{
+				Date:    time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+				Pending: true,
+				Notes:   "Hotel \"Plaza\"\nNew York",
+				Postings: []Posting{
+					{Account: "Expenses:Travel", Amount: 11000, Currency: "USD", Price: 10000, PriceCurrency: "EUR"},
+					{Account: "Assets:Bank", Amount: -10000, Currency: "EUR"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "ledger",
+			format: Ledger,
+			want: "account Assets:Bank\n" +
+				"account Expenses:Travel\n" +
+				"\n" +
+				"2024-03-05 ! Hotel \"Plaza\" New York\n" +
+				"    Expenses:Travel  110.00 USD @@ 100.00 EUR\n" +
+				"    Assets:Bank      -100.00 EUR\n",
+		},
+		{
+			name:   "beancount",
+			format: Beancount,
+			want: "2024-01-01 open Assets:Bank EUR\n" +
+				"2024-01-02 open Expenses:Travel USD\n" +
+				"\n" +
+				"2024-03-05 ! \"Hotel \\\"Plaza\\\" New York\"\n" +
+				"  Expenses:Travel  110.00 USD @@ 100.00 EUR\n" +
+				"  Assets:Bank      -100.00 EUR\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+
+			assert.NoError(t, Write(&b, tt.format, journal))
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+
+	assert.Error(t, Write(&strings.Builder{}, "gnucash", journal))
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		exponent int
+		want     string
+	}{
+		{amount: 1234, exponent: 2, want: "12.34"},
+		{amount: -5, exponent: 2, want: "-0.05"},
+		{amount: 1234, exponent: 0, want: "1234"},
+		{amount: 1234, exponent: 3, want: "1.234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatAmount(tt.amount, tt.exponent))
+		})
+	}
+}
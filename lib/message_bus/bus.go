@@ -1,22 +1,48 @@
 package message_bus
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
+	"hash/fnv"
+	"runtime/debug"
 	"sync"
+	"time"
+)
+
+var (
+	ErrClosed              = errors.New("message_bus: bus is closed")
+	ErrUnknownSubscription = errors.New("message_bus: unknown subscription")
+	ErrDuplicatedName      = errors.New("message_bus: subscription name already in use")
+	errDroppedByOverflow   = errors.New("message_bus: dropped by overflow policy")
 )
 
 // Bus is bus to which consumers subscribe to and publishers publish to.
 type Bus[Payload any] interface {
-	// Subscribe allows the given [Consumer] to subscribe to the [Bus].
+	// Subscribe allows the given [Consumer] to subscribe to the [Bus]. Every
+	// subscription gets its own queues and workers, see [Option].
+	//
+	// It returns an error if the [Bus] is closed or the subscription name is
+	// already in use.
+	Subscribe(consumer Consumer[Payload], opts ...Option) error
+
+	// Unsubscribe removes the subscription with the given name, the payloads
+	// already queued for it are still consumed.
 	//
-	// It returns an error if the [Bus] can't handle more consumers.
-	Subscribe(consumer Consumer[Payload]) error
+	// It returns an error if there is no subscription with that name.
+	Unsubscribe(name string) error
 
-	// Publish publishes the given message and its payload to the [Bus].
+	// Publish queues the given payload for every subscription. Depending on
+	// the [Overflow] policy of a subscription it blocks until there is room
+	// in its queue or drops its oldest queued payload.
 	//
-	// It returns an error if something fails during publishing.
+	// It returns an error if the [Bus] is closed.
 	Publish(payload Payload) error
+
+	// Close stops accepting payloads and drains the queues of every
+	// subscription, the workers are tracked by the [*sync.WaitGroup] given to
+	// [New], wait for it to know when every queued payload was consumed.
+	Close() error
 }
 
 type messageBus[Payload any] struct {
@@ -24,32 +50,79 @@ type messageBus[Payload any] struct {
 
 	name          string
 	wg            *sync.WaitGroup
-	consumers     [math.MaxUint8]Consumer[Payload]
-	consumerCount int
+	subscriptions map[string]*subscription[Payload]
+	subscribed    int
+	closed        bool
 }
 
 func New[Payload any](wg *sync.WaitGroup, name string) Bus[Payload] {
 	return &messageBus[Payload]{
 		name:          name,
 		wg:            wg,
-		consumers:     [math.MaxUint8]Consumer[Payload]{},
-		consumerCount: 0,
+		subscriptions: make(map[string]*subscription[Payload]),
 	}
 }
 
-func (m *messageBus[Payload]) Subscribe(consumer Consumer[Payload]) error {
-	if m.consumerCount > math.MaxUint8 {
-		return fmt.Errorf("message_bus: %s can't handle more consumers", m.name)
+func (m *messageBus[Payload]) Subscribe(consumer Consumer[Payload], opts ...Option) error {
+	m.RWMutex.Lock()
+	defer m.RWMutex.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	o := options{
+		name:     fmt.Sprintf("%s#%d", m.name, m.subscribed),
+		retry:    DefaultRetryPolicy,
+		workers:  DefaultWorkers,
+		buffer:   DefaultBuffer,
+		overflow: Block,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
 	}
 
-	m.wg.Add(1)
+	if _, ok := m.subscriptions[o.name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicatedName, o.name)
+	}
+
+	s := &subscription[Payload]{
+		bus:      m.name,
+		name:     o.name,
+		consumer: consumer,
+		retry:    o.retry,
+		overflow: o.overflow,
+		key:      o.key,
+		queues:   make([]chan Payload, max(o.workers, 1)),
+		closing:  make(chan struct{}),
+	}
+
+	m.wg.Add(len(s.queues))
+
+	for i := range s.queues {
+		s.queues[i] = make(chan Payload, max(o.buffer, 1))
+
+		go m.work(s, s.queues[i])
+	}
+
+	m.subscriptions[s.name] = s
+	m.subscribed++
+
+	return nil
+}
+
+func (m *messageBus[Payload]) Unsubscribe(name string) error {
 	m.RWMutex.Lock()
+	defer m.RWMutex.Unlock()
 
-	m.consumers[m.consumerCount] = consumer
-	m.consumerCount++
+	s, ok := m.subscriptions[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSubscription, name)
+	}
 
-	m.RWMutex.Unlock()
-	m.wg.Done()
+	delete(m.subscriptions, name)
+	s.close()
 
 	return nil
 }
@@ -58,12 +131,181 @@ func (m *messageBus[Payload]) Publish(payload Payload) error {
 	m.RWMutex.RLock()
 	defer m.RWMutex.RUnlock()
 
-	m.wg.Add(m.consumerCount + 1)
-	defer m.wg.Done()
+	if m.closed {
+		return ErrClosed
+	}
 
-	for i := 0; i < m.consumerCount; i++ {
-		go m.consumers[i].Consume(m.wg, payload)
+	for _, s := range m.subscriptions {
+		dropped, ok := s.enqueue(payload)
+		if ok {
+			s.deadLetter(dropped, errDroppedByOverflow, 0)
+		}
 	}
 
 	return nil
 }
+
+func (m *messageBus[Payload]) Close() error {
+	m.RWMutex.Lock()
+	defer m.RWMutex.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	m.closed = true
+
+	for name, s := range m.subscriptions {
+		delete(m.subscriptions, name)
+		s.close()
+	}
+
+	return nil
+}
+
+// work consumes the payloads of queue one at a time, so payloads sharing a
+// key are consumed in the order they were published. It returns once queue
+// is closed and drained.
+func (m *messageBus[Payload]) work(s *subscription[Payload], queue chan Payload) {
+	defer m.wg.Done()
+
+	for payload := range queue {
+		s.deliver(payload)
+	}
+}
+
+func consume[Payload any](consumer Consumer[Payload], payload Payload) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("message_bus: consumer panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return consumer.Consume(payload)
+}
+
+type subscription[Payload any] struct {
+	sync.Mutex
+
+	bus      string
+	name     string
+	consumer Consumer[Payload]
+	retry    RetryPolicy
+	overflow Overflow
+	key      func(payload any) string
+	queues   []chan Payload
+	next     int
+	closing  chan struct{}
+}
+
+// deliver gives payload to the consumer of s until it is consumed or the
+// retry policy of s is exhausted, then it is stored as a [DeadLetter]. Once
+// the subscription is closing the backoff is skipped, so queues drain fast.
+func (s *subscription[Payload]) deliver(payload Payload) {
+	var err error
+
+	attempt := 1
+
+	for ; ; attempt++ {
+		err = consume(s.consumer, payload)
+		if err == nil {
+			return
+		}
+
+		if attempt >= s.retry.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(s.retry.Backoff(attempt)):
+		case <-s.closing:
+		}
+	}
+
+	s.deadLetter(payload, err, attempt)
+}
+
+func (s *subscription[Payload]) deadLetter(payload Payload, err error, attempts int) {
+	letter := DeadLetter{
+		Bus:          s.bus,
+		Subscription: s.name,
+		Error:        err.Error(),
+		Attempts:     attempts,
+		FailedAt:     time.Now().UTC(),
+	}
+
+	letter.Payload, err = json.Marshal(payload)
+	if err != nil {
+		letter.Payload = json.RawMessage("null")
+		letter.Error = fmt.Sprintf("%s (payload can't be marshaled: %s)", letter.Error, err)
+	}
+
+	storeDeadLetter(letter)
+}
+
+// queue returns the queue of payload, payloads with the same key always share
+// a queue, payloads without a key are spread across the queues.
+func (s *subscription[Payload]) queue(payload Payload) chan Payload {
+	if len(s.queues) == 1 {
+		return s.queues[0]
+	}
+
+	if s.key != nil {
+		h := fnv.New32a()
+		h.Write([]byte(s.key(payload)))
+
+		return s.queues[h.Sum32()%uint32(len(s.queues))]
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.next = (s.next + 1) % len(s.queues)
+
+	return s.queues[s.next]
+}
+
+// enqueue adds payload to its queue following the overflow policy of s, it
+// returns the payload dropped to make room for it, if any.
+func (s *subscription[Payload]) enqueue(payload Payload) (Payload, bool) {
+	var (
+		queue   = s.queue(payload)
+		dropped Payload
+	)
+
+	if s.overflow == Block {
+		queue <- payload
+
+		return dropped, false
+	}
+
+	// Producers are serialized, so once a payload is dropped there is room
+	// for the new one, workers only ever take payloads out of the queue.
+	s.Lock()
+	defer s.Unlock()
+
+	select {
+	case queue <- payload:
+		return dropped, false
+	default:
+	}
+
+	select {
+	case dropped = <-queue:
+		queue <- payload
+
+		return dropped, true
+	default:
+		queue <- payload
+
+		return dropped, false
+	}
+}
+
+func (s *subscription[Payload]) close() {
+	close(s.closing)
+
+	for _, queue := range s.queues {
+		close(queue)
+	}
+}
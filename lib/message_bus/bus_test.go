@@ -0,0 +1,232 @@
+package message_bus
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     3,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 300 * time.Millisecond},
+		{attempt: 3, want: 900 * time.Millisecond},
+		{attempt: 4, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.Backoff(tt.attempt))
+	}
+}
+
+func TestBusDelivery(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+	tests := []struct {
+		name         string
+		consumer     func(attempt int32) error
+		wantAttempts int32
+		wantLetter   bool
+	}{
+		{
+			name:         "consumed",
+			consumer:     func(attempt int32) error { return nil },
+			wantAttempts: 1,
+		},
+		{
+			name: "consumed after retries",
+			consumer: func(attempt int32) error {
+				if attempt < 3 {
+					return errors.New("unavailable")
+				}
+				return nil
+			},
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries exhausted",
+			consumer:     func(attempt int32) error { return errors.New("unavailable") },
+			wantAttempts: 3,
+			wantLetter:   true,
+		},
+		{
+			name:         "panic",
+			consumer:     func(attempt int32) error { panic("boom") },
+			wantAttempts: 3,
+			wantLetter:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				wg       = new(sync.WaitGroup)
+				bus      = New[int](wg, "test")
+				attempts atomic.Int32
+				letters  []DeadLetter
+			)
+
+			SetDeadLetterStore(DeadLetterStoreFunc(func(letter DeadLetter) error {
+				letters = append(letters, letter)
+				return nil
+			}))
+			defer SetDeadLetterStore(nil)
+
+			err := bus.Subscribe(ConsumerFunc[int](func(payload int) error {
+				return tt.consumer(attempts.Add(1))
+			}), WithName("consumer"), WithRetry(retry))
+			assert.NoError(t, err)
+
+			assert.NoError(t, bus.Publish(42))
+			assert.NoError(t, bus.Close())
+
+			wg.Wait()
+
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+
+			if !tt.wantLetter {
+				assert.Empty(t, letters)
+				return
+			}
+
+			if assert.Len(t, letters, 1) {
+				assert.Equal(t, "test", letters[0].Bus)
+				assert.Equal(t, "consumer", letters[0].Subscription)
+				assert.Equal(t, "42", string(letters[0].Payload))
+				assert.Equal(t, 3, letters[0].Attempts)
+			}
+		})
+	}
+}
+
+func TestBusOrderedByKey(t *testing.T) {
+	type payload struct {
+		Key   string
+		Value int
+	}
+
+	var (
+		wg   = new(sync.WaitGroup)
+		bus  = New[payload](wg, "test")
+		mu   sync.Mutex
+		seen = make(map[string][]int)
+	)
+
+	err := bus.Subscribe(
+		ConsumerFunc[payload](func(p payload) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			seen[p.Key] = append(seen[p.Key], p.Value)
+			return nil
+		}),
+		WithWorkers(4),
+		WithBuffer(2),
+		WithKey(func(p payload) string { return p.Key }),
+	)
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, bus.Publish(payload{Key: string(rune('a' + i%5)), Value: i}))
+	}
+
+	assert.NoError(t, bus.Close())
+
+	wg.Wait()
+
+	assert.Len(t, seen, 5)
+
+	for key, values := range seen {
+		assert.Len(t, values, 20, key)
+		assert.IsIncreasing(t, values, key)
+	}
+}
+
+func TestBusDropOldest(t *testing.T) {
+	var (
+		wg       = new(sync.WaitGroup)
+		bus      = New[int](wg, "test")
+		started  = make(chan struct{}, 3)
+		release  = make(chan struct{})
+		consumed []int
+		letters  []DeadLetter
+	)
+
+	SetDeadLetterStore(DeadLetterStoreFunc(func(letter DeadLetter) error {
+		letters = append(letters, letter)
+		return nil
+	}))
+	defer SetDeadLetterStore(nil)
+
+	err := bus.Subscribe(
+		ConsumerFunc[int](func(payload int) error {
+			started <- struct{}{}
+			<-release
+			consumed = append(consumed, payload)
+			return nil
+		}),
+		WithBuffer(2),
+		WithOverflow(DropOldest),
+	)
+	assert.NoError(t, err)
+
+	// The worker takes 1 and waits, 2 and 3 fill the queue and 4 drops 2.
+	assert.NoError(t, bus.Publish(1))
+	<-started
+	assert.NoError(t, bus.Publish(2))
+	assert.NoError(t, bus.Publish(3))
+	assert.NoError(t, bus.Publish(4))
+
+	close(release)
+	assert.NoError(t, bus.Close())
+
+	wg.Wait()
+
+	assert.Equal(t, []int{1, 3, 4}, consumed)
+
+	if assert.Len(t, letters, 1) {
+		assert.Equal(t, "2", string(letters[0].Payload))
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	var (
+		wg       = new(sync.WaitGroup)
+		bus      = New[int](wg, "test")
+		consumed atomic.Int32
+	)
+
+	err := bus.Subscribe(ConsumerFunc[int](func(payload int) error {
+		consumed.Add(1)
+		return nil
+	}), WithName("consumer"))
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, bus.Subscribe(ConsumerFunc[int](func(payload int) error { return nil }), WithName("consumer")), ErrDuplicatedName)
+
+	assert.NoError(t, bus.Publish(1))
+	assert.NoError(t, bus.Unsubscribe("consumer"))
+	assert.ErrorIs(t, bus.Unsubscribe("consumer"), ErrUnknownSubscription)
+	assert.NoError(t, bus.Publish(2))
+
+	assert.NoError(t, bus.Close())
+	assert.ErrorIs(t, bus.Publish(3), ErrClosed)
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), consumed.Load())
+}
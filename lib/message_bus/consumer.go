@@ -1,19 +1,17 @@
 package message_bus
 
-import "sync"
-
 // Consumer is a service that consumes [Payload]s
 type Consumer[Payload any] interface {
-	// Consume is a function that accepts a [*sync.WaitGroup] from the [Broker]
-	// and the [Payload].
+	// Consume is a function that accepts the published [Payload].
 	//
-	// The provided [*sync.WaitGroup] should be only use to indicate when the
-	// work is done.
-	Consume(wg *sync.WaitGroup, payload Payload)
+	// It returns an error if the [Payload] couldn't be consumed, the [Bus]
+	// retries it following the [RetryPolicy] of the subscription. A panic is
+	// recovered and handled as an error.
+	Consume(payload Payload) error
 }
 
-type ConsumerFunc[Payload any] func(wg *sync.WaitGroup, payload Payload)
+type ConsumerFunc[Payload any] func(payload Payload) error
 
-func (f ConsumerFunc[Payload]) Consume(wg *sync.WaitGroup, payload Payload) {
-	f(wg, payload)
+func (f ConsumerFunc[Payload]) Consume(payload Payload) error {
+	return f(payload)
 }
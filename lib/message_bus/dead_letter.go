@@ -0,0 +1,60 @@
+package message_bus
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// DeadLetter is a [Payload] a [Consumer] failed to consume after exhausting
+// the retries of its subscription.
+type DeadLetter struct {
+	Bus          string
+	Subscription string
+	Payload      json.RawMessage
+	Error        string
+	Attempts     int
+	FailedAt     time.Time
+}
+
+// DeadLetterStore keeps [DeadLetter]s so they can be inspected.
+type DeadLetterStore interface {
+	Store(letter DeadLetter) error
+}
+
+type DeadLetterStoreFunc func(letter DeadLetter) error
+
+func (f DeadLetterStoreFunc) Store(letter DeadLetter) error {
+	return f(letter)
+}
+
+var (
+	deadLetterStore   DeadLetterStore
+	deadLetterStoreMu sync.RWMutex
+)
+
+// SetDeadLetterStore sets the [DeadLetterStore] of every [Bus], please do
+// this on program startup. Until a store is set dead letters are only logged.
+func SetDeadLetterStore(store DeadLetterStore) {
+	deadLetterStoreMu.Lock()
+	defer deadLetterStoreMu.Unlock()
+
+	deadLetterStore = store
+}
+
+func storeDeadLetter(letter DeadLetter) {
+	deadLetterStoreMu.RLock()
+	store := deadLetterStore
+	deadLetterStoreMu.RUnlock()
+
+	if store == nil {
+		log.Printf("message_bus: %s dead letter for %s: %s\n", letter.Bus, letter.Subscription, letter.Error)
+		return
+	}
+
+	err := store.Store(letter)
+	if err != nil {
+		log.Printf("message_bus: failed to store %s dead letter for %s: %s\n", letter.Bus, letter.Subscription, err)
+	}
+}
@@ -0,0 +1,479 @@
+package message_bus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"financo/lib/resp"
+)
+
+// Dialer opens a connection to the Redis server backing a Redis [Bus].
+type Dialer func(ctx context.Context) (*resp.Conn, error)
+
+const (
+	// RedisMaxLen is the approximate number of entries kept in every stream.
+	RedisMaxLen = 100_000
+
+	// RedisClaimIdle is how long an entry stays pending before another
+	// consumer of the group claims it, e.g. because its process is gone.
+	RedisClaimIdle = time.Minute
+
+	redisBlock   = time.Second
+	redisTimeout = 5 * time.Second
+	redisRetry   = time.Second
+	redisField   = "payload"
+)
+
+// redisBuses numbers the Redis buses of the process, so consumer names are
+// unique even for buses sharing a stream.
+var redisBuses atomic.Int64
+
+type redisBus[Payload any] struct {
+	sync.Mutex
+
+	name          string
+	stream        string
+	consumer      string
+	wg            *sync.WaitGroup
+	dial          Dialer
+	conn          *resp.Conn
+	subscriptions map[string]*redisSubscription[Payload]
+	subscribed    int
+	closed        bool
+}
+
+type redisSubscription[Payload any] struct {
+	*subscription[Payload]
+
+	count   int
+	readers sync.WaitGroup
+}
+
+// NewRedis returns a [Bus] backed by a Redis stream, so payloads are shared
+// by every process using a bus with the same name.
+//
+// Every subscription is a consumer group named after the subscription, so
+// processes subscribing with the same name share its payloads and each
+// payload is consumed by only one of them, name subscriptions accordingly.
+// Every worker of a subscription is a consumer of the group, reading up to
+// its buffer of entries at a time. Entries are acknowledged once consumed or
+// stored as a [DeadLetter], entries left pending by a stopped process are
+// claimed after [RedisClaimIdle], so payloads are consumed at least once.
+//
+// Keys and overflow policies don't apply, the stream is the queue and the
+// group spreads it across the workers.
+func NewRedis[Payload any](wg *sync.WaitGroup, name string, dial Dialer) Bus[Payload] {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &redisBus[Payload]{
+		name:          name,
+		stream:        "message_bus:" + name,
+		consumer:      fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), redisBuses.Add(1)),
+		wg:            wg,
+		dial:          dial,
+		subscriptions: make(map[string]*redisSubscription[Payload]),
+	}
+}
+
+func (b *redisBus[Payload]) Subscribe(consumer Consumer[Payload], opts ...Option) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	o := options{
+		name:    fmt.Sprintf("%s#%d", b.name, b.subscribed),
+		retry:   DefaultRetryPolicy,
+		workers: DefaultWorkers,
+		buffer:  DefaultBuffer,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if _, ok := b.subscriptions[o.name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicatedName, o.name)
+	}
+
+	// The group starts at the end of the stream the first time, afterwards
+	// it resumes from where the last process using it stopped.
+	_, err := b.do(context.Background(), "XGROUP", "CREATE", b.stream, o.name, "$", "MKSTREAM")
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return errors.Join(errors.New("message_bus: failed to create consumer group"), err)
+	}
+
+	s := &redisSubscription[Payload]{
+		subscription: &subscription[Payload]{
+			bus:      b.name,
+			name:     o.name,
+			consumer: consumer,
+			retry:    o.retry,
+			closing:  make(chan struct{}),
+		},
+		count: max(o.buffer, 1),
+	}
+
+	workers := max(o.workers, 1)
+
+	b.wg.Add(workers)
+	s.readers.Add(workers)
+
+	for i := range workers {
+		go b.read(s, fmt.Sprintf("%s-%d", b.consumer, i))
+	}
+
+	b.subscriptions[s.name] = s
+	b.subscribed++
+
+	return nil
+}
+
+// Unsubscribe removes the subscription with the given name, its workers
+// finish the entries they already read and then its consumer group is
+// destroyed, along with the entries it didn't read yet.
+func (b *redisBus[Payload]) Unsubscribe(name string) error {
+	b.Lock()
+	defer b.Unlock()
+
+	s, ok := b.subscriptions[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSubscription, name)
+	}
+
+	delete(b.subscriptions, name)
+	s.close()
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		s.readers.Wait()
+
+		conn, err := b.dial(context.Background())
+		if err != nil {
+			log.Printf("message_bus: failed to destroy consumer group %s: %s\n", name, err)
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+		defer cancel()
+
+		_, err = conn.Do(ctx, "XGROUP", "DESTROY", b.stream, name)
+		if err != nil {
+			log.Printf("message_bus: failed to destroy consumer group %s: %s\n", name, err)
+		}
+	}()
+
+	return nil
+}
+
+// Publish appends payload to the stream.
+//
+// It returns an error if the [Bus] is closed or the payload can't be
+// appended.
+func (b *redisBus[Payload]) Publish(payload Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Join(errors.New("message_bus: failed to marshal payload"), err)
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	_, err = b.do(
+		context.Background(),
+		"XADD", b.stream, "MAXLEN", "~", strconv.Itoa(RedisMaxLen), "*", redisField, string(data),
+	)
+	if err != nil {
+		return errors.Join(errors.New("message_bus: failed to publish payload"), err)
+	}
+
+	return nil
+}
+
+// Close stops accepting payloads and stops the workers of every
+// subscription once they consumed the entries they already read, the
+// consumer groups are kept so the next process resumes from there.
+func (b *redisBus[Payload]) Close() error {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	b.closed = true
+
+	for name, s := range b.subscriptions {
+		delete(b.subscriptions, name)
+		s.close()
+	}
+
+	if b.conn != nil {
+		b.conn.Close()
+		b.conn = nil
+	}
+
+	return nil
+}
+
+// do runs a command on the connection of the bus, dialing it if needed. The
+// caller must hold the lock of the bus.
+func (b *redisBus[Payload]) do(ctx context.Context, args ...string) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+
+	if b.conn == nil {
+		conn, err := b.dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		b.conn = conn
+	}
+
+	reply, err := b.conn.Do(ctx, args...)
+
+	var replyErr resp.Error
+	if err != nil && !errors.As(err, &replyErr) {
+		b.conn.Close()
+		b.conn = nil
+	}
+
+	return reply, err
+}
+
+// read is a worker of s, it first claims the entries other consumers left
+// pending for too long, then consumes the entries pending for consumer and
+// then new entries, claiming again every [RedisClaimIdle].
+// It returns once s is closing.
+func (b *redisBus[Payload]) read(s *redisSubscription[Payload], consumer string) {
+	defer b.wg.Done()
+	defer s.readers.Done()
+
+	var (
+		conn    *resp.Conn
+		err     error
+		pending = true
+		claimed time.Time
+	)
+
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		default:
+		}
+
+		if conn == nil {
+			conn, err = b.dial(context.Background())
+			if err != nil {
+				log.Printf("message_bus: %s failed to connect: %s\n", s.name, err)
+				b.wait(s)
+				continue
+			}
+		}
+
+		var entries []entry
+
+		switch {
+		case time.Since(claimed) >= RedisClaimIdle:
+			entries, err = b.claim(conn, s, consumer)
+			claimed = time.Now()
+		case pending:
+			entries, err = b.readGroup(conn, s, consumer, "0")
+			pending = err != nil || len(entries) > 0
+		default:
+			entries, err = b.readGroup(conn, s, consumer, ">")
+		}
+
+		if err != nil {
+			log.Printf("message_bus: %s failed to read entries: %s\n", s.name, err)
+			conn.Close()
+			conn = nil
+			b.wait(s)
+			continue
+		}
+
+		for _, e := range entries {
+			b.handle(conn, s, e)
+		}
+	}
+}
+
+func (b *redisBus[Payload]) wait(s *redisSubscription[Payload]) {
+	select {
+	case <-time.After(redisRetry):
+	case <-s.closing:
+	}
+}
+
+func (b *redisBus[Payload]) readGroup(conn *resp.Conn, s *redisSubscription[Payload], consumer, id string) ([]entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisBlock+redisTimeout)
+	defer cancel()
+
+	reply, err := conn.Do(
+		ctx,
+		"XREADGROUP", "GROUP", s.name, consumer,
+		"COUNT", strconv.Itoa(s.count),
+		"BLOCK", strconv.FormatInt(redisBlock.Milliseconds(), 10),
+		"STREAMS", b.stream, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, err := resp.Array(reply)
+	if err != nil || len(streams) == 0 {
+		return nil, err
+	}
+
+	stream, err := resp.Array(streams[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stream) != 2 {
+		return nil, fmt.Errorf("%w: unexpected stream reply", resp.ErrProtocol)
+	}
+
+	return parseEntries(stream[1])
+}
+
+func (b *redisBus[Payload]) claim(conn *resp.Conn, s *redisSubscription[Payload], consumer string) ([]entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	reply, err := conn.Do(
+		ctx,
+		"XAUTOCLAIM", b.stream, s.name, consumer,
+		strconv.FormatInt(RedisClaimIdle.Milliseconds(), 10), "0-0",
+		"COUNT", strconv.Itoa(s.count),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := resp.Array(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) < 2 {
+		return nil, fmt.Errorf("%w: unexpected claim reply", resp.ErrProtocol)
+	}
+
+	return parseEntries(values[1])
+}
+
+// handle delivers the payload of e and acknowledges it, an entry that can't
+// be decoded is stored as a [DeadLetter] right away and an entry trimmed from
+// the stream is only acknowledged.
+func (b *redisBus[Payload]) handle(conn *resp.Conn, s *redisSubscription[Payload], e entry) {
+	if e.payload != "" {
+		var payload Payload
+
+		err := json.Unmarshal([]byte(e.payload), &payload)
+		if err != nil {
+			raw, _ := json.Marshal(e.payload)
+
+			storeDeadLetter(DeadLetter{
+				Bus:          s.bus,
+				Subscription: s.name,
+				Payload:      raw,
+				Error:        fmt.Sprintf("message_bus: failed to decode entry %s: %s", e.id, err),
+				FailedAt:     time.Now().UTC(),
+			})
+		} else {
+			s.deliver(payload)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	_, err := conn.Do(ctx, "XACK", b.stream, s.name, e.id)
+	if err != nil {
+		log.Printf("message_bus: %s failed to acknowledge entry %s: %s\n", s.name, e.id, err)
+	}
+}
+
+type entry struct {
+	id      string
+	payload string
+}
+
+// parseEntries parses a list of stream entries, each one an id followed by
+// its fields and values. Entries trimmed from the stream while pending have
+// no fields, they are kept so they get acknowledged.
+func parseEntries(reply any) ([]entry, error) {
+	values, err := resp.Array(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]entry, 0, len(values))
+
+	for _, value := range values {
+		fields, err := resp.Array(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: unexpected entry reply", resp.ErrProtocol)
+		}
+
+		id, err := resp.String(fields[0])
+		if err != nil {
+			return nil, err
+		}
+
+		e := entry{id: id}
+
+		pairs, err := resp.Array(fields[1])
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i+1 < len(pairs); i += 2 {
+			if pairs[i] == redisField {
+				e.payload, err = resp.String(pairs[i+1])
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
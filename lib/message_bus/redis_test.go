@@ -0,0 +1,118 @@
+package message_bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"financo/lib/resp"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redisDialer returns a [Dialer] for the server at REDIS_ADDR, the test is
+// skipped without one, e.g. REDIS_ADDR=localhost:6379 with a local
+// redis-server.
+func redisDialer(t *testing.T) Dialer {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR is not set")
+	}
+
+	return func(ctx context.Context) (*resp.Conn, error) {
+		return resp.Dial(ctx, addr)
+	}
+}
+
+func TestRedisBusSharesGroupsAcrossProcesses(t *testing.T) {
+	var (
+		dial  = redisDialer(t)
+		name  = fmt.Sprintf("test_%d", time.Now().UnixNano())
+		wg    = new(sync.WaitGroup)
+		first = NewRedis[int](wg, name, dial)
+		other = NewRedis[int](wg, name, dial)
+
+		mu       sync.Mutex
+		consumed = make(map[int]int)
+		audited  = make(chan int, 10)
+	)
+
+	count := ConsumerFunc[int](func(payload int) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		consumed[payload]++
+
+		return nil
+	})
+
+	assert.NoError(t, first.Subscribe(count, WithName("count")))
+	assert.NoError(t, other.Subscribe(count, WithName("count")))
+	assert.NoError(t, other.Subscribe(ConsumerFunc[int](func(payload int) error {
+		audited <- payload
+		return nil
+	}), WithName("audit")))
+
+	for i := range 10 {
+		assert.NoError(t, first.Publish(i))
+	}
+
+	for range 10 {
+		select {
+		case <-audited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("payload not consumed")
+		}
+	}
+
+	assert.NoError(t, first.Unsubscribe("count"))
+	assert.NoError(t, other.Unsubscribe("count"))
+	assert.NoError(t, other.Unsubscribe("audit"))
+	assert.NoError(t, first.Close())
+	assert.NoError(t, other.Close())
+	wg.Wait()
+
+	assert.Len(t, consumed, 10)
+	for i, n := range consumed {
+		assert.Equal(t, 1, n, "payload %d", i)
+	}
+}
+
+func TestRedisBusStoresDeadLetters(t *testing.T) {
+	var (
+		dial    = redisDialer(t)
+		wg      = new(sync.WaitGroup)
+		bus     = NewRedis[int](wg, fmt.Sprintf("test_%d", time.Now().UnixNano()), dial)
+		letters = make(chan DeadLetter, 1)
+	)
+
+	SetDeadLetterStore(DeadLetterStoreFunc(func(letter DeadLetter) error {
+		letters <- letter
+		return nil
+	}))
+	defer SetDeadLetterStore(nil)
+
+	assert.NoError(t, bus.Subscribe(
+		ConsumerFunc[int](func(payload int) error { return errors.New("unavailable") }),
+		WithName("failing"),
+		WithRetry(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+	))
+	assert.NoError(t, bus.Publish(7))
+
+	select {
+	case letter := <-letters:
+		assert.Equal(t, "failing", letter.Subscription)
+		assert.Equal(t, 2, letter.Attempts)
+		assert.JSONEq(t, "7", string(letter.Payload))
+	case <-time.After(5 * time.Second):
+		t.Fatal("dead letter not stored")
+	}
+
+	assert.NoError(t, bus.Unsubscribe("failing"))
+	assert.NoError(t, bus.Close())
+	wg.Wait()
+}
@@ -0,0 +1,126 @@
+package message_bus
+
+import (
+	"math"
+	"time"
+)
+
+// RetryPolicy describes how many times a [Consumer] is given a [Payload] and
+// how long the [Bus] waits between attempts. The wait starts at
+// InitialBackoff and is multiplied by Multiplier after every failed attempt,
+// up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// Overflow is what a [Bus] does when a subscription queue is full.
+type Overflow int
+
+const (
+	// Block makes Publish wait until there is room in the queue, slowing
+	// publishers down to the pace of the consumer.
+	Block Overflow = iota
+	// DropOldest drops the oldest queued payload to make room, the dropped
+	// payload is stored as a [DeadLetter].
+	DropOldest
+)
+
+const (
+	DefaultWorkers = 1
+	DefaultBuffer  = 64
+)
+
+// DefaultRetryPolicy is used by subscriptions that don't provide their own.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// Backoff returns how long to wait after the given failed attempt, attempts
+// start at 1.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+
+	return time.Duration(backoff)
+}
+
+// Option configures a subscription to a [Bus].
+type Option func(s *options)
+
+type options struct {
+	name     string
+	retry    RetryPolicy
+	workers  int
+	buffer   int
+	overflow Overflow
+	key      func(payload any) string
+}
+
+// WithName names the subscription, the name is used to unsubscribe and
+// identifies the subscription in its dead letters.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithRetry replaces the [DefaultRetryPolicy] of the subscription.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithWorkers sets how many payloads of the subscription are consumed
+// concurrently, every worker has its own queue. It defaults to
+// [DefaultWorkers].
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+// WithBuffer sets how many payloads each worker queue holds before the
+// [Overflow] policy applies. It defaults to [DefaultBuffer].
+func WithBuffer(buffer int) Option {
+	return func(o *options) {
+		o.buffer = buffer
+	}
+}
+
+// WithOverflow sets the [Overflow] policy of the subscription, it defaults to
+// [Block].
+func WithOverflow(overflow Overflow) Option {
+	return func(o *options) {
+		o.overflow = overflow
+	}
+}
+
+// WithKey routes payloads with the same key to the same worker, so they are
+// consumed in the order they were published, e.g. every transaction of an
+// account. Payloads that aren't of type [Payload] get an empty key.
+func WithKey[Payload any](key func(payload Payload) string) Option {
+	return func(o *options) {
+		o.key = func(payload any) string {
+			p, ok := payload.(Payload)
+			if !ok {
+				return ""
+			}
+
+			return key(p)
+		}
+	}
+}
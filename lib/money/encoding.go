@@ -0,0 +1,98 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"financo/lib/currency"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type jsonMoney struct {
+	Amount   int64         `json:"amount"`
+	Currency currency.Type `json:"currency"`
+}
+
+// UnmarshalJSON receives a buffer b, and ensures that the provided value is a
+// valid [Money], an object with its amount in minor units and its currency. So
+// [Money] satisfies the [json.Unmarshaler] interface.
+//
+// It returns an error if the buffer can't be unmarshal into an object or the
+// currency is not supported.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var v jsonMoney
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Currency == "" {
+		return errors.New("money: missing currency")
+	}
+
+	*m = New(v.Amount, v.Currency)
+
+	return nil
+}
+
+// MarshalJSON returns the json encoding of [Money]. So [Money] satisfies the
+// [json.Marshaler] interface.
+//
+// It returns an error if the currency is not supported.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.amount, Currency: m.currency})
+}
+
+// Scan takes the value returned by the SQL database and maps it to [Money].
+// Money is stored in a single text column as its amount in minor units
+// followed by its currency, like "1234 EUR". So [Money] satisfies the
+// [sql.Scanner] interface.
+//
+// It returns an error if the value is not a valid [Money].
+func (m *Money) Scan(value any) error {
+	var s string
+
+	switch v := value.(type) {
+	default:
+		return errors.New("money: invalid column type")
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return fmt.Errorf("money: invalid money \"%s\"", s)
+	}
+
+	amount, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("money: invalid amount \"%s\"", fields[0])
+	}
+
+	var cur currency.Type
+
+	if err := cur.Scan(fields[1]); err != nil {
+		return err
+	}
+
+	*m = New(amount, cur)
+
+	return nil
+}
+
+// Value returns the value of [Money] to be stored in the SQL database. So
+// [Money] satisfies the [driver.Valuer] interface.
+//
+// It returns an error if the currency is not supported.
+func (m Money) Value() (driver.Value, error) {
+	cur, err := m.currency.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprintf("%d %s", m.amount, cur), nil
+}
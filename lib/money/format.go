@@ -0,0 +1,143 @@
+package money
+
+import (
+	"strconv"
+	"strings"
+)
+
+// locale describes how a locale writes amounts of money.
+type locale struct {
+	decimal string
+	group   string
+	// after writes the symbol after the number.
+	after bool
+	// space separates the symbol and the number with a no-break space.
+	space bool
+}
+
+var (
+	english = locale{decimal: ".", group: ","}
+
+	locales = map[string]locale{
+		"en":    english,
+		"ja":    english,
+		"zh":    english,
+		"de":    {decimal: ",", group: ".", after: true, space: true},
+		"es":    {decimal: ",", group: ".", after: true, space: true},
+		"it":    {decimal: ",", group: ".", after: true, space: true},
+		"pt":    {decimal: ",", group: ".", after: true, space: true},
+		"fr":    {decimal: ",", group: "\u202f", after: true, space: true},
+		"nl":    {decimal: ",", group: ".", space: true},
+		"de-CH": {decimal: ".", group: "’", space: true},
+		"it-CH": {decimal: ".", group: "’", space: true},
+		"fr-CH": {decimal: ",", group: "\u202f", after: true, space: true},
+		"fr-CA": {decimal: ",", group: "\u00a0", after: true, space: true},
+	}
+)
+
+// Format writes [Money] as a human readable amount using the conventions of
+// the BCP 47 tag, like "en-US", "de" or "fr-CH". Tags without conventions of
+// their own fall back to their language and then to English.
+//
+//	New(123456, currency.EUR).Format("en") // €1,234.56
+//	New(123456, currency.EUR).Format("de") // 1.234,56 €
+func (m Money) Format(tag string) string {
+	var (
+		l      = lookupLocale(tag)
+		b      strings.Builder
+		symbol = string(m.currency)
+	)
+
+	if entry, ok := m.currency.Entry(); ok {
+		symbol = entry.Symbol
+	}
+
+	if m.amount < 0 {
+		b.WriteString("-")
+	}
+
+	if !l.after {
+		b.WriteString(symbol)
+
+		if l.space {
+			b.WriteString("\u00a0")
+		}
+	}
+
+	b.WriteString(l.number(m.amount, m.currency.Exponent()))
+
+	if l.after {
+		if l.space {
+			b.WriteString("\u00a0")
+		}
+
+		b.WriteString(symbol)
+	}
+
+	return b.String()
+}
+
+// String returns [Money] formatted with the English conventions.
+func (m Money) String() string {
+	return m.Format("en")
+}
+
+func lookupLocale(tag string) locale {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+
+	for tag != "" {
+		for key, l := range locales {
+			if strings.EqualFold(key, tag) {
+				return l
+			}
+		}
+
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			break
+		}
+
+		tag = tag[:i]
+	}
+
+	return english
+}
+
+// number writes the absolute value of amount as a decimal with exponent
+// decimals and grouped thousands.
+func (l locale) number(amount int64, exponent int) string {
+	digits := strconv.FormatUint(abs(amount), 10)
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	var (
+		integer  = digits[:len(digits)-exponent]
+		fraction = digits[len(digits)-exponent:]
+		b        strings.Builder
+	)
+
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.group)
+		}
+
+		b.WriteRune(r)
+	}
+
+	if exponent > 0 {
+		b.WriteString(l.decimal)
+		b.WriteString(fraction)
+	}
+
+	return b.String()
+}
+
+func abs(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-(amount + 1)) + 1
+	}
+
+	return uint64(amount)
+}
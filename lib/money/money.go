@@ -0,0 +1,189 @@
+// Package money pairs amounts, expressed in minor units, with their currency.
+package money
+
+import (
+	"errors"
+	"financo/lib/currency"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	ErrOverflow         = errors.New("money: amount overflows")
+	ErrInvalidRatios    = errors.New("money: ratios must be positive and add up to more than zero")
+)
+
+// Money is an amount of currency expressed in its minor units, so 12.34 EUR
+// is 1234 and 1234 JPY is 1234.
+type Money struct {
+	amount   int64
+	currency currency.Type
+}
+
+// New returns [Money] of amount minor units of cur.
+func New(amount int64, cur currency.Type) Money {
+	return Money{amount: amount, currency: cur}
+}
+
+// Amount returns the amount of [Money] in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the currency of [Money].
+func (m Money) Currency() currency.Type {
+	return m.currency
+}
+
+// IsZero reports if [Money] amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative reports if [Money] amount is lower than zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Negate returns [Money] with the opposite amount.
+//
+// It returns [ErrOverflow] if the opposite amount doesn't fit in an int64.
+func (m Money) Negate() (Money, error) {
+	if m.amount == math.MinInt64 {
+		return m, ErrOverflow
+	}
+
+	return Money{amount: -m.amount, currency: m.currency}, nil
+}
+
+// Add returns the sum of [Money] and o.
+//
+// It returns [ErrCurrencyMismatch] if both have different currencies and
+// [ErrOverflow] if the result doesn't fit in an int64.
+func (m Money) Add(o Money) (Money, error) {
+	if m.currency != o.currency {
+		return m, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+
+	sum := m.amount + o.amount
+
+	if (o.amount > 0 && sum < m.amount) || (o.amount < 0 && sum > m.amount) {
+		return m, ErrOverflow
+	}
+
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Sub returns the difference between [Money] and o.
+//
+// It returns [ErrCurrencyMismatch] if both have different currencies and
+// [ErrOverflow] if the result doesn't fit in an int64.
+func (m Money) Sub(o Money) (Money, error) {
+	negated, err := o.Negate()
+	if err != nil {
+		return m, err
+	}
+
+	return m.Add(negated)
+}
+
+// Sum adds every value into [Money] of cur.
+//
+// It returns [ErrCurrencyMismatch] if any value isn't in cur.
+func Sum(cur currency.Type, values ...Money) (Money, error) {
+	var (
+		out = New(0, cur)
+		err error
+	)
+
+	for _, value := range values {
+		out, err = out.Add(value)
+		if err != nil {
+			return out, err
+		}
+	}
+
+	return out, nil
+}
+
+// Allocate splits [Money] proportionally to ratios without losing any minor
+// unit. The remainder is given one minor unit at a time to the first parts,
+// so allocating 0.05 EUR by 1:1 returns 0.03 EUR and 0.02 EUR.
+//
+// It returns [ErrInvalidRatios] if a ratio is negative or all of them are
+// zero, and [ErrOverflow] if the ratios add up to more than an int64.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var (
+		total int64
+		out   = make([]Money, len(ratios))
+	)
+
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, ErrInvalidRatios
+		}
+
+		if total > math.MaxInt64-ratio {
+			return nil, ErrOverflow
+		}
+
+		total += ratio
+	}
+
+	if total <= 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	var (
+		remainder = m.amount
+		amount    = big.NewInt(m.amount)
+		divisor   = big.NewInt(total)
+		share     = new(big.Int)
+	)
+
+	// A share never exceeds the amount in absolute value, only the product
+	// needs more than 64 bits.
+	for i, ratio := range ratios {
+		share.Mul(amount, big.NewInt(ratio))
+		share.Quo(share, divisor)
+
+		out[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+
+	for i := 0; remainder != 0; i = (i + 1) % len(out) {
+		if ratios[i] == 0 {
+			continue
+		}
+
+		out[i].amount += step
+		remainder -= step
+	}
+
+	return out, nil
+}
+
+// Split divides [Money] into n parts as equal as possible, the first parts
+// get the remainder.
+//
+// It returns [ErrInvalidRatios] if n is lower than one.
+func (m Money) Split(n int) ([]Money, error) {
+	if n < 1 {
+		return nil, ErrInvalidRatios
+	}
+
+	ratios := make([]int64, n)
+
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return m.Allocate(ratios...)
+}
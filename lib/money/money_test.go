@@ -0,0 +1,173 @@
+package money
+
+import (
+	"encoding/json"
+	"financo/lib/currency"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	jpy currency.Type = "JPY"
+	bhd currency.Type = "BHD"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	eur := New(12_34, currency.EUR)
+
+	sum, err := eur.Add(New(66, currency.EUR))
+	assert.NoError(t, err)
+	assert.Equal(t, New(13_00, currency.EUR), sum)
+
+	diff, err := eur.Sub(New(20_00, currency.EUR))
+	assert.NoError(t, err)
+	assert.Equal(t, New(-7_66, currency.EUR), diff)
+	assert.True(t, diff.IsNegative())
+
+	negated, err := eur.Negate()
+	assert.NoError(t, err)
+	assert.Equal(t, New(-12_34, currency.EUR), negated)
+
+	_, err = New(math.MinInt64, currency.EUR).Negate()
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = eur.Sub(New(math.MinInt64, currency.EUR))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = eur.Add(New(1, currency.USD))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = New(math.MaxInt64, currency.EUR).Add(New(1, currency.EUR))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Sum(currency.EUR, eur, New(1, currency.CHF))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   Money
+		ratios  []int64
+		want    []int64
+		wantErr error
+	}{
+		{
+			name:   "even split keeps the remainder",
+			money:  New(5, currency.EUR),
+			ratios: []int64{1, 1},
+			want:   []int64{3, 2},
+		},
+		{
+			name:   "weighted",
+			money:  New(100_00, currency.EUR),
+			ratios: []int64{70, 20, 10},
+			want:   []int64{70_00, 20_00, 10_00},
+		},
+		{
+			name:   "negative amounts",
+			money:  New(-10, currency.EUR),
+			ratios: []int64{1, 1, 1},
+			want:   []int64{-4, -3, -3},
+		},
+		{
+			name:   "zero ratio gets nothing",
+			money:  New(7, currency.EUR),
+			ratios: []int64{0, 1, 1},
+			want:   []int64{0, 4, 3},
+		},
+		{
+			name:   "large amount and ratios",
+			money:  New(math.MaxInt64, currency.EUR),
+			ratios: []int64{1 << 40, 1<<40 + 1},
+			want:   []int64{4611686018425290752, 4611686018429485055},
+		},
+		{
+			name:   "minimum amount",
+			money:  New(math.MinInt64, currency.EUR),
+			ratios: []int64{3, 1},
+			want:   []int64{math.MinInt64 / 4 * 3, math.MinInt64 / 4},
+		},
+		{
+			name:    "ratios overflow",
+			money:   New(7, currency.EUR),
+			ratios:  []int64{math.MaxInt64, 1},
+			wantErr: ErrOverflow,
+		},
+		{
+			name:    "invalid ratios",
+			money:   New(7, currency.EUR),
+			ratios:  []int64{0, 0},
+			wantErr: ErrInvalidRatios,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.Allocate(tt.ratios...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			amounts := make([]int64, len(got))
+			for i, m := range got {
+				amounts[i] = m.Amount()
+				assert.Equal(t, tt.money.Currency(), m.Currency())
+			}
+
+			assert.Equal(t, tt.want, amounts)
+		})
+	}
+
+	parts, err := New(100, jpy).Split(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{New(34, jpy), New(33, jpy), New(33, jpy)}, parts)
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		money Money
+		tag   string
+		want  string
+	}{
+		{money: New(123456, currency.EUR), tag: "en", want: "€1,234.56"},
+		{money: New(123456, currency.EUR), tag: "de-DE", want: "1.234,56\u00a0€"},
+		{money: New(-5, currency.USD), tag: "en-US", want: "-$0.05"},
+		{money: New(123456789, currency.CHF), tag: "de_CH", want: "CHF\u00a01’234’567.89"},
+		{money: New(1234567, jpy), tag: "ja", want: "¥1,234,567"},
+		{money: New(1234, bhd), tag: "unknown", want: "BD1.234"},
+		{money: New(100000, currency.EUR), tag: "fr-FR", want: "1\u202f000,00\u00a0€"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.Format(tt.tag))
+		})
+	}
+}
+
+func TestMoneyEncoding(t *testing.T) {
+	var m Money
+
+	b, err := json.Marshal(New(1234, currency.EUR))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":1234,"currency":"EUR"}`, string(b))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":-50,"currency":"usd"}`), &m))
+	assert.Equal(t, New(-50, currency.USD), m)
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":50}`), &m))
+
+	v, err := New(1234, currency.GBP).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1234 GBP", v)
+
+	assert.NoError(t, m.Scan([]byte("-7 CHF")))
+	assert.Equal(t, New(-7, currency.CHF), m)
+	assert.Error(t, m.Scan("7"))
+	assert.Error(t, m.Scan(7))
+}
@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+// Execer is satisfied by both [sql.Conn] and [sql.Tx].
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Write stores the JSON encoding of payload in the outbox under topic, the
+// relay publishes it later to the bus subscribed to topic.
+//
+// Pass the [sql.Tx] that persists the change the payload describes, so the
+// message is stored if and only if the change is committed.
+func Write(ctx context.Context, e Execer, topic string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Join(errors.New("outbox: payload can't be marshaled"), err)
+	}
+
+	_, err = e.ExecContext(
+		ctx,
+		"INSERT INTO outbox_messages (topic, payload) VALUES ($1, $2)",
+		topic,
+		b,
+	)
+	if err != nil {
+		return errors.Join(errors.New("outbox: failed to write message"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+// Package poll runs background jobs that process a table in batches.
+package poll
+
+import (
+	"context"
+	"time"
+)
+
+// Loop calls run right away and then every interval until ctx is canceled.
+//
+// run reports whether more work is already due, like when it processed a
+// full batch, then it is called again right away instead of waiting for the
+// next tick. It must only report so when the next call makes progress,
+// otherwise Loop spins.
+func Loop(ctx context.Context, interval time.Duration, run func(ctx context.Context) bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if run(ctx) && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
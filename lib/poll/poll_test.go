@@ -0,0 +1,64 @@
+package poll
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoopRunsAgainWhenMoreIsDue(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		calls       = 0
+	)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		Loop(ctx, time.Hour, func(context.Context) bool {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+
+			return true
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("loop didn't stop")
+	}
+
+	assert.Equal(t, 3, calls)
+}
+
+func TestLoopWaitsForTheNextTick(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		calls       = make(chan struct{}, 10)
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		Loop(ctx, 20*time.Millisecond, func(context.Context) bool {
+			calls <- struct{}{}
+
+			return false
+		})
+	}()
+
+	<-calls
+	<-calls
+	cancel()
+	<-done
+
+	assert.LessOrEqual(t, len(calls), 1)
+}
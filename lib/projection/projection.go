@@ -0,0 +1,78 @@
+// Package projection estimates when ordered savings goals are reached from a
+// steady monthly contribution.
+package projection
+
+import (
+	"financo/lib/nullable"
+	"math"
+	"time"
+)
+
+// monthDays is the length in days of the average gregorian month.
+const monthDays = 365.2425 / 12
+
+// Goal is a goal still being saved for, goals are funded in the order they
+// are given so a goal only receives contributions once the ones before it
+// are reached.
+type Goal struct {
+	Target   int64
+	Saved    int64
+	Deadline nullable.Type[time.Time]
+}
+
+// Estimate is the projection of a [Goal].
+//
+// ReachedAt is unset when the goal is never reached at the given pace.
+// Required and OnTrack are only set for goals with a deadline, Required is the
+// monthly contribution needed to reach the goal by its deadline, including
+// what the goals before it still need.
+type Estimate struct {
+	ReachedAt nullable.Type[time.Time]
+	Required  nullable.Type[int64]
+	OnTrack   nullable.Type[bool]
+}
+
+// Project estimates when each goal is reached contributing monthly from now
+// on, and for goals with a deadline how much should be contributed every
+// month to reach them in time. A deadline less than a month away requires
+// what is left in a single contribution.
+//
+// It returns the estimates in the order of goals.
+func Project(now time.Time, monthly int64, goals []Goal) []Estimate {
+	var (
+		estimates = make([]Estimate, len(goals))
+		remaining int64
+	)
+
+	for i, goal := range goals {
+		var estimate Estimate
+
+		remaining += max(goal.Target-goal.Saved, 0)
+
+		switch {
+		case remaining == 0:
+			estimate.ReachedAt = nullable.New(now)
+		case monthly > 0:
+			days := math.Ceil(float64(remaining) / float64(monthly) * monthDays)
+			estimate.ReachedAt = nullable.New(now.AddDate(0, 0, int(days)))
+		}
+
+		if goal.Deadline.Valid {
+			months := goal.Deadline.Val.Sub(now).Hours() / 24 / monthDays
+
+			required := remaining
+			if months > 1 && remaining > 0 {
+				required = int64(math.Ceil(float64(remaining) / months))
+			}
+
+			estimate.Required = nullable.New(required)
+			estimate.OnTrack = nullable.New(
+				remaining == 0 || estimate.ReachedAt.Valid && !estimate.ReachedAt.Val.After(goal.Deadline.Val),
+			)
+		}
+
+		estimates[i] = estimate
+	}
+
+	return estimates
+}
@@ -0,0 +1,105 @@
+package projection
+
+import (
+	"financo/lib/nullable"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	in := func(days int) nullable.Type[time.Time] {
+		return nullable.New(now.AddDate(0, 0, days))
+	}
+
+	tests := []struct {
+		name    string
+		monthly int64
+		goals   []Goal
+		want    []Estimate
+	}{
+		{
+			name:    "reached goal",
+			monthly: 0,
+			goals:   []Goal{{Target: 100, Saved: 100}},
+			want:    []Estimate{{ReachedAt: nullable.New(now)}},
+		},
+		{
+			name:    "never reached without contributions",
+			monthly: 0,
+			goals:   []Goal{{Target: 100, Saved: 40}},
+			want:    []Estimate{{}},
+		},
+		{
+			name:    "never reached with negative contributions",
+			monthly: -50,
+			goals:   []Goal{{Target: 100, Deadline: in(365)}},
+			want: []Estimate{{
+				Required: nullable.New(int64(9)),
+				OnTrack:  nullable.New(false),
+			}},
+		},
+		{
+			name:    "waterfall order",
+			monthly: 100,
+			goals: []Goal{
+				{Target: 300, Saved: 100},
+				{Target: 100},
+			},
+			want: []Estimate{
+				{ReachedAt: in(61)},
+				{ReachedAt: in(92)},
+			},
+		},
+		{
+			name:    "deadline includes earlier goals",
+			monthly: 100,
+			goals: []Goal{
+				{Target: 300, Saved: 100},
+				{Target: 100, Deadline: in(183)},
+			},
+			want: []Estimate{
+				{ReachedAt: in(61)},
+				{ReachedAt: in(92), Required: nullable.New(int64(50)), OnTrack: nullable.New(true)},
+			},
+		},
+		{
+			name:    "behind schedule",
+			monthly: 10,
+			goals:   []Goal{{Target: 120, Deadline: in(183)}},
+			want: []Estimate{{
+				ReachedAt: in(366),
+				Required:  nullable.New(int64(20)),
+				OnTrack:   nullable.New(false),
+			}},
+		},
+		{
+			name:    "deadline within a month",
+			monthly: 10,
+			goals:   []Goal{{Target: 120, Saved: 20, Deadline: in(10)}},
+			want: []Estimate{{
+				ReachedAt: in(305),
+				Required:  nullable.New(int64(100)),
+				OnTrack:   nullable.New(false),
+			}},
+		},
+		{
+			name:    "passed deadline of a reached goal",
+			monthly: 10,
+			goals:   []Goal{{Target: 120, Saved: 120, Deadline: in(-10)}},
+			want: []Estimate{{
+				ReachedAt: nullable.New(now),
+				Required:  nullable.New(int64(0)),
+				OnTrack:   nullable.New(true),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Project(now, tt.monthly, tt.goals))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package recurrence
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Frequency represents how often a [Rule] repeats.
+type Frequency string
+
+const (
+	MonthlyOnDay    Frequency = "monthly_on_day"
+	Weekly          Frequency = "weekly"
+	LastBusinessDay Frequency = "last_business_day"
+)
+
+// UnmarshalJSON receives a buffer b, and ensures that the provided value is a
+// valid [Frequency]. So [Frequency] satisfies the [json.Unmarshaler] interface.
+//
+// It returns an error if the buffer can't be unmarshal into an string or the
+// provided value is not a supported [Frequency].
+func (f *Frequency) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(s) {
+	default:
+		return fmt.Errorf("recurrence: invalid frequency \"%s\"", s)
+	case "monthly_on_day":
+		*f = MonthlyOnDay
+	case "weekly":
+		*f = Weekly
+	case "last_business_day":
+		*f = LastBusinessDay
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the json encoding of [Frequency]. So [Frequency]
+// satisfies the [json.Marshaler] interface.
+//
+// It returns an error if [Frequency] is an unsupported value or if json
+// encoding fails.
+func (f Frequency) MarshalJSON() ([]byte, error) {
+	var s string
+
+	switch f {
+	default:
+		return []byte{}, fmt.Errorf("recurrence: invalid frequency \"%s\"", string(f))
+	case MonthlyOnDay:
+		s = "monthly_on_day"
+	case Weekly:
+		s = "weekly"
+	case LastBusinessDay:
+		s = "last_business_day"
+	}
+
+	return json.Marshal(s)
+}
+
+// Scan takes the value returned by the SQL database and maps it to
+// [Frequency]. So [Frequency] satisfies the [sql.Scanner] interface.
+//
+// It returns an error if [Frequency] is an unsupported value.
+func (f *Frequency) Scan(value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return errors.New("recurrence: invalid column type")
+	}
+
+	switch strings.ToLower(s) {
+	default:
+		return fmt.Errorf("recurrence: invalid frequency \"%s\"", value)
+	case "monthly_on_day":
+		*f = MonthlyOnDay
+	case "weekly":
+		*f = Weekly
+	case "last_business_day":
+		*f = LastBusinessDay
+	}
+
+	return nil
+}
+
+// Value returns the value of [Frequency] to be stored in the SQL database. So
+// [Frequency] satisfies the [driver.Valuer] interface.
+//
+// It returns an error if [Frequency] is an unsupported value.
+func (f Frequency) Value() (driver.Value, error) {
+	var s string
+
+	switch f {
+	default:
+		return s, fmt.Errorf("recurrence: invalid frequency \"%s\"", string(f))
+	case MonthlyOnDay:
+		s = "monthly_on_day"
+	case Weekly:
+		s = "weekly"
+	case LastBusinessDay:
+		s = "last_business_day"
+	}
+
+	return s, nil
+}
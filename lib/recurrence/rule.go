@@ -0,0 +1,129 @@
+package recurrence
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidFrequency = errors.New("recurrence: invalid frequency")
+	ErrInvalidInterval  = errors.New("recurrence: interval must be greater than zero")
+	ErrInvalidDay       = errors.New("recurrence: day must be between 1 and 31")
+)
+
+// Rule is a reduced RRULE-like cadence. It describes when a recurring event
+// happens relative to an anchor date.
+//
+//   - [MonthlyOnDay] happens every Interval months on the given Day. When the
+//     month is shorter than Day, the last day of the month is used instead.
+//   - [Weekly] happens every Interval weeks on the same weekday as the anchor.
+//   - [LastBusinessDay] happens every Interval months on the last weekday of
+//     the month.
+type Rule struct {
+	Frequency Frequency `json:"frequency"`
+	Interval  int       `json:"interval"`
+	Day       int       `json:"day"`
+}
+
+// Validate checks that [Rule] can produce occurrences.
+//
+// It returns an error describing the first invalid field.
+func (r Rule) Validate() error {
+	switch r.Frequency {
+	default:
+		return ErrInvalidFrequency
+	case MonthlyOnDay:
+		if r.Day < 1 || r.Day > 31 {
+			return ErrInvalidDay
+		}
+	case Weekly, LastBusinessDay:
+	}
+
+	if r.Interval < 1 {
+		return ErrInvalidInterval
+	}
+
+	return nil
+}
+
+// Between returns every occurrence of [Rule] anchored at anchor that falls
+// inside the closed range [from, until]. All dates are truncated to midnight
+// UTC. No occurrence happens before anchor.
+//
+// It returns an empty slice if [Rule] is invalid.
+func (r Rule) Between(anchor time.Time, from time.Time, until time.Time) []time.Time {
+	var (
+		out = make([]time.Time, 0, 12)
+	)
+
+	if r.Validate() != nil {
+		return out
+	}
+
+	anchor = truncate(anchor)
+	from = truncate(from)
+	until = truncate(until)
+
+	if from.Before(anchor) {
+		from = anchor
+	}
+
+	// The first occurrences of a monthly rule can land before the anchor
+	// inside the anchor's month, those are skipped by the from check.
+	for i := 0; ; i++ {
+		date := r.occurrence(anchor, i)
+
+		if date.After(until) {
+			break
+		}
+
+		if date.Before(from) {
+			continue
+		}
+
+		out = append(out, date)
+	}
+
+	return out
+}
+
+func (r Rule) occurrence(anchor time.Time, i int) time.Time {
+	switch r.Frequency {
+	case Weekly:
+		return anchor.AddDate(0, 0, 7*r.Interval*i)
+	case LastBusinessDay:
+		return lastBusinessDay(anchor.Year(), anchor.Month()+time.Month(r.Interval*i))
+	default:
+		return dayOfMonth(anchor.Year(), anchor.Month()+time.Month(r.Interval*i), r.Day)
+	}
+}
+
+func truncate(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func lastDayOfMonth(year int, month time.Month) time.Time {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	last := lastDayOfMonth(year, month)
+
+	if day > last.Day() {
+		return last
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func lastBusinessDay(year int, month time.Month) time.Time {
+	date := lastDayOfMonth(year, month)
+
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	return date
+}
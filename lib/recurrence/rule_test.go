@@ -0,0 +1,155 @@
+package recurrence
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr error
+	}{
+		{
+			name: "valid monthly on day",
+			rule: Rule{Frequency: MonthlyOnDay, Interval: 1, Day: 27},
+		},
+		{
+			name: "valid weekly",
+			rule: Rule{Frequency: Weekly, Interval: 2},
+		},
+		{
+			name: "valid last business day",
+			rule: Rule{Frequency: LastBusinessDay, Interval: 1},
+		},
+		{
+			name:    "unknown frequency",
+			rule:    Rule{Frequency: "yearly", Interval: 1},
+			wantErr: ErrInvalidFrequency,
+		},
+		{
+			name:    "zero interval",
+			rule:    Rule{Frequency: Weekly, Interval: 0},
+			wantErr: ErrInvalidInterval,
+		},
+		{
+			name:    "day out of range",
+			rule:    Rule{Frequency: MonthlyOnDay, Interval: 1, Day: 32},
+			wantErr: ErrInvalidDay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.rule.Validate())
+		})
+	}
+}
+
+func TestRuleBetween(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		anchor time.Time
+		from   time.Time
+		until  time.Time
+		want   []time.Time
+	}{
+		{
+			name:   "monthly on day skips the anchor month when the day already passed",
+			rule:   Rule{Frequency: MonthlyOnDay, Interval: 1, Day: 5},
+			anchor: date(2024, time.January, 20),
+			from:   date(2024, time.January, 1),
+			until:  date(2024, time.April, 30),
+			want: []time.Time{
+				date(2024, time.February, 5),
+				date(2024, time.March, 5),
+				date(2024, time.April, 5),
+			},
+		},
+		{
+			name:   "monthly on day clamps to the end of short months",
+			rule:   Rule{Frequency: MonthlyOnDay, Interval: 1, Day: 31},
+			anchor: date(2024, time.January, 1),
+			from:   date(2024, time.January, 1),
+			until:  date(2024, time.April, 30),
+			want: []time.Time{
+				date(2024, time.January, 31),
+				date(2024, time.February, 29),
+				date(2024, time.March, 31),
+				date(2024, time.April, 30),
+			},
+		},
+		{
+			name:   "every second month across a year boundary",
+			rule:   Rule{Frequency: MonthlyOnDay, Interval: 2, Day: 15},
+			anchor: date(2024, time.November, 1),
+			from:   date(2024, time.November, 1),
+			until:  date(2025, time.March, 31),
+			want: []time.Time{
+				date(2024, time.November, 15),
+				date(2025, time.January, 15),
+				date(2025, time.March, 15),
+			},
+		},
+		{
+			name:   "every two weeks starting from a later date",
+			rule:   Rule{Frequency: Weekly, Interval: 2},
+			anchor: date(2024, time.January, 1),
+			from:   date(2024, time.January, 10),
+			until:  date(2024, time.February, 12),
+			want: []time.Time{
+				date(2024, time.January, 15),
+				date(2024, time.January, 29),
+				date(2024, time.February, 12),
+			},
+		},
+		{
+			name:   "last business day moves back from weekends",
+			rule:   Rule{Frequency: LastBusinessDay, Interval: 1},
+			anchor: date(2024, time.March, 1),
+			from:   date(2024, time.March, 1),
+			until:  date(2024, time.June, 30),
+			want: []time.Time{
+				date(2024, time.March, 29),
+				date(2024, time.April, 30),
+				date(2024, time.May, 31),
+				date(2024, time.June, 28),
+			},
+		},
+		{
+			name:   "invalid rule has no occurrences",
+			rule:   Rule{Frequency: Weekly},
+			anchor: date(2024, time.January, 1),
+			from:   date(2024, time.January, 1),
+			until:  date(2024, time.December, 31),
+			want:   []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Between(tt.anchor, tt.from, tt.until))
+		})
+	}
+}
+
+func TestFrequencyJSON(t *testing.T) {
+	var f Frequency
+
+	assert.NoError(t, json.Unmarshal([]byte(`"WEEKLY"`), &f))
+	assert.Equal(t, Weekly, f)
+	assert.Error(t, json.Unmarshal([]byte(`"daily"`), &f))
+
+	b, err := json.Marshal(LastBusinessDay)
+	assert.NoError(t, err)
+	assert.Equal(t, `"last_business_day"`, string(b))
+}
@@ -0,0 +1,14 @@
+package request_id
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// FromContext returns the ID assigned to the HTTP request ctx belongs to by
+// the RequestID middleware, or an empty string when ctx doesn't come from a
+// request, e.g. background jobs.
+func FromContext(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
@@ -0,0 +1,218 @@
+package resp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// Error is an error reply sent by the server, e.g. a wrong command or a
+// BUSYGROUP when a consumer group already exists.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+var ErrProtocol = errors.New("resp: protocol error")
+
+// Conn is a connection to a server speaking RESP2, e.g. Redis. Commands are
+// serialized, so a Conn can be shared but a blocking command holds it until it
+// returns.
+//
+// Replies are mapped to Go values: simple and bulk strings to string,
+// integers to int64, arrays to []any and null replies to nil. Error replies
+// are returned as [Error].
+type Conn struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// Dial connects to the server listening at addr.
+func Dial(ctx context.Context, addr string) (*Conn, error) {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.Join(errors.New("resp: failed to dial"), err)
+	}
+
+	return NewConn(conn), nil
+}
+
+// NewConn wraps an established connection.
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+		writer: bufio.NewWriter(conn),
+	}
+}
+
+// Do sends a command and waits for its reply. If ctx has a deadline it is
+// used as the deadline of the whole round trip.
+//
+// It returns an error if the command can't be sent or the reply can't be
+// read, after that the Conn should be closed. An error reply is returned as
+// [Error] and the Conn is still usable.
+func (c *Conn) Do(ctx context.Context, args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadline, _ := ctx.Deadline()
+
+	err := c.conn.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.write(args)
+	if err != nil {
+		return nil, errors.Join(errors.New("resp: failed to send command"), err)
+	}
+
+	reply, err := c.read()
+	if err != nil {
+		var replyErr Error
+		if errors.As(err, &replyErr) {
+			return nil, replyErr
+		}
+
+		return nil, errors.Join(errors.New("resp: failed to read reply"), err)
+	}
+
+	return reply, nil
+}
+
+// Close closes the underlying connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Conn) write(args []string) error {
+	fmt.Fprintf(c.writer, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		fmt.Fprintf(c.writer, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	return c.writer.Flush()
+}
+
+func (c *Conn) read() (any, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 {
+		return nil, ErrProtocol
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, errors.Join(ErrProtocol, err)
+		}
+
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errors.Join(ErrProtocol, err)
+		}
+
+		if n < 0 {
+			return nil, nil
+		}
+
+		b := make([]byte, n+2)
+
+		_, err = io.ReadFull(c.reader, b)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(b[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errors.Join(ErrProtocol, err)
+		}
+
+		if n < 0 {
+			return nil, nil
+		}
+
+		// An error nested in an array is part of the reply, e.g. in the
+		// reply of a transaction, so it is kept as a value.
+		values := make([]any, n)
+
+		for i := range values {
+			values[i], err = c.read()
+
+			var replyErr Error
+			if errors.As(err, &replyErr) {
+				values[i] = replyErr
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected reply type %q", ErrProtocol, line[0])
+	}
+}
+
+func (c *Conn) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", ErrProtocol
+	}
+
+	return line[:len(line)-2], nil
+}
+
+// String returns reply as a string.
+func String(reply any) (string, error) {
+	s, ok := reply.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: expected string, got %T", ErrProtocol, reply)
+	}
+
+	return s, nil
+}
+
+// Array returns reply as an array, a null reply is an empty array.
+func Array(reply any) ([]any, error) {
+	if reply == nil {
+		return nil, nil
+	}
+
+	values, ok := reply.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected array, got %T", ErrProtocol, reply)
+	}
+
+	return values, nil
+}
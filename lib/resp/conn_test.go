@@ -0,0 +1,123 @@
+package resp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serve answers every command read from conn with the next reply.
+func serve(conn net.Conn, replies ...string) <-chan []string {
+	commands := make(chan []string, len(replies))
+
+	go func() {
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+
+		for _, reply := range replies {
+			var n int
+
+			_, err := fmt.Fscanf(reader, "*%d\r\n", &n)
+			if err != nil {
+				return
+			}
+
+			command := make([]string, n)
+
+			for i := range command {
+				var size int
+
+				_, err := fmt.Fscanf(reader, "$%d\r\n", &size)
+				if err != nil {
+					return
+				}
+
+				b := make([]byte, size+2)
+
+				_, err = io.ReadFull(reader, b)
+				if err != nil {
+					return
+				}
+
+				command[i] = string(b[:size])
+			}
+
+			commands <- command
+
+			_, err = io.WriteString(conn, reply)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return commands
+}
+
+func TestConnDo(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		want    any
+		wantErr error
+	}{
+		{name: "simple string", reply: "+OK\r\n", want: "OK"},
+		{name: "integer", reply: ":42\r\n", want: int64(42)},
+		{name: "bulk string", reply: "$5\r\nhe\r\nl\r\n", want: "he\r\nl"},
+		{name: "null bulk string", reply: "$-1\r\n", want: nil},
+		{name: "null array", reply: "*-1\r\n", want: nil},
+		{
+			name:  "nested array",
+			reply: "*2\r\n$3\r\n1-0\r\n*2\r\n$7\r\npayload\r\n$2\r\n{}\r\n",
+			want:  []any{"1-0", []any{"payload", "{}"}},
+		},
+		{
+			name:  "error in array",
+			reply: "*2\r\n:1\r\n-ERR nope\r\n",
+			want:  []any{int64(1), Error("ERR nope")},
+		},
+		{name: "error", reply: "-BUSYGROUP exists\r\n", wantErr: Error("BUSYGROUP exists")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			commands := serve(server, tt.reply)
+
+			conn := NewConn(client)
+			defer conn.Close()
+
+			got, err := conn.Do(context.Background(), "XADD", "stream", "*", "payload", "a b")
+			assert.Equal(t, []string{"XADD", "stream", "*", "payload", "a b"}, <-commands)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConnDoReusableAfterErrorReply(t *testing.T) {
+	client, server := net.Pipe()
+	serve(server, "-ERR unknown command\r\n", "+PONG\r\n")
+
+	conn := NewConn(client)
+	defer conn.Close()
+
+	_, err := conn.Do(context.Background(), "NOPE")
+	assert.Equal(t, Error("ERR unknown command"), err)
+
+	got, err := conn.Do(context.Background(), "PING")
+	assert.NoError(t, err)
+	assert.Equal(t, "PONG", got)
+}
@@ -0,0 +1,99 @@
+package statement
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseAmount parses a decimal amount written with the given decimal
+// separator into minor units of a currency with the given exponent, the
+// number of decimals of its minor unit. Thousand separators, spaces and a
+// leading plus sign are ignored. Amounts with more decimal digits than
+// exponent are rejected.
+//
+// It returns an error if raw is not a valid amount.
+func ParseAmount(raw string, decimalSeparator string, exponent int) (int64, error) {
+	var (
+		negative bool
+		units    int64
+		fraction int64
+		decimals = -1
+	)
+
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+
+	s := strings.TrimSpace(raw)
+
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	if strings.HasSuffix(s, "-") {
+		negative = !negative
+		s = s[:len(s)-1]
+	}
+
+	if s == "" {
+		return 0, fmt.Errorf("statement: invalid amount \"%s\"", raw)
+	}
+
+	for _, r := range s {
+		switch {
+		case string(r) == decimalSeparator:
+			if decimals >= 0 {
+				return 0, fmt.Errorf("statement: invalid amount \"%s\"", raw)
+			}
+
+			decimals = 0
+		case r >= '0' && r <= '9':
+			if decimals < 0 {
+				units = units*10 + int64(r-'0')
+				continue
+			}
+
+			if decimals == exponent {
+				return 0, fmt.Errorf("statement: amount \"%s\" has too many decimals", raw)
+			}
+
+			fraction = fraction*10 + int64(r-'0')
+			decimals++
+		case r == '.' || r == ',' || r == '\'' || r == ' ' || r == '\u00a0':
+			if decimals >= 0 {
+				return 0, fmt.Errorf("statement: invalid amount \"%s\"", raw)
+			}
+		default:
+			return 0, fmt.Errorf("statement: invalid amount \"%s\"", raw)
+		}
+	}
+
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	for ; decimals < exponent; decimals++ {
+		fraction *= 10
+	}
+
+	amount := units
+	for i := 0; i < exponent; i++ {
+		amount *= 10
+	}
+
+	amount += fraction
+
+	if negative {
+		return -amount, nil
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,127 @@
+package statement
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount         string   `xml:"Amt"`
+	CreditDebit    string   `xml:"CdtDbtInd"`
+	BookingDate    camtDate `xml:"BookgDt"`
+	ValueDate      camtDate `xml:"ValDt"`
+	Reference      string   `xml:"NtryRef"`
+	ServicerRef    string   `xml:"AcctSvcrRef"`
+	AdditionalInfo string   `xml:"AddtlNtryInf"`
+	Remittances    []string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) value() string {
+	if d.Date != "" {
+		return d.Date
+	}
+
+	return d.DateTime
+}
+
+// ParseCAMT053 reads an ISO 20022 CAMT.053 bank to customer statement from r
+// and maps every entry (Ntry) into an [Entry]. The account servicer reference
+// is used as [Entry] ExternalID, falling back to the entry reference.
+// Amounts are converted into minor units of a currency with the given
+// exponent.
+//
+// It returns an error pointing to the first entry that can't be parsed.
+func ParseCAMT053(r io.Reader, exponent int) ([]Entry, error) {
+	var (
+		entries = make([]Entry, 0, 50)
+		doc     camtDocument
+	)
+
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return entries, errors.Join(errors.New("statement: failed to decode camt.053"), err)
+	}
+
+	for _, stmt := range doc.Statements {
+		for _, ntry := range stmt.Entries {
+			entry, err := camtEntryToEntry(ntry, exponent)
+			if err != nil {
+				return entries, errors.Join(fmt.Errorf("statement: failed to parse camt.053 entry %d", len(entries)+1), err)
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func camtEntryToEntry(ntry camtEntry, exponent int) (Entry, error) {
+	var (
+		entry Entry
+		notes = make([]string, 0, 2)
+	)
+
+	raw := ntry.BookingDate.value()
+	if raw == "" {
+		raw = ntry.ValueDate.value()
+	}
+
+	if len(raw) < 10 {
+		return entry, fmt.Errorf("statement: invalid camt.053 date \"%s\"", raw)
+	}
+
+	date, err := time.Parse(time.DateOnly, raw[:10])
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Date = date
+
+	entry.Amount, err = ParseAmount(ntry.Amount, ".", exponent)
+	if err != nil {
+		return entry, err
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(ntry.CreditDebit)) {
+	default:
+		return entry, fmt.Errorf("statement: invalid camt.053 credit debit indicator \"%s\"", ntry.CreditDebit)
+	case "CRDT":
+	case "DBIT":
+		entry.Amount = -entry.Amount
+	}
+
+	if info := strings.TrimSpace(ntry.AdditionalInfo); info != "" {
+		notes = append(notes, info)
+	}
+
+	for _, remittance := range ntry.Remittances {
+		if remittance = strings.TrimSpace(remittance); remittance != "" {
+			notes = append(notes, remittance)
+		}
+	}
+
+	entry.Notes = strings.Join(notes, " ")
+	entry.ExternalID = strings.TrimSpace(ntry.ServicerRef)
+
+	if entry.ExternalID == "" {
+		entry.ExternalID = strings.TrimSpace(ntry.Reference)
+	}
+
+	return entry, nil
+}
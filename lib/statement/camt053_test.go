@@ -0,0 +1,72 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCAMT053(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "debit and credit entries",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <NtryRef>1</NtryRef>
+        <Amt Ccy="CHF">1200.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><Dt>2024-10-01</Dt></BookgDt>
+        <AcctSvcrRef>SVC-001</AcctSvcrRef>
+        <NtryDtls><TxDtls><RmtInf><Ustrd>Rent October</Ustrd></RmtInf></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>2</NtryRef>
+        <Amt Ccy="CHF">3500.5</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><DtTm>2024-10-02T08:30:00</DtTm></BookgDt>
+        <AddtlNtryInf>Salary</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`,
+			want: []Entry{
+				{Date: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), Amount: -120000, Notes: "Rent October", ExternalID: "SVC-001"},
+				{Date: time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC), Amount: 350050, Notes: "Salary", ExternalID: "2"},
+			},
+		},
+		{
+			name: "missing credit debit indicator",
+			data: `<Document><BkToCstmrStmt><Stmt><Ntry><Amt>1.00</Amt>` +
+				`<BookgDt><Dt>2024-10-01</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed xml",
+			data:    `<Document><BkToCstmrStmt>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCAMT053(strings.NewReader(tt.data), 2)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package statement
+
+import (
+	"encoding/csv"
+	"errors"
+	"financo/lib/nullable"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// CSVMapping describes how the columns of a bank's CSV statement map onto an
+// [Entry]. Columns are zero based.
+type CSVMapping struct {
+	// Delimiter separating the columns, it defaults to a comma.
+	Delimiter string `json:"delimiter"`
+	// SkipRows is the amount of rows before the first entry, like headers.
+	SkipRows int `json:"skipRows"`
+	// DateColumn holds the booking date written with DateFormat, a Go time
+	// layout.
+	DateColumn int    `json:"dateColumn"`
+	DateFormat string `json:"dateFormat"`
+	// AmountColumn holds the amount unless Sign is [SplitColumns], then
+	// OutflowColumn and InflowColumn are used instead.
+	AmountColumn  int                `json:"amountColumn"`
+	OutflowColumn nullable.Type[int] `json:"outflowColumn"`
+	InflowColumn  nullable.Type[int] `json:"inflowColumn"`
+	Sign          SignConvention     `json:"sign"`
+	NotesColumns  []int              `json:"notesColumns"`
+	// ExternalIDColumn holds the bank's identifier of the movement, if any.
+	ExternalIDColumn nullable.Type[int] `json:"externalIDColumn"`
+	// DecimalSeparator used by the amounts, it defaults to a dot.
+	DecimalSeparator string `json:"decimalSeparator"`
+}
+
+// Validate checks that [CSVMapping] can be used to parse a statement.
+//
+// It returns an error describing the first invalid field.
+func (m CSVMapping) Validate() error {
+	if len([]rune(m.Delimiter)) > 1 {
+		return errors.New("statement: delimiter must be a single character")
+	}
+
+	if m.DateFormat == "" {
+		return errors.New("statement: date format is required")
+	}
+
+	switch m.Sign {
+	default:
+		return fmt.Errorf("statement: invalid sign convention \"%s\"", m.Sign)
+	case NegativeIsOutflow, PositiveIsOutflow:
+	case SplitColumns:
+		if !m.OutflowColumn.Valid || !m.InflowColumn.Valid {
+			return errors.New("statement: split columns require an outflow and an inflow column")
+		}
+	}
+
+	if m.DecimalSeparator != "" && m.DecimalSeparator != "." && m.DecimalSeparator != "," {
+		return fmt.Errorf("statement: invalid decimal separator \"%s\"", m.DecimalSeparator)
+	}
+
+	return nil
+}
+
+// ParseCSV reads a CSV statement from r and maps every row into an [Entry]
+// using [CSVMapping]. Amounts are converted into minor units of a currency
+// with the given exponent. Empty rows are skipped.
+//
+// It returns an error pointing to the first row that can't be parsed.
+func ParseCSV(r io.Reader, m CSVMapping, exponent int) ([]Entry, error) {
+	var (
+		entries = make([]Entry, 0, 50)
+		reader  = csv.NewReader(r)
+	)
+
+	if err := m.Validate(); err != nil {
+		return entries, err
+	}
+
+	if m.Delimiter != "" {
+		reader.Comma = []rune(m.Delimiter)[0]
+	}
+
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return entries, errors.Join(fmt.Errorf("statement: failed to read row %d", row), err)
+		}
+
+		if row <= m.SkipRows || isEmpty(record) {
+			continue
+		}
+
+		entry, err := m.entry(record, exponent)
+		if err != nil {
+			return entries, errors.Join(fmt.Errorf("statement: failed to parse row %d", row), err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (m CSVMapping) entry(record []string, exponent int) (Entry, error) {
+	var (
+		entry Entry
+		notes = make([]string, 0, len(m.NotesColumns))
+	)
+
+	raw, err := column(record, m.DateColumn)
+	if err != nil {
+		return entry, err
+	}
+
+	date, err := time.Parse(m.DateFormat, raw)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	entry.Amount, err = m.amount(record, exponent)
+	if err != nil {
+		return entry, err
+	}
+
+	for _, i := range m.NotesColumns {
+		raw, err := column(record, i)
+		if err != nil {
+			return entry, err
+		}
+
+		if raw != "" {
+			notes = append(notes, raw)
+		}
+	}
+
+	entry.Notes = strings.Join(notes, " ")
+
+	if m.ExternalIDColumn.Valid {
+		entry.ExternalID, err = column(record, m.ExternalIDColumn.Val)
+		if err != nil {
+			return entry, err
+		}
+	}
+
+	return entry, nil
+}
+
+func (m CSVMapping) amount(record []string, exponent int) (int64, error) {
+	switch m.Sign {
+	case SplitColumns:
+		outflow, err := optionalAmount(record, m.OutflowColumn.Val, m.DecimalSeparator, exponent)
+		if err != nil {
+			return 0, err
+		}
+
+		inflow, err := optionalAmount(record, m.InflowColumn.Val, m.DecimalSeparator, exponent)
+		if err != nil {
+			return 0, err
+		}
+
+		return abs(inflow) - abs(outflow), nil
+	case PositiveIsOutflow:
+		raw, err := column(record, m.AmountColumn)
+		if err != nil {
+			return 0, err
+		}
+
+		amount, err := ParseAmount(raw, m.DecimalSeparator, exponent)
+
+		return -amount, err
+	default:
+		raw, err := column(record, m.AmountColumn)
+		if err != nil {
+			return 0, err
+		}
+
+		return ParseAmount(raw, m.DecimalSeparator, exponent)
+	}
+}
+
+func optionalAmount(record []string, i int, decimalSeparator string, exponent int) (int64, error) {
+	raw, err := column(record, i)
+	if err != nil || raw == "" {
+		return 0, err
+	}
+
+	return ParseAmount(raw, decimalSeparator, exponent)
+}
+
+func column(record []string, i int) (string, error) {
+	if i < 0 || i >= len(record) {
+		return "", fmt.Errorf("statement: column %d not found", i)
+	}
+
+	return strings.TrimSpace(record[i]), nil
+}
+
+func isEmpty(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
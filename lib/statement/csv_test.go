@@ -0,0 +1,141 @@
+package statement
+
+import (
+	"financo/lib/nullable"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		raw       string
+		separator string
+		exponent  int
+		want      int64
+		wantErr   bool
+	}{
+		{raw: "12.34", separator: ".", exponent: 2, want: 1234},
+		{raw: "-1,234.5", separator: ".", exponent: 2, want: -123450},
+		{raw: "1.234,56", separator: ",", exponent: 2, want: 123456},
+		{raw: "+7", separator: "", exponent: 2, want: 700},
+		{raw: "(15.00)", separator: ".", exponent: 2, want: -1500},
+		{raw: "20.00-", separator: ".", exponent: 2, want: -2000},
+		{raw: "1'000.10", separator: ".", exponent: 2, want: 100010},
+		{raw: "1.234", separator: ".", exponent: 2, wantErr: true},
+		{raw: "12.3.4", separator: ".", exponent: 2, wantErr: true},
+		{raw: "abc", separator: ".", exponent: 2, wantErr: true},
+		{raw: "", separator: ".", exponent: 2, wantErr: true},
+		{raw: "1,234", separator: ".", exponent: 0, want: 1234},
+		{raw: "12.5", separator: ".", exponent: 0, wantErr: true},
+		{raw: "1.234", separator: ".", exponent: 3, want: 1234},
+		{raw: "-1.5", separator: ".", exponent: 3, want: -1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseAmount(tt.raw, tt.separator, tt.exponent)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping CSVMapping
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "signed amounts with headers",
+			data: "Date,Description,Reference,Amount\n" +
+				"2024-10-01,Rent,OCT,-1200.00\n" +
+				"\n" +
+				"2024-10-02,Salary,,3500.50\n",
+			mapping: CSVMapping{
+				SkipRows:     1,
+				DateColumn:   0,
+				DateFormat:   "2006-01-02",
+				AmountColumn: 3,
+				Sign:         NegativeIsOutflow,
+				NotesColumns: []int{1, 2},
+			},
+			want: []Entry{
+				{Date: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), Amount: -120000, Notes: "Rent OCT"},
+				{Date: time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC), Amount: 350050, Notes: "Salary"},
+			},
+		},
+		{
+			name: "credit card statement with european format",
+			data: "01.10.2024;Coffee;4,50\n02.10.2024;Refund;-10,00\n",
+			mapping: CSVMapping{
+				Delimiter:        ";",
+				DateColumn:       0,
+				DateFormat:       "02.01.2006",
+				AmountColumn:     2,
+				Sign:             PositiveIsOutflow,
+				NotesColumns:     []int{1},
+				DecimalSeparator: ",",
+			},
+			want: []Entry{
+				{Date: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), Amount: -450, Notes: "Coffee"},
+				{Date: time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC), Amount: 1000, Notes: "Refund"},
+			},
+		},
+		{
+			name: "split outflow and inflow columns",
+			data: "10/01/2024,Groceries,52.10,\n10/03/2024,Interest,,1.25\n",
+			mapping: CSVMapping{
+				DateColumn:    0,
+				DateFormat:    "01/02/2006",
+				OutflowColumn: nullable.New(2),
+				InflowColumn:  nullable.New(3),
+				Sign:          SplitColumns,
+				NotesColumns:  []int{1},
+			},
+			want: []Entry{
+				{Date: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), Amount: -5210, Notes: "Groceries"},
+				{Date: time.Date(2024, time.October, 3, 0, 0, 0, 0, time.UTC), Amount: 125, Notes: "Interest"},
+			},
+		},
+		{
+			name: "missing column",
+			data: "2024-10-01,Rent\n",
+			mapping: CSVMapping{
+				DateFormat:   "2006-01-02",
+				AmountColumn: 3,
+				Sign:         NegativeIsOutflow,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mapping",
+			data:    "2024-10-01,Rent,-1\n",
+			mapping: CSVMapping{DateFormat: "2006-01-02", Sign: SplitColumns},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV(strings.NewReader(tt.data), tt.mapping, 2)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Package statement parses bank statements into a list of [Entry].
+package statement
+
+import "time"
+
+// Entry is a single movement found in a bank statement, seen from the
+// statement's account.
+type Entry struct {
+	// Date is the date the movement was booked, truncated to midnight UTC.
+	Date time.Time
+	// Amount is expressed in the currency's minor units. It is negative when
+	// money leaves the account and positive when money enters the account.
+	Amount int64
+	// Notes is the free text the bank attached to the movement.
+	Notes string
+	// ExternalID is the bank's identifier of the movement, like OFX's FITID.
+	// It is empty when the statement doesn't provide one.
+	ExternalID string
+}
@@ -0,0 +1,69 @@
+package statement
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format is the file format of a bank statement.
+type Format string
+
+const (
+	CSV Format = "csv"
+	// OFX covers OFX 1.x (SGML), OFX 2.x (XML) and Quicken's QFX.
+	OFX     Format = "ofx"
+	CAMT053 Format = "camt053"
+)
+
+// ParseFormat maps raw to a [Format], QFX is accepted as an alias of [OFX].
+//
+// It returns an error if raw is not a supported [Format].
+func ParseFormat(raw string) (Format, error) {
+	switch strings.ToLower(raw) {
+	default:
+		return "", fmt.Errorf("statement: invalid format \"%s\"", raw)
+	case "csv":
+		return CSV, nil
+	case "ofx", "qfx":
+		return OFX, nil
+	case "camt053", "camt.053":
+		return CAMT053, nil
+	}
+}
+
+// UnmarshalJSON receives a buffer b, and ensures that the provided value is a
+// valid [Format]. So [Format] satisfies the [json.Unmarshaler] interface.
+//
+// It returns an error if the buffer can't be unmarshal into an string or the
+// provided value is not a supported [Format].
+func (f *Format) UnmarshalJSON(b []byte) error {
+	var raw string
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	format, err := ParseFormat(raw)
+	if err != nil {
+		return err
+	}
+
+	*f = format
+
+	return nil
+}
+
+// MarshalJSON returns the json encoding of [Format]. So [Format] satisfies the
+// [json.Marshaler] interface.
+//
+// It returns an error if [Format] is an unsupported value or if json encoding
+// fails.
+func (f Format) MarshalJSON() ([]byte, error) {
+	switch f {
+	default:
+		return []byte{}, fmt.Errorf("statement: invalid format \"%s\"", string(f))
+	case CSV, OFX, CAMT053:
+		return json.Marshal(string(f))
+	}
+}
@@ -0,0 +1,124 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ofxEntities = strings.NewReplacer(
+		"&lt;", "<",
+		"&gt;", ">",
+		"&quot;", "\"",
+		"&apos;", "'",
+		"&amp;", "&",
+	)
+)
+
+// ParseOFX reads an OFX or QFX statement from r and maps every STMTTRN
+// aggregate into an [Entry]. Both OFX 1.x, where elements are not closed,
+// and OFX 2.x are supported. The FITID is used as [Entry] ExternalID.
+// Amounts are converted into minor units of a currency with the given
+// exponent.
+//
+// It returns an error pointing to the first transaction that can't be parsed.
+func ParseOFX(r io.Reader, exponent int) ([]Entry, error) {
+	var (
+		entries = make([]Entry, 0, 50)
+	)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return entries, errors.Join(errors.New("statement: failed to read ofx"), err)
+	}
+
+	body := string(data)
+
+	for i := 1; ; i++ {
+		start := strings.Index(body, "<STMTTRN>")
+		if start < 0 {
+			break
+		}
+
+		body = body[start+len("<STMTTRN>"):]
+
+		end := strings.Index(body, "</STMTTRN>")
+		if end < 0 {
+			return entries, fmt.Errorf("statement: ofx transaction %d is not closed", i)
+		}
+
+		entry, err := ofxEntry(body[:end], exponent)
+		if err != nil {
+			return entries, errors.Join(fmt.Errorf("statement: failed to parse ofx transaction %d", i), err)
+		}
+
+		entries = append(entries, entry)
+		body = body[end+len("</STMTTRN>"):]
+	}
+
+	return entries, nil
+}
+
+func ofxEntry(aggregate string, exponent int) (Entry, error) {
+	var (
+		entry Entry
+		notes = make([]string, 0, 2)
+	)
+
+	posted := ofxElement(aggregate, "DTPOSTED")
+	if len(posted) < 8 {
+		return entry, fmt.Errorf("statement: invalid ofx date \"%s\"", posted)
+	}
+
+	date, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Date = date
+
+	amount := ofxElement(aggregate, "TRNAMT")
+	separator := "."
+
+	if strings.Contains(amount, ",") && !strings.Contains(amount, ".") {
+		separator = ","
+	}
+
+	entry.Amount, err = ParseAmount(amount, separator, exponent)
+	if err != nil {
+		return entry, err
+	}
+
+	for _, name := range []string{"NAME", "MEMO"} {
+		if value := ofxElement(aggregate, name); value != "" {
+			notes = append(notes, value)
+		}
+	}
+
+	entry.Notes = strings.Join(notes, " ")
+	entry.ExternalID = ofxElement(aggregate, "FITID")
+
+	return entry, nil
+}
+
+// ofxElement returns the text of the first element called name inside
+// aggregate. OFX 1.x elements end where the next tag starts.
+func ofxElement(aggregate string, name string) string {
+	tag := "<" + name + ">"
+
+	start := strings.Index(aggregate, tag)
+	if start < 0 {
+		return ""
+	}
+
+	value := aggregate[start+len(tag):]
+
+	if end := strings.Index(value, "<"); end >= 0 {
+		value = value[:end]
+	}
+
+	return ofxEntities.Replace(strings.TrimSpace(value))
+}
@@ -0,0 +1,65 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "ofx 1.x sgml",
+			data: "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n" +
+				"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>\n" +
+				"<STMTTRN>\n<TRNTYPE>DEBIT\n<DTPOSTED>20241001120000[-5:EST]\n<TRNAMT>-45.10\n<FITID>2024100101\n<NAME>GROCERY STORE\n<MEMO>Card 1234\n</STMTTRN>\n" +
+				"<STMTTRN>\n<TRNTYPE>CREDIT\n<DTPOSTED>20241002\n<TRNAMT>1500.00\n<FITID>2024100201\n<NAME>PAYROLL\n</STMTTRN>\n" +
+				"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n",
+			want: []Entry{
+				{Date: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), Amount: -4510, Notes: "GROCERY STORE Card 1234", ExternalID: "2024100101"},
+				{Date: time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC), Amount: 150000, Notes: "PAYROLL", ExternalID: "2024100201"},
+			},
+		},
+		{
+			name: "ofx 2.x xml",
+			data: `<?xml version="1.0" encoding="UTF-8"?><?OFX OFXHEADER="200" VERSION="220"?>` +
+				`<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><BANKTRANLIST>` +
+				`<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20241003</DTPOSTED><TRNAMT>-9.99</TRNAMT>` +
+				`<FITID>ABC-1</FITID><NAME>Books &amp; More</NAME></STMTTRN>` +
+				`</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`,
+			want: []Entry{
+				{Date: time.Date(2024, time.October, 3, 0, 0, 0, 0, time.UTC), Amount: -999, Notes: "Books & More", ExternalID: "ABC-1"},
+			},
+		},
+		{
+			name:    "invalid amount",
+			data:    "<STMTTRN><DTPOSTED>20241003<TRNAMT>abc<FITID>1</STMTTRN>",
+			wantErr: true,
+		},
+		{
+			name:    "unclosed transaction",
+			data:    "<STMTTRN><DTPOSTED>20241003<TRNAMT>1.00<FITID>1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOFX(strings.NewReader(tt.data), 2)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
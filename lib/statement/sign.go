@@ -0,0 +1,71 @@
+package statement
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SignConvention describes how a bank writes the direction of a movement.
+type SignConvention string
+
+const (
+	// NegativeIsOutflow is used by banks that write money leaving the account
+	// as a negative amount.
+	NegativeIsOutflow SignConvention = "negative_is_outflow"
+	// PositiveIsOutflow is used by banks that write money leaving the account
+	// as a positive amount, usually credit card statements.
+	PositiveIsOutflow SignConvention = "positive_is_outflow"
+	// SplitColumns is used by banks that write outflows and inflows in
+	// different columns.
+	SplitColumns SignConvention = "split_columns"
+)
+
+// UnmarshalJSON receives a buffer b, and ensures that the provided value is a
+// valid [SignConvention]. So [SignConvention] satisfies the
+// [json.Unmarshaler] interface.
+//
+// It returns an error if the buffer can't be unmarshal into an string or the
+// provided value is not a supported [SignConvention].
+func (s *SignConvention) UnmarshalJSON(b []byte) error {
+	var raw string
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(raw) {
+	default:
+		return fmt.Errorf("statement: invalid sign convention \"%s\"", raw)
+	case "negative_is_outflow":
+		*s = NegativeIsOutflow
+	case "positive_is_outflow":
+		*s = PositiveIsOutflow
+	case "split_columns":
+		*s = SplitColumns
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the json encoding of [SignConvention]. So
+// [SignConvention] satisfies the [json.Marshaler] interface.
+//
+// It returns an error if [SignConvention] is an unsupported value or if json
+// encoding fails.
+func (s SignConvention) MarshalJSON() ([]byte, error) {
+	var raw string
+
+	switch s {
+	default:
+		return []byte{}, fmt.Errorf("statement: invalid sign convention \"%s\"", string(s))
+	case NegativeIsOutflow:
+		raw = "negative_is_outflow"
+	case PositiveIsOutflow:
+		raw = "positive_is_outflow"
+	case SplitColumns:
+		raw = "split_columns"
+	}
+
+	return json.Marshal(raw)
+}
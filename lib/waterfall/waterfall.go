@@ -0,0 +1,27 @@
+// Package waterfall distributes an amount across ordered buckets, filling
+// each one before moving to the next.
+package waterfall
+
+// Fill distributes available across targets in order, every bucket gets up to
+// its target before the next one gets anything. Non-positive amounts and
+// targets get nothing.
+//
+// It returns the amount given to each bucket, in the order of targets.
+func Fill(available int64, targets []int64) []int64 {
+	filled := make([]int64, len(targets))
+
+	for i, target := range targets {
+		if available <= 0 {
+			break
+		}
+
+		if target <= 0 {
+			continue
+		}
+
+		filled[i] = min(target, available)
+		available -= filled[i]
+	}
+
+	return filled
+}
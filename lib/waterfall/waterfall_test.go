@@ -0,0 +1,65 @@
+package waterfall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFill(t *testing.T) {
+	tests := []struct {
+		name      string
+		available int64
+		targets   []int64
+		want      []int64
+	}{
+		{
+			name:      "nothing available",
+			available: 0,
+			targets:   []int64{100, 200},
+			want:      []int64{0, 0},
+		},
+		{
+			name:      "negative balance",
+			available: -50,
+			targets:   []int64{100},
+			want:      []int64{0},
+		},
+		{
+			name:      "partially fills the first bucket",
+			available: 40,
+			targets:   []int64{100, 200},
+			want:      []int64{40, 0},
+		},
+		{
+			name:      "fills in order",
+			available: 150,
+			targets:   []int64{100, 200, 300},
+			want:      []int64{100, 50, 0},
+		},
+		{
+			name:      "surplus is left over",
+			available: 1000,
+			targets:   []int64{100, 200},
+			want:      []int64{100, 200},
+		},
+		{
+			name:      "skips empty targets",
+			available: 150,
+			targets:   []int64{0, 100, -20, 100},
+			want:      []int64{0, 100, 0, 50},
+		},
+		{
+			name:      "no buckets",
+			available: 150,
+			targets:   nil,
+			want:      []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Fill(tt.available, tt.targets))
+		})
+	}
+}
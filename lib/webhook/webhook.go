@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 of the timestamp and the body,
+	// see [Sign].
+	SignatureHeader = "X-Financo-Signature"
+	// TimestampHeader holds the unix time the request was signed at, so
+	// receivers can reject replayed requests.
+	TimestampHeader = "X-Financo-Timestamp"
+	EventHeader     = "X-Financo-Event"
+	DeliveryHeader  = "X-Financo-Delivery"
+
+	signaturePrefix = "sha256="
+	responseLimit   = 1 << 10
+)
+
+var ErrUnexpectedStatus = errors.New("webhook: unexpected response status")
+
+// Request is a payload to send to a webhook.
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID int64
+	Body       []byte
+}
+
+// Result describes the response of the webhook, StatusCode is zero when no
+// response was received.
+type Result struct {
+	StatusCode int
+	Duration   time.Duration
+}
+
+// Sign returns the signature of body sent at timestamp, it is the hex encoded
+// HMAC-SHA256 of "<unix timestamp>.<body>" keyed with secret, prefixed with
+// "sha256=".
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature and timestamp are the headers of body
+// signed with secret, receivers can use it to authenticate requests.
+func Verify(secret, signature, timestamp string, body []byte) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	expected := Sign(secret, time.Unix(unix, 0), body)
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// Send posts the signed body of req to its URL, the timeout is the one of
+// ctx or client.
+//
+// It returns an error if the request can't be sent or the response status
+// isn't 2xx, wrapping [ErrUnexpectedStatus] in the latter case.
+func Send(ctx context.Context, client *http.Client, req Request) (Result, error) {
+	var (
+		res       Result
+		timestamp = time.Now()
+	)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return res, errors.Join(errors.New("webhook: failed to build request"), err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "financo-webhooks")
+	httpReq.Header.Set(EventHeader, req.Event)
+	httpReq.Header.Set(DeliveryHeader, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, timestamp, req.Body))
+
+	httpRes, err := client.Do(httpReq)
+	res.Duration = time.Since(timestamp)
+	if err != nil {
+		return res, errors.Join(errors.New("webhook: failed to send request"), err)
+	}
+	defer httpRes.Body.Close()
+
+	res.StatusCode = httpRes.StatusCode
+
+	// A short excerpt of the body helps telling why the webhook refused the
+	// payload, the rest is discarded so the connection can be reused.
+	excerpt, _ := io.ReadAll(io.LimitReader(httpRes.Body, responseLimit))
+	_, _ = io.Copy(io.Discard, httpRes.Body)
+
+	if httpRes.StatusCode < 200 || httpRes.StatusCode > 299 {
+		return res, fmt.Errorf("%w: %s %s", ErrUnexpectedStatus, httpRes.Status, strings.TrimSpace(string(excerpt)))
+	}
+
+	return res, nil
+}
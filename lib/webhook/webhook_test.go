@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	var (
+		body      = []byte(`{"id":1}`)
+		timestamp = time.Unix(1729670400, 0)
+		signature = Sign("secret", timestamp, body)
+	)
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.True(t, Verify("secret", signature, "1729670400", body))
+	assert.False(t, Verify("other", signature, "1729670400", body))
+	assert.False(t, Verify("secret", signature, "1729670401", body))
+	assert.False(t, Verify("secret", signature, "1729670400", []byte(`{"id":2}`)))
+	assert.False(t, Verify("secret", signature[7:], "1729670400", body))
+}
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		delay      time.Duration
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "accepted", status: http.StatusNoContent, wantStatus: http.StatusNoContent},
+		{name: "refused", status: http.StatusInternalServerError, wantStatus: http.StatusInternalServerError, wantErr: true},
+		{name: "timeout", status: http.StatusOK, delay: 200 * time.Millisecond, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verified := make(chan bool, 1)
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				verified <- Verify("secret", r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body) &&
+					r.Header.Get(EventHeader) == "transaction.created" &&
+					r.Header.Get(DeliveryHeader) == "42"
+
+				time.Sleep(tt.delay)
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+
+			client := &http.Client{Timeout: 100 * time.Millisecond}
+
+			res, err := Send(context.Background(), client, Request{
+				URL:        receiver.URL,
+				Secret:     "secret",
+				Event:      "transaction.created",
+				DeliveryID: 42,
+				Body:       []byte(`{"id":1}`),
+			})
+
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+			assert.True(t, <-verified)
+		})
+	}
+}
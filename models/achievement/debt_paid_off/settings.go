@@ -0,0 +1,10 @@
+package debt_paid_off
+
+// Settings is the struct representing the json stored inside the settings column
+// of every Achievement record with kind "debt_paid_off".
+//
+// The achievement is reached once the balance of the debt account AccountID
+// is back to zero.
+type Settings struct {
+	AccountID int64 `json:"accountId"`
+}
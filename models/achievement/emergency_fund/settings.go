@@ -0,0 +1,13 @@
+package emergency_fund
+
+import "financo/lib/currency"
+
+// Settings is the struct representing the json stored inside the settings column
+// of every Achievement record with kind "emergency_fund".
+//
+// The achievement is reached once the savings in Currency cover Months months
+// of the average monthly expenses.
+type Settings struct {
+	Months   int           `json:"months"`
+	Currency currency.Type `json:"currency"`
+}
@@ -5,14 +5,50 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 type Kind string
 
 const (
-	SavingsGoal Kind = "savings_goal"
+	SavingsGoal       Kind = "savings_goal"
+	DebtPaidOff       Kind = "debt_paid_off"
+	NetWorthMilestone Kind = "net_worth_milestone"
+	SpendingStreak    Kind = "spending_streak"
+	EmergencyFund     Kind = "emergency_fund"
 )
 
+var (
+	kindsMu sync.RWMutex
+	// kinds holds the kinds that can be stored, the built-in ones are known
+	// up front so every binary can read and write them.
+	kinds = map[Kind]struct{}{
+		SavingsGoal:       {},
+		DebtPaidOff:       {},
+		NetWorthMilestone: {},
+		SpendingStreak:    {},
+		EmergencyFund:     {},
+	}
+)
+
+// Register makes k a supported value of [Kind], so achievements of a kind
+// defined outside of this package can be stored.
+func Register(k Kind) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	kinds[k] = struct{}{}
+}
+
+func registered(k Kind) bool {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+
+	_, ok := kinds[k]
+
+	return ok
+}
+
 // Scan takes the value returned by the SQL database and maps it to
 // [Kind]. So [Kind] satisfies the [sql.Scanner] interface.
 //
@@ -23,13 +59,13 @@ func (k *Kind) Scan(value any) error {
 		return errors.New("achievement: invalid column type")
 	}
 
-	switch strings.ToLower(s) {
-	default:
+	kind := Kind(strings.ToLower(s))
+	if !registered(kind) {
 		return fmt.Errorf("achievement: invalid achievement kind \"%s\"", value)
-	case "savings_goal":
-		*k = SavingsGoal
 	}
 
+	*k = kind
+
 	return nil
 }
 
@@ -38,14 +74,9 @@ func (k *Kind) Scan(value any) error {
 //
 // It returns an error if [Kind] is an unsupported value.
 func (k Kind) Value() (driver.Value, error) {
-	var s string
-
-	switch k {
-	default:
-		return s, fmt.Errorf("account: invalid account kind \"%s\"", string(k))
-	case SavingsGoal:
-		s = "savings_goal"
+	if !registered(k) {
+		return "", fmt.Errorf("achievement: invalid achievement kind \"%s\"", string(k))
 	}
 
-	return s, nil
+	return string(k), nil
 }
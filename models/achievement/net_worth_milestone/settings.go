@@ -0,0 +1,13 @@
+package net_worth_milestone
+
+import "financo/lib/currency"
+
+// Settings is the struct representing the json stored inside the settings column
+// of every Achievement record with kind "net_worth_milestone".
+//
+// The achievement is reached once the capital minus the debts in Currency
+// reaches Target.
+type Settings struct {
+	Target   int64         `json:"target"`
+	Currency currency.Type `json:"currency"`
+}
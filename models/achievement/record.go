@@ -12,7 +12,16 @@ type Record[Settings any] struct {
 	Description nullable.Type[string]    `json:"description"`
 	Settings    Settings                 `json:"settings"`
 	AchievedAt  nullable.Type[time.Time] `json:"achievedAt"`
+	ArchivedAt  nullable.Type[time.Time] `json:"archivedAt"`
 	DeletedAt   nullable.Type[time.Time] `json:"deletedAt"`
 	CreatedAt   time.Time                `json:"createdAt"`
 	UpdatedAt   time.Time                `json:"updatedAt"`
 }
+
+func (r Record[Settings]) GetKind() Kind {
+	return r.Kind
+}
+
+func (r Record[Settings]) AchieveTime() time.Time {
+	return r.AchievedAt.Val
+}
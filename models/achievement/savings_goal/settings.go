@@ -5,16 +5,23 @@ import (
 	"encoding/json"
 	"errors"
 	"financo/lib/currency"
+	"financo/lib/nullable"
 	"fmt"
+	"time"
 )
 
 // Settings is the struct representing the json stored inside the settings column
 // of every Achievement record with kind "achievement_goal".
+//
+// Position orders the goals of a currency, it is unique among the active
+// goals of a currency, the ones neither achieved, archived nor deleted.
+// Deadline is the optional date the goal should be reached by.
 type Settings struct {
-	Position int16         `json:"position"` // unique
-	Target   int64         `json:"target"`
-	Saved    int64         `json:"saved"`
-	Currency currency.Type `json:"currency"`
+	Position int16                    `json:"position"` // unique
+	Target   int64                    `json:"target"`
+	Saved    int64                    `json:"saved"`
+	Currency currency.Type            `json:"currency"`
+	Deadline nullable.Type[time.Time] `json:"deadline"`
 }
 
 // Scan takes the json value returned by the SQL database and maps it to
@@ -0,0 +1,14 @@
+package spending_streak
+
+import "financo/lib/currency"
+
+// Settings is the struct representing the json stored inside the settings column
+// of every Achievement record with kind "spending_streak".
+//
+// The achievement is reached once the expenses in Currency stayed at or under
+// Budget for each of the last Months complete months.
+type Settings struct {
+	Months   int           `json:"months"`
+	Budget   int64         `json:"budget"`
+	Currency currency.Type `json:"currency"`
+}
@@ -0,0 +1,40 @@
+package audit_entry
+
+import (
+	"financo/lib/diff"
+	"financo/lib/nullable"
+	"time"
+)
+
+// Entity is the kind of record an entry audits
+type Entity string
+
+const (
+	Account     Entity = "account"
+	Transaction Entity = "transaction"
+)
+
+// Action is the change an entry audits
+type Action string
+
+const (
+	Created Action = "created"
+	Updated Action = "updated"
+	Deleted Action = "deleted"
+)
+
+// Record is an append-only entry of the audit log, Changes holds the fields
+// of the audited record that changed.
+type Record struct {
+	ID        int64
+	Entity    Entity
+	RecordID  int64
+	Action    Action
+	Changes   diff.Changes
+	RequestID nullable.Type[string]
+	// OutboxID is the id of the outbox message the entry was recorded from,
+	// a message delivered again doesn't record a second entry.
+	OutboxID   nullable.Type[int64]
+	OccurredAt time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,19 @@
+package categorization_rule
+
+import (
+	"financo/lib/categorize"
+	"financo/lib/nullable"
+	"time"
+)
+
+// Record is a categorization rule, rules are matched in Position order.
+type Record struct {
+	ID        int64
+	Name      string
+	Position  int
+	Condition categorize.Condition
+	Action    categorize.Action
+	DeletedAt nullable.Type[time.Time]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package dead_letter
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Record is a message a consumer failed to consume after exhausting its
+// retries, Subscription names the consumer and Payload holds the message.
+type Record struct {
+	ID           int64
+	Bus          string
+	Subscription string
+	Payload      json.RawMessage
+	Error        string
+	Attempts     int
+	FailedAt     time.Time
+	CreatedAt    time.Time
+}
@@ -0,0 +1,21 @@
+package deletion
+
+import "time"
+
+// Kind is the kind of record whose delete produced the deletion
+type Kind string
+
+const (
+	Account     Kind = "account"
+	Transaction Kind = "transaction"
+)
+
+// Record groups every account and transaction removed by a single delete, so
+// they can be restored or purged together.
+type Record struct {
+	ID        int64
+	Kind      Kind
+	RecordID  int64
+	DeletedAt time.Time
+	CreatedAt time.Time
+}
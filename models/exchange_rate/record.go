@@ -0,0 +1,16 @@
+package exchange_rate
+
+import (
+	"financo/lib/currency"
+	"time"
+)
+
+type Record struct {
+	ID        int64
+	Date      time.Time
+	From      currency.Type
+	To        currency.Type
+	Rate      float64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
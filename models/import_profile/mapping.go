@@ -0,0 +1,64 @@
+package import_profile
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"financo/lib/nullable"
+	"financo/lib/statement"
+	"fmt"
+)
+
+// Mapping is the struct representing the json stored inside the mapping column
+// of every import profile record. It describes how a bank's statement maps
+// onto transactions.
+type Mapping struct {
+	// CSV is only required to import CSV statements, other formats describe
+	// their own columns.
+	CSV   nullable.Type[statement.CSVMapping] `json:"csv"`
+	Rules []Rule                              `json:"rules"`
+	// DefaultOutflowID is the counter account used for money leaving the
+	// statement's account when no [Rule] matches.
+	DefaultOutflowID nullable.Type[int64] `json:"defaultOutflowID"`
+	// DefaultInflowID is the counter account used for money entering the
+	// statement's account when no [Rule] matches.
+	DefaultInflowID nullable.Type[int64] `json:"defaultInflowID"`
+}
+
+// Rule assigns AccountID as the counter account of every statement entry
+// whose notes contain the text in Contains, case insensitive.
+type Rule struct {
+	Contains  string `json:"contains"`
+	AccountID int64  `json:"accountID"`
+}
+
+// Scan takes the json value returned by the SQL database and maps it to
+// [Mapping]. So [Mapping] satisfies the [sql.Scanner] interface.
+//
+// It returns an error if [Mapping] can't be mapped to the json given by the
+// SQL database.
+func (m *Mapping) Scan(value any) error {
+	data, ok := value.([]uint8)
+	if !ok {
+		return errors.New("import_profile: invalid column type")
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return errors.Join(fmt.Errorf("import_profile: records: mapping: can't be mapped"), err)
+	}
+
+	return nil
+}
+
+// Value returns the json encoding of [Mapping] to be stored in the SQL
+// database. So [Mapping] satisfies the [driver.Valuer] interface.
+//
+// It returns an error if [Mapping] can't be marshaled into json.
+func (m Mapping) Value() (driver.Value, error) {
+	b, err := json.Marshal(&m)
+	if err != nil {
+		return b, errors.Join(fmt.Errorf("import_profile: records: mapping: can't be marshaled"), err)
+	}
+
+	return []uint8(b), nil
+}
@@ -0,0 +1,15 @@
+package import_profile
+
+import (
+	"financo/lib/nullable"
+	"time"
+)
+
+type Record struct {
+	ID        int64
+	Name      string
+	Mapping   Mapping
+	DeletedAt nullable.Type[time.Time]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,21 @@
+package outbox_message
+
+import (
+	"encoding/json"
+	"financo/lib/nullable"
+	"time"
+)
+
+// Record is a message waiting in the outbox until the relay publishes it,
+// DeliveredAt is set once it was handed to its bus, NextAttemptAt delays the
+// next attempt after a failed one.
+type Record struct {
+	ID            int64
+	Topic         string
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     nullable.Type[string]
+	NextAttemptAt time.Time
+	DeliveredAt   nullable.Type[time.Time]
+	CreatedAt     time.Time
+}
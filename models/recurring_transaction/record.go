@@ -0,0 +1,23 @@
+package recurring_transaction
+
+import (
+	"financo/lib/nullable"
+	"financo/lib/recurrence"
+	"time"
+)
+
+type Record struct {
+	ID                int64
+	SourceID          int64
+	TargetID          int64
+	SourceAmount      int64
+	TargetAmount      int64
+	Notes             nullable.Type[string]
+	Rule              recurrence.Rule
+	StartsAt          time.Time
+	EndsAt            nullable.Type[time.Time]
+	MaterializedUntil nullable.Type[time.Time]
+	DeletedAt         nullable.Type[time.Time]
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -0,0 +1,16 @@
+package tag
+
+import (
+	"financo/lib/color"
+	"financo/lib/nullable"
+	"time"
+)
+
+type Record struct {
+	ID        int64
+	Name      string
+	Color     color.Type
+	DeletedAt nullable.Type[time.Time]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
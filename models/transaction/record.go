@@ -14,7 +14,14 @@ type Record struct {
 	Notes        nullable.Type[string]
 	IssuedAt     time.Time
 	ExecutedAt   nullable.Type[time.Time]
-	DeletedAt    nullable.Type[time.Time]
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// StatementAccountID and ExternalID identify transactions imported from a
+	// bank statement, ExternalID is unique per statement account.
+	StatementAccountID nullable.Type[int64]
+	ExternalID         nullable.Type[string]
+	// SplitID references the transaction_split.Record the transaction is a
+	// leg of.
+	SplitID   nullable.Type[int64]
+	DeletedAt nullable.Type[time.Time]
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
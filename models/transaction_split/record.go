@@ -0,0 +1,21 @@
+package transaction_split
+
+import (
+	"financo/lib/nullable"
+	"time"
+)
+
+// Record is the parent of a split transaction. Every leg is persisted as a
+// transaction.Record from SourceID pointing back to it, SourceAmount is the
+// sum of the source amounts of its legs.
+type Record struct {
+	ID           int64
+	SourceID     int64
+	SourceAmount int64
+	Notes        nullable.Type[string]
+	IssuedAt     time.Time
+	ExecutedAt   nullable.Type[time.Time]
+	DeletedAt    nullable.Type[time.Time]
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,15 @@
+package webhook
+
+import "time"
+
+// Record is an endpoint notified of account and transaction changes, Events
+// filters the event types it receives, an empty filter receives them all.
+// Secret signs every payload sent to URL.
+type Record struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package webhook_attempt
+
+import (
+	"financo/lib/nullable"
+	"time"
+)
+
+// Record is a single try at sending a delivery, StatusCode is missing when
+// the webhook didn't answer, e.g. on a timeout, and Error explains why the
+// attempt failed.
+type Record struct {
+	ID          int64
+	DeliveryID  int64
+	StatusCode  nullable.Type[int]
+	Error       nullable.Type[string]
+	DurationMs  int64
+	AttemptedAt time.Time
+}
@@ -0,0 +1,31 @@
+package webhook_delivery
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status is the state of a delivery
+type Status string
+
+const (
+	Pending   Status = "pending"
+	Succeeded Status = "succeeded"
+	Failed    Status = "failed"
+)
+
+// Record is an event sent to a webhook, EventID is the id of the outbox
+// message behind the event. A pending delivery is attempted at NextAttemptAt
+// until it succeeds or runs out of attempts.
+type Record struct {
+	ID            int64
+	WebhookID     int64
+	EventID       int64
+	Event         string
+	Payload       json.RawMessage
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,120 @@
+package achievements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/currency"
+	"financo/models/account"
+	"time"
+)
+
+// balance returns the balance as of now of the accounts of the kinds in the
+// currency, archived and deleted accounts are left out.
+func balance(ctx context.Context, tx *sql.Tx, kinds []account.Kind, c currency.Type, now time.Time) (int64, error) {
+	var amount int64
+
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			SELECT COALESCE(SUM(
+				CASE
+					WHEN tr.target_id = acc.id THEN tr.target_amount
+					WHEN tr.source_id = acc.id THEN - tr.source_amount
+					ELSE 0
+				END
+			), 0)
+			FROM transactions tr
+				INNER JOIN accounts acc ON acc.id = tr.target_id OR acc.id = tr.source_id
+			WHERE
+				acc.kind = ANY ($1)
+				AND acc.currency = $2
+				AND tr.deleted_at IS NULL
+				AND acc.deleted_at IS NULL
+				AND acc.archived_at IS NULL
+				AND (tr.executed_at IS NULL OR tr.executed_at <= $3)
+				AND tr.issued_at <= $3
+		`,
+		kinds,
+		c,
+		now,
+	).Scan(&amount)
+
+	return amount, err
+}
+
+// expenses returns the net amount spent in the currency during each month
+// between from and until, months without expenses are left out.
+func expenses(ctx context.Context, tx *sql.Tx, c currency.Type, from, until time.Time) (map[time.Time]int64, error) {
+	res := make(map[time.Time]int64, 12)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT
+				date_trunc('month', COALESCE(tr.executed_at, tr.issued_at) AT TIME ZONE 'UTC'),
+				SUM(
+					CASE
+						WHEN tr.target_id = acc.id THEN tr.target_amount
+						WHEN tr.source_id = acc.id THEN - tr.source_amount
+						ELSE 0
+					END
+				)
+			FROM transactions tr
+				INNER JOIN accounts acc ON acc.id = tr.target_id OR acc.id = tr.source_id
+			WHERE
+				acc.kind = $1
+				AND acc.currency = $2
+				AND tr.deleted_at IS NULL
+				AND acc.deleted_at IS NULL
+				AND COALESCE(tr.executed_at, tr.issued_at) >= $3
+				AND COALESCE(tr.executed_at, tr.issued_at) < $4
+			GROUP BY 1
+		`,
+		account.ExternalExpense,
+		c,
+		from,
+		until,
+	)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			month time.Time
+			spent int64
+		)
+
+		err = rows.Scan(&month, &spent)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan expenses"), err)
+		}
+
+		res[month] = spent
+	}
+
+	return res, rows.Err()
+}
+
+// historySince reports whether the transactions history starts at or before
+// the moment.
+func historySince(ctx context.Context, tx *sql.Tx, moment time.Time) (bool, error) {
+	var covered bool
+
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			SELECT EXISTS (
+				SELECT 1
+				FROM transactions
+				WHERE deleted_at IS NULL
+					AND COALESCE(executed_at, issued_at) <= $1
+			)
+		`,
+		moment,
+	).Scan(&covered)
+
+	return covered, err
+}
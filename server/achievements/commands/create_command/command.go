@@ -0,0 +1,101 @@
+package create_command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/nullable"
+	"financo/models/achievement"
+	"financo/server/achievements"
+	"financo/server/achievements/queries/detailed_query"
+	"financo/server/achievements/types/request"
+	"financo/services/postgresql_database"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[achievement.Record[json.RawMessage]] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run persists the achievement and evaluates it right away, so milestones
+// already behind are unlocked without waiting for the next change.
+func (c *command) Run(ctx context.Context) (achievement.Record[json.RawMessage], error) {
+	var (
+		postgres = postgresql_database.New()
+
+		id  int64
+		res achievement.Record[json.RawMessage]
+	)
+
+	if strings.TrimSpace(c.req.Name) == "" {
+		return res, fmt.Errorf("%w: name is required", achievements.ErrInvalid)
+	}
+
+	err := achievements.ValidateCreate(c.req.Kind, c.req.Settings)
+	if err != nil {
+		return res, err
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	reached, err := achievements.Evaluate(ctx, tx, c.req.Kind, c.req.Settings, c.timestamp)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to evaluate achievement"), err, tx.Rollback())
+	}
+
+	var achievedAt nullable.Type[time.Time]
+	if reached {
+		achievedAt = nullable.New(c.timestamp)
+	}
+
+	err = tx.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO achievements(kind, name, description, settings, achieved_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`,
+		c.req.Kind,
+		strings.TrimSpace(c.req.Name),
+		c.req.Description,
+		string(c.req.Settings),
+		achievedAt,
+		c.timestamp,
+		c.timestamp,
+	).Scan(&id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err = detailed_query.New(id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted achievement"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,60 @@
+package delete_command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/achievement"
+	"financo/server/achievements/queries/detailed_query"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	id        int64
+	timestamp time.Time
+}
+
+func New(id int64) commands.Command[achievement.Record[json.RawMessage]] {
+	return &command{
+		id:        id,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run soft deletes the achievement, savings goals are deleted through their
+// own endpoint.
+func (c *command) Run(ctx context.Context) (achievement.Record[json.RawMessage], error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find achievement"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(
+		ctx,
+		`
+			UPDATE achievements
+			SET deleted_at = $2, updated_at = $2
+			WHERE id = $1
+				AND deleted_at IS NULL
+		`,
+		c.id,
+		c.timestamp,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to delete record"), err)
+	}
+
+	return res, nil
+}
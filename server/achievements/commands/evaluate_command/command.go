@@ -0,0 +1,117 @@
+package evaluate_command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/achievement"
+	"financo/server/achievements"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	timestamp time.Time
+}
+
+func New() commands.Command[[]int64] {
+	return &command{
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run evaluates every pending achievement whose kind has an evaluator and
+// unlocks the ones reached. Pending achievements are locked while evaluated
+// so concurrent runs unlock each one once.
+//
+// It returns the ids of the unlocked achievements.
+func (c *command) Run(ctx context.Context) ([]int64, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res = make([]int64, 0, 5)
+	)
+
+	type pending struct {
+		id       int64
+		kind     achievement.Kind
+		settings []byte
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT id, kind, settings
+			FROM achievements
+			WHERE kind = ANY ($1)
+				AND achieved_at IS NULL
+				AND archived_at IS NULL
+				AND deleted_at IS NULL
+			ORDER BY id
+			FOR UPDATE
+		`,
+		achievements.Evaluated(),
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to query pending achievements"), err, tx.Rollback())
+	}
+
+	all := make([]pending, 0, 10)
+	for rows.Next() {
+		var p pending
+
+		err = rows.Scan(&p.id, &p.kind, &p.settings)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan pending achievement"), err, rows.Close(), tx.Rollback())
+		}
+
+		all = append(all, p)
+	}
+
+	err = errors.Join(rows.Err(), rows.Close())
+	if err != nil {
+		return res, errors.Join(errors.New("failed to iterate pending achievements"), err, tx.Rollback())
+	}
+
+	for _, p := range all {
+		reached, err := achievements.Evaluate(ctx, tx, p.kind, json.RawMessage(p.settings), c.timestamp)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to evaluate achievement"), err, tx.Rollback())
+		}
+
+		if !reached {
+			continue
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE achievements SET achieved_at = $2, updated_at = $2 WHERE id = $1",
+			p.id,
+			c.timestamp,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to unlock achievement"), err, tx.Rollback())
+		}
+
+		res = append(res, p.id)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return res, nil
+}
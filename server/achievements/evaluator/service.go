@@ -0,0 +1,98 @@
+// Package evaluator unlocks the pending achievements as the accounts and the
+// transactions they depend on change.
+package evaluator
+
+import (
+	"context"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/core/scope_accounts/infrastructure/broker_handler"
+	"financo/lib/message_bus"
+	"financo/server/achievements/commands/evaluate_command"
+	"financo/server/transactions/brokers"
+	"financo/server/transactions/types/message"
+	"log"
+	"time"
+)
+
+const evaluateTimeout = 10 * time.Second
+
+// Subscribe registers the evaluator consumers on the accounts and
+// transactions brokers, every change published from then on evaluates the
+// pending achievements.
+//
+// Achievements are evaluated from the current balances rather than from the
+// messages, so retried or reordered messages unlock the same achievements.
+func Subscribe(accounts broker_handler.BrokerHandler, transactions brokers.Broker) error {
+	err := accounts.CreatedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Created](func(messages.Created) error {
+			return evaluate()
+		}),
+		message_bus.WithName("achievements_account_created"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = accounts.UpdatedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Updated](func(messages.Updated) error {
+			return evaluate()
+		}),
+		message_bus.WithName("achievements_account_updated"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = accounts.DeletedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Deleted](func(messages.Deleted) error {
+			return evaluate()
+		}),
+		message_bus.WithName("achievements_account_deleted"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToCreated(
+		message_bus.ConsumerFunc[message.Created](func(message.Created) error {
+			return evaluate()
+		}),
+		message_bus.WithName("achievements_transaction_created"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToUpdated(
+		message_bus.ConsumerFunc[message.Updated](func(message.Updated) error {
+			return evaluate()
+		}),
+		message_bus.WithName("achievements_transaction_updated"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return transactions.SubscribeToDeleted(
+		message_bus.ConsumerFunc[message.Deleted](func(message.Deleted) error {
+			return evaluate()
+		}),
+		message_bus.WithName("achievements_transaction_deleted"),
+	)
+}
+
+func evaluate() error {
+	ctx, cancel := context.WithTimeout(context.Background(), evaluateTimeout)
+	defer cancel()
+
+	unlocked, err := evaluate_command.New().Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range unlocked {
+		log.Printf("achievement %d unlocked\n", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package achievements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/currency"
+	"financo/models/account"
+	"financo/models/achievement"
+	"financo/models/achievement/debt_paid_off"
+	"financo/models/achievement/emergency_fund"
+	"financo/models/achievement/net_worth_milestone"
+	"financo/models/achievement/savings_goal"
+	"financo/models/achievement/spending_streak"
+	"fmt"
+	"time"
+)
+
+// expensesMonths is the number of complete months averaged to know the
+// monthly expenses an emergency fund must cover.
+const expensesMonths = 12
+
+// definitions holds the built-in kinds, savings goals are reached by their
+// funding so they have no evaluator.
+var definitions = map[achievement.Kind]definition{
+	achievement.SavingsGoal: define(Schema[savings_goal.Settings]{}),
+	achievement.DebtPaidOff: define(Schema[debt_paid_off.Settings]{
+		Validate: validateDebtPaidOff,
+		Evaluate: evaluateDebtPaidOff,
+	}),
+	achievement.NetWorthMilestone: define(Schema[net_worth_milestone.Settings]{
+		Validate: validateNetWorthMilestone,
+		Evaluate: evaluateNetWorthMilestone,
+	}),
+	achievement.SpendingStreak: define(Schema[spending_streak.Settings]{
+		Validate: validateSpendingStreak,
+		Evaluate: evaluateSpendingStreak,
+	}),
+	achievement.EmergencyFund: define(Schema[emergency_fund.Settings]{
+		Validate: validateEmergencyFund,
+		Evaluate: evaluateEmergencyFund,
+	}),
+}
+
+func validateDebtPaidOff(s debt_paid_off.Settings) error {
+	if s.AccountID <= 0 {
+		return fmt.Errorf("%w: account is required", ErrInvalid)
+	}
+
+	return nil
+}
+
+// evaluateDebtPaidOff reaches the achievement once a debt account that
+// carried debt is back to a zero balance.
+func evaluateDebtPaidOff(ctx context.Context, tx *sql.Tx, s debt_paid_off.Settings, now time.Time) (bool, error) {
+	movements := make([]int64, 0, 32)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT
+				CASE
+					WHEN tr.target_id = acc.id THEN tr.target_amount
+					WHEN tr.source_id = acc.id THEN - tr.source_amount
+					ELSE 0
+				END
+			FROM transactions tr
+				INNER JOIN accounts acc ON acc.id = tr.target_id OR acc.id = tr.source_id
+			WHERE
+				acc.id = $1
+				AND acc.kind = ANY ($2)
+				AND tr.deleted_at IS NULL
+				AND acc.deleted_at IS NULL
+				AND (tr.executed_at IS NULL OR tr.executed_at <= $3)
+				AND tr.issued_at <= $3
+			ORDER BY COALESCE(tr.executed_at, tr.issued_at), tr.id
+		`,
+		s.AccountID,
+		[]account.Kind{account.DebtLoan, account.DebtPersonal, account.DebtCredit},
+		now,
+	)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to calculate debt balance"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var amount int64
+
+		err = rows.Scan(&amount)
+		if err != nil {
+			return false, errors.Join(errors.New("failed to calculate debt balance"), err)
+		}
+
+		movements = append(movements, amount)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return false, errors.Join(errors.New("failed to calculate debt balance"), err)
+	}
+
+	return paidOff(movements), nil
+}
+
+// paidOff reports whether the balance running through the movements went
+// below zero and ended back at zero or above.
+func paidOff(movements []int64) bool {
+	var (
+		balance int64
+		owed    bool
+	)
+
+	for _, amount := range movements {
+		balance += amount
+
+		if balance < 0 {
+			owed = true
+		}
+	}
+
+	return owed && balance >= 0
+}
+
+func validateNetWorthMilestone(s net_worth_milestone.Settings) error {
+	if s.Target <= 0 {
+		return fmt.Errorf("%w: target must be greater than zero", ErrInvalid)
+	}
+
+	return validateCurrency(s.Currency)
+}
+
+func evaluateNetWorthMilestone(ctx context.Context, tx *sql.Tx, s net_worth_milestone.Settings, now time.Time) (bool, error) {
+	worth, err := balance(
+		ctx,
+		tx,
+		[]account.Kind{
+			account.CapitalNormal,
+			account.CapitalSavings,
+			account.DebtLoan,
+			account.DebtPersonal,
+			account.DebtCredit,
+		},
+		s.Currency,
+		now,
+	)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to calculate net worth"), err)
+	}
+
+	return worth >= s.Target, nil
+}
+
+func validateSpendingStreak(s spending_streak.Settings) error {
+	if s.Months <= 0 {
+		return fmt.Errorf("%w: months must be greater than zero", ErrInvalid)
+	}
+
+	if s.Budget < 0 {
+		return fmt.Errorf("%w: budget can't be negative", ErrInvalid)
+	}
+
+	return validateCurrency(s.Currency)
+}
+
+// evaluateSpendingStreak reaches the achievement once every one of the last
+// complete months stayed under budget, the streak only counts months the
+// transactions history covers.
+func evaluateSpendingStreak(ctx context.Context, tx *sql.Tx, s spending_streak.Settings, now time.Time) (bool, error) {
+	var (
+		until = monthStart(now)
+		from  = until.AddDate(0, -s.Months, 0)
+	)
+
+	covered, err := historySince(ctx, tx, from)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to find transactions history"), err)
+	}
+
+	if !covered {
+		return false, nil
+	}
+
+	months, err := expenses(ctx, tx, s.Currency, from, until)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to calculate expenses"), err)
+	}
+
+	for _, spent := range months {
+		if spent > s.Budget {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func validateEmergencyFund(s emergency_fund.Settings) error {
+	if s.Months <= 0 {
+		return fmt.Errorf("%w: months must be greater than zero", ErrInvalid)
+	}
+
+	return validateCurrency(s.Currency)
+}
+
+// evaluateEmergencyFund reaches the achievement once the savings cover the
+// average monthly expenses of the last complete months, it can't be reached
+// without expenses to cover.
+func evaluateEmergencyFund(ctx context.Context, tx *sql.Tx, s emergency_fund.Settings, now time.Time) (bool, error) {
+	var (
+		until = monthStart(now)
+		total int64
+	)
+
+	months, err := expenses(ctx, tx, s.Currency, until.AddDate(0, -expensesMonths, 0), until)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to calculate expenses"), err)
+	}
+
+	for _, spent := range months {
+		total += spent
+	}
+
+	monthly := total / expensesMonths
+	if monthly <= 0 {
+		return false, nil
+	}
+
+	savings, err := balance(ctx, tx, []account.Kind{account.CapitalSavings}, s.Currency, now)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to calculate savings"), err)
+	}
+
+	return savings >= monthly*int64(s.Months), nil
+}
+
+func validateCurrency(c currency.Type) error {
+	if _, ok := c.Entry(); !ok {
+		return fmt.Errorf("%w: currency \"%s\" is not supported", ErrInvalid, c)
+	}
+
+	return nil
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,30 @@
+package achievements
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaidOff(t *testing.T) {
+	tests := []struct {
+		name      string
+		movements []int64
+		want      bool
+	}{
+		{name: "no movements", movements: []int64{}},
+		{name: "never owed", movements: []int64{1000, -1000}},
+		{name: "still owed", movements: []int64{-1000, 600}},
+		{name: "paid off", movements: []int64{-1000, 600, 400}, want: true},
+		{name: "overpaid", movements: []int64{-1000, 1200}, want: true},
+		{name: "owed again", movements: []int64{-1000, 1000, -200}},
+		{name: "owed again and paid off", movements: []int64{-1000, 1000, -200, 200}, want: true},
+		{name: "credit before the debt", movements: []int64{500, -1000, 500}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, paidOff(tt.movements))
+		})
+	}
+}
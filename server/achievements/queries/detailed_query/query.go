@@ -0,0 +1,84 @@
+package detailed_query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/models/achievement"
+	"financo/server/achievements"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+func New(id int64) queries.Query[achievement.Record[json.RawMessage]] {
+	return &query{
+		id: id,
+	}
+}
+
+// Find returns the achievement if its kind has an evaluator, other kinds are
+// found through their own endpoints.
+func (q *query) Find(ctx context.Context) (achievement.Record[json.RawMessage], error) {
+	var (
+		postgres = postgresql_database.New()
+
+		record   achievement.Record[json.RawMessage]
+		settings []byte
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return record, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				kind,
+				name,
+				description,
+				settings,
+				achieved_at,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM achievements
+			WHERE kind = ANY ($1)
+				AND id = $2
+				AND deleted_at IS NULL
+		`,
+		achievements.Evaluated(),
+		q.id,
+	).Scan(
+		&record.ID,
+		&record.Kind,
+		&record.Name,
+		&record.Description,
+		&settings,
+		&record.AchievedAt,
+		&record.ArchivedAt,
+		&record.DeletedAt,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return record, achievements.ErrNotFound
+	}
+
+	if err != nil {
+		return record, errors.Join(errors.New("failed to execute and scan query"), err)
+	}
+
+	record.Settings = settings
+
+	return record, nil
+}
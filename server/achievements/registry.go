@@ -0,0 +1,157 @@
+package achievements
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financo/models/achievement"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrInvalid is returned when an achievement can't be persisted.
+	ErrInvalid = errors.New("invalid achievement")
+	// ErrUnknownKind is returned for kinds that aren't defined.
+	ErrUnknownKind = errors.New("unknown achievement kind")
+	// ErrNotFound is returned when the achievement doesn't exist, was
+	// deleted or is managed through the endpoints of its kind.
+	ErrNotFound = errors.New("achievement not found")
+)
+
+// Schema describes the settings S of an achievement kind.
+//
+// Validate checks the settings of a new achievement and returns an error
+// describing the first invalid field. Evaluate reports whether a pending
+// achievement is reached as of now, it runs inside the transaction unlocking
+// the achievement. Kinds reached by other means, like savings goals, have no
+// Evaluate.
+type Schema[S any] struct {
+	Validate func(settings S) error
+	Evaluate func(ctx context.Context, tx *sql.Tx, settings S, now time.Time) (bool, error)
+}
+
+type definition struct {
+	validate func(raw json.RawMessage) error
+	evaluate func(ctx context.Context, tx *sql.Tx, raw json.RawMessage, now time.Time) (bool, error)
+}
+
+func define[S any](schema Schema[S]) definition {
+	d := definition{
+		validate: func(raw json.RawMessage) error {
+			var settings S
+
+			// Unknown fields are rejected so typos in new achievements don't
+			// silently fall back to zero values.
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.DisallowUnknownFields()
+
+			err := dec.Decode(&settings)
+			if err != nil {
+				return fmt.Errorf("%w: settings can't be decoded: %s", ErrInvalid, err)
+			}
+
+			if schema.Validate == nil {
+				return nil
+			}
+
+			return schema.Validate(settings)
+		},
+	}
+
+	if schema.Evaluate != nil {
+		d.evaluate = func(ctx context.Context, tx *sql.Tx, raw json.RawMessage, now time.Time) (bool, error) {
+			var settings S
+
+			err := json.Unmarshal(raw, &settings)
+			if err != nil {
+				return false, errors.Join(errors.New("failed to decode settings"), err)
+			}
+
+			return schema.Evaluate(ctx, tx, settings, now)
+		}
+	}
+
+	return d
+}
+
+// Register defines a kind beyond the built-in ones, achievements of the kind
+// can then be stored, validated and evaluated like the built-in ones. It must
+// be called before the evaluator starts.
+func Register[S any](kind achievement.Kind, schema Schema[S]) {
+	definitions[kind] = define(schema)
+	achievement.Register(kind)
+}
+
+// Kinds returns every defined kind sorted by name.
+func Kinds() []achievement.Kind {
+	kinds := make([]achievement.Kind, 0, len(definitions))
+	for kind := range definitions {
+		kinds = append(kinds, kind)
+	}
+
+	slices.Sort(kinds)
+
+	return kinds
+}
+
+// Evaluated returns the defined kinds having an evaluator sorted by name.
+func Evaluated() []achievement.Kind {
+	kinds := make([]achievement.Kind, 0, len(definitions))
+	for kind, d := range definitions {
+		if d.evaluate != nil {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	slices.Sort(kinds)
+
+	return kinds
+}
+
+// Validate checks the settings of a new achievement of the kind.
+//
+// It returns [ErrUnknownKind] if the kind isn't defined, or an error
+// wrapping [ErrInvalid] describing the first invalid field.
+func Validate(kind achievement.Kind, settings json.RawMessage) error {
+	d, ok := definitions[kind]
+	if !ok {
+		return fmt.Errorf("%w: %w \"%s\"", ErrInvalid, ErrUnknownKind, kind)
+	}
+
+	return d.validate(settings)
+}
+
+// ValidateCreate checks a new achievement created through the achievements
+// endpoints, only kinds having an evaluator can be. Savings goals are reached
+// by their funding and managed through their own endpoints.
+//
+// It returns an error wrapping [ErrInvalid] describing why the achievement
+// can't be created.
+func ValidateCreate(kind achievement.Kind, settings json.RawMessage) error {
+	if !slices.Contains(Evaluated(), kind) {
+		return fmt.Errorf("%w: achievements of kind \"%s\" can't be created", ErrInvalid, kind)
+	}
+
+	return Validate(kind, settings)
+}
+
+// Evaluate reports whether the pending achievement is reached as of now,
+// achievements of kinds without evaluator never are.
+//
+// It returns [ErrUnknownKind] if the kind isn't defined.
+func Evaluate(ctx context.Context, tx *sql.Tx, kind achievement.Kind, settings json.RawMessage, now time.Time) (bool, error) {
+	d, ok := definitions[kind]
+	if !ok {
+		return false, fmt.Errorf("%w \"%s\"", ErrUnknownKind, kind)
+	}
+
+	if d.evaluate == nil {
+		return false, nil
+	}
+
+	return d.evaluate(ctx, tx, settings, now)
+}
@@ -0,0 +1,129 @@
+package achievements
+
+import (
+	"encoding/json"
+	"financo/models/achievement"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefineValidate(t *testing.T) {
+	type settings struct {
+		Target int64 `json:"target"`
+	}
+
+	d := define(Schema[settings]{
+		Validate: func(s settings) error {
+			if s.Target <= 0 {
+				return fmt.Errorf("%w: target must be greater than zero", ErrInvalid)
+			}
+
+			return nil
+		},
+	})
+
+	tests := []struct {
+		name  string
+		raw   string
+		valid bool
+	}{
+		{name: "valid", raw: `{"target": 10}`, valid: true},
+		{name: "unknown field", raw: `{"target": 10, "traget": 10}`},
+		{name: "wrong type", raw: `{"target": "10"}`},
+		{name: "not json", raw: `target`},
+		{name: "schema rule", raw: `{"target": 0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := d.validate(json.RawMessage(tt.raw))
+
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+
+	assert.Nil(t, d.evaluate)
+}
+
+func TestEvaluated(t *testing.T) {
+	evaluated := Evaluated()
+
+	assert.NotContains(t, evaluated, achievement.SavingsGoal)
+	assert.Contains(t, Kinds(), achievement.SavingsGoal)
+	assert.IsIncreasing(t, evaluated)
+
+	for _, kind := range Kinds() {
+		if kind != achievement.SavingsGoal {
+			assert.Contains(t, evaluated, kind)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := Validate("unknown", json.RawMessage(`{}`))
+
+	assert.ErrorIs(t, err, ErrInvalid)
+	assert.ErrorIs(t, err, ErrUnknownKind)
+}
+
+func TestValidateCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     achievement.Kind
+		settings string
+		valid    bool
+	}{
+		{name: "evaluated kind", kind: achievement.DebtPaidOff, settings: `{"accountId": 1}`, valid: true},
+		{name: "invalid settings", kind: achievement.DebtPaidOff, settings: `{"accountId": 0}`},
+		{name: "unknown settings field", kind: achievement.DebtPaidOff, settings: `{"accountId": 1, "account": 1}`},
+		{name: "savings goal", kind: achievement.SavingsGoal, settings: `{}`},
+		{name: "unknown kind", kind: "unknown", settings: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreate(tt.kind, json.RawMessage(tt.settings))
+
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	type settings struct {
+		Days int64 `json:"days"`
+	}
+
+	kind := achievement.Kind("no_spend_days")
+
+	_, err := kind.Value()
+	assert.Error(t, err)
+
+	Register(kind, Schema[settings]{})
+	defer delete(definitions, kind)
+
+	assert.Contains(t, Kinds(), kind)
+	assert.NotContains(t, Evaluated(), kind)
+	assert.NoError(t, Validate(kind, json.RawMessage(`{"days": 3}`)))
+
+	for _, kind := range Kinds() {
+		value, err := kind.Value()
+		assert.NoError(t, err)
+
+		var scanned achievement.Kind
+		assert.NoError(t, scanned.Scan(value))
+		assert.Equal(t, kind, scanned)
+	}
+}
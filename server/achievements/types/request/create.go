@@ -0,0 +1,16 @@
+package request
+
+import (
+	"encoding/json"
+	"financo/lib/nullable"
+	"financo/models/achievement"
+)
+
+// Create adds an achievement of a kind having an evaluator, Settings follows
+// the schema defined for the kind.
+type Create struct {
+	Kind        achievement.Kind      `json:"kind"`
+	Name        string                `json:"name"`
+	Description nullable.Type[string] `json:"description"`
+	Settings    json.RawMessage       `json:"settings"`
+}
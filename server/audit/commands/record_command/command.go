@@ -0,0 +1,68 @@
+package record_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/audit_entry"
+	"financo/services/postgresql_database"
+)
+
+type command struct {
+	record audit_entry.Record
+}
+
+// New returns a command inserting the audit entry. An entry of an outbox
+// message already recorded isn't inserted again, the command then returns
+// the entry without ID.
+func New(record audit_entry.Record) commands.Command[audit_entry.Record] {
+	return &command{
+		record: record,
+	}
+}
+
+func (c *command) Run(ctx context.Context) (audit_entry.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+		record   = c.record
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return record, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO audit_entries (
+				entity,
+				record_id,
+				action,
+				changes,
+				request_id,
+				outbox_id,
+				occurred_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (outbox_id) DO NOTHING
+			RETURNING id, created_at
+		`,
+		record.Entity,
+		record.RecordID,
+		record.Action,
+		record.Changes,
+		record.RequestID,
+		record.OutboxID,
+		record.OccurredAt,
+	).Scan(&record.ID, &record.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return record, nil
+	}
+	if err != nil {
+		return record, errors.Join(errors.New("failed to insert audit entry"), err)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,75 @@
+package history_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/models/audit_entry"
+	"financo/server/audit/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	entity   audit_entry.Entity
+	recordID int64
+}
+
+func New(entity audit_entry.Entity, recordID int64) queries.Query[[]response.Entry] {
+	return &query{
+		entity:   entity,
+		recordID: recordID,
+	}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Entry, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Entry, 0, 10)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				action,
+				changes,
+				request_id,
+				occurred_at
+			FROM audit_entries
+			WHERE entity = $1 AND record_id = $2
+			ORDER BY occurred_at, id
+		`,
+		q.entity,
+		q.recordID,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row response.Entry
+
+		err = rows.Scan(
+			&row.ID,
+			&row.Action,
+			&row.Changes,
+			&row.RequestID,
+			&row.OccurredAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, nil
+}
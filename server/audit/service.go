@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"context"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/core/scope_accounts/infrastructure/broker_handler"
+	"financo/lib/diff"
+	"financo/lib/message_bus"
+	"financo/lib/nullable"
+	"financo/models/audit_entry"
+	"financo/server/audit/commands/record_command"
+	"financo/server/transactions/brokers"
+	"financo/server/transactions/types/message"
+	"fmt"
+	"time"
+)
+
+const (
+	recordTimeout = 5 * time.Second
+	// consumerWorkers record messages concurrently, the messages of an
+	// account are still recorded in the order they were published.
+	consumerWorkers = 4
+)
+
+// consumerRetry keeps retrying a message for about a minute, so it outlives a
+// database restart before ending up as a dead letter.
+var consumerRetry = message_bus.RetryPolicy{
+	MaxAttempts:    6,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     3,
+}
+
+// Subscribe registers the audit log consumers on the accounts and
+// transactions brokers, every create, update and delete published from then
+// on is persisted as an audit entry.
+func Subscribe(accounts broker_handler.BrokerHandler, transactions brokers.Broker) error {
+	err := accounts.CreatedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Created](func(msg messages.Created) error {
+			return record(audit_entry.Account, msg.Record.ID, audit_entry.Created, nil, msg.Record, msg.RequestID, msg.OutboxID, msg.Record.CreatedAt)
+		}),
+		message_bus.WithName("audit_account_created"),
+		message_bus.WithKey(messages.Created.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = accounts.UpdatedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Updated](func(msg messages.Updated) error {
+			return record(audit_entry.Account, msg.Current.ID, audit_entry.Updated, msg.Previous, msg.Current, msg.RequestID, msg.OutboxID, msg.Current.UpdatedAt)
+		}),
+		message_bus.WithName("audit_account_updated"),
+		message_bus.WithKey(messages.Updated.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = accounts.DeletedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Deleted](func(msg messages.Deleted) error {
+			// The account message only carries the deleted record, so the
+			// previous state is rebuilt by clearing the deletion timestamp.
+			previous := msg.Record
+			previous.DeletedAt = nullable.Type[time.Time]{}
+
+			return record(audit_entry.Account, msg.Record.ID, audit_entry.Deleted, previous, msg.Record, msg.RequestID, msg.OutboxID, msg.Record.UpdatedAt)
+		}),
+		message_bus.WithName("audit_account_deleted"),
+		message_bus.WithKey(messages.Deleted.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToCreated(
+		message_bus.ConsumerFunc[message.Created](func(msg message.Created) error {
+			return record(audit_entry.Transaction, msg.Record.ID, audit_entry.Created, nil, msg.Record, msg.RequestID, msg.OutboxID, msg.Record.CreatedAt)
+		}),
+		message_bus.WithName("audit_transaction_created"),
+		message_bus.WithKey(message.Created.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToUpdated(
+		message_bus.ConsumerFunc[message.Updated](func(msg message.Updated) error {
+			return record(audit_entry.Transaction, msg.ID, audit_entry.Updated, msg.PreviousState, msg.CurrentState, msg.RequestID, msg.OutboxID, msg.CurrentState.UpdatedAt)
+		}),
+		message_bus.WithName("audit_transaction_updated"),
+		message_bus.WithKey(message.Updated.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	return transactions.SubscribeToDeleted(
+		message_bus.ConsumerFunc[message.Deleted](func(msg message.Deleted) error {
+			return record(audit_entry.Transaction, msg.ID, audit_entry.Deleted, msg.PreviousState, msg.CurrentState, msg.RequestID, msg.OutboxID, msg.CurrentState.UpdatedAt)
+		}),
+		message_bus.WithName("audit_transaction_deleted"),
+		message_bus.WithKey(message.Deleted.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+}
+
+func record(
+	entity audit_entry.Entity,
+	id int64,
+	action audit_entry.Action,
+	previous, current any,
+	requestID string,
+	outboxID int64,
+	occurredAt time.Time,
+) error {
+	changes, err := diff.Compute(previous, current)
+	if err != nil {
+		return fmt.Errorf("audit: failed to diff %s %d: %w", entity, id, err)
+	}
+
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+
+	entry := audit_entry.Record{
+		Entity:     entity,
+		RecordID:   id,
+		Action:     action,
+		Changes:    changes,
+		OccurredAt: occurredAt,
+	}
+
+	if requestID != "" {
+		entry.RequestID = nullable.New(requestID)
+	}
+
+	// The relay delivers at least once, the outbox id makes recording a
+	// redelivered message a no-op.
+	if outboxID != 0 {
+		entry.OutboxID = nullable.New(outboxID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+	defer cancel()
+
+	_, err = record_command.New(entry).Run(ctx)
+	if err != nil {
+		return fmt.Errorf("audit: failed to record %s %s %d: %w", action, entity, id, err)
+	}
+
+	return nil
+}
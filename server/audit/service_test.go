@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+	"financo/models/audit_entry"
+	"financo/models/transaction"
+	"financo/services/postgresql_database"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecordRedeliveredMessage needs a migrated database, it is skipped
+// without DB_HOST.
+func TestRecordRedeliveredMessage(t *testing.T) {
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	var (
+		outboxID = time.Now().UnixNano()
+		current  = transaction.Record{ID: 1, SourceAmount: 10, TargetAmount: 10}
+		count    int64
+	)
+
+	for range 2 {
+		err := record(audit_entry.Transaction, current.ID, audit_entry.Created, nil, current, "", outboxID, time.Now().UTC())
+		assert.NoError(t, err)
+	}
+
+	conn, err := postgresql_database.New().Conn(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		context.Background(),
+		"SELECT COUNT(*) FROM audit_entries WHERE outbox_id = $1",
+		outboxID,
+	).Scan(&count)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
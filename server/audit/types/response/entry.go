@@ -0,0 +1,16 @@
+package response
+
+import (
+	"financo/lib/diff"
+	"financo/lib/nullable"
+	"financo/models/audit_entry"
+	"time"
+)
+
+type Entry struct {
+	ID         int64                 `json:"id"`
+	Action     audit_entry.Action    `json:"action"`
+	Changes    diff.Changes          `json:"changes"`
+	RequestID  nullable.Type[string] `json:"requestId"`
+	OccurredAt time.Time             `json:"occurredAt"`
+}
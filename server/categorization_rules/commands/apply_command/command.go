@@ -0,0 +1,103 @@
+package apply_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/categorize"
+	"financo/server/categorization_rules/queries/changes_query"
+	"financo/server/categorization_rules/queries/detailed_query"
+	"financo/server/transactions/commands/tag_command"
+	"financo/server/transactions/commands/update_command"
+	transactions_request "financo/server/transactions/types/request"
+	transactions_response "financo/server/transactions/types/response"
+	"fmt"
+	"slices"
+)
+
+type command struct {
+	id int64
+}
+
+// New returns a command re-categorizing the existing transactions matching
+// the categorization rule id. Transactions are updated one at a time through
+// the transactions update command, so every change publishes its own Updated
+// message.
+func New(id int64) commands.Command[[]transactions_response.Detailed] {
+	return &command{
+		id: id,
+	}
+}
+
+func (c *command) Run(ctx context.Context) ([]transactions_response.Detailed, error) {
+	res := make([]transactions_response.Detailed, 0, 20)
+
+	rule, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find categorization rule"), err)
+	}
+
+	changes, err := changes_query.New(categorize.Rule{
+		ID:        rule.ID,
+		Condition: rule.Condition,
+		Action:    rule.Action,
+	}).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find categorization rule changes"), err)
+	}
+
+	for _, change := range changes {
+		var (
+			current = change.Transaction
+			updated = current
+		)
+
+		notesChanged := change.Notes.Valid != current.Notes.Valid || change.Notes.Val != current.Notes.Val
+
+		if change.TargetID != current.Target.ID || notesChanged {
+			updated, err = update_command.New(transactions_request.Update{
+				ID:           current.ID,
+				IssuedAt:     current.IssuedAt,
+				ExecutedAt:   current.ExecutedAt,
+				Notes:        change.Notes,
+				SourceID:     current.Source.ID,
+				TargetID:     change.TargetID,
+				SourceAmount: current.SourceAmount.Amount(),
+				TargetAmount: current.TargetAmount.Amount(),
+			}).Run(ctx)
+			if err != nil {
+				return res, errors.Join(fmt.Errorf("failed to update transaction %d", current.ID), err)
+			}
+		}
+
+		if tagsChanged(current.Tags, change.Tags) {
+			updated, err = tag_command.New(transactions_request.Tag{
+				ID:   current.ID,
+				Tags: change.Tags,
+			}).Run(ctx)
+			if err != nil {
+				return res, errors.Join(fmt.Errorf("failed to tag transaction %d", current.ID), err)
+			}
+		}
+
+		res = append(res, updated)
+	}
+
+	return res, nil
+}
+
+// tagsChanged reports whether the tag IDs differ from the current tags of the
+// transaction, regardless of their order.
+func tagsChanged(current []transactions_response.Tag, tags []int64) bool {
+	ids := make([]int64, 0, len(current))
+	for _, tag := range current {
+		ids = append(ids, tag.ID)
+	}
+
+	tags = slices.Clone(tags)
+
+	slices.Sort(ids)
+	slices.Sort(tags)
+
+	return !slices.Equal(slices.Compact(ids), slices.Compact(tags))
+}
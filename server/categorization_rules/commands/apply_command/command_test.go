@@ -0,0 +1,31 @@
+package apply_command
+
+import (
+	transactions_response "financo/server/transactions/types/response"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsChanged(t *testing.T) {
+	current := []transactions_response.Tag{{ID: 3}, {ID: 1}}
+
+	tests := []struct {
+		name    string
+		tags    []int64
+		changed bool
+	}{
+		{name: "same tags", tags: []int64{1, 3}},
+		{name: "same tags in another order", tags: []int64{3, 1}},
+		{name: "tag added", tags: []int64{1, 3, 4}, changed: true},
+		{name: "tag removed", tags: []int64{1}, changed: true},
+		{name: "tag replaced", tags: []int64{1, 4}, changed: true},
+		{name: "no tags", tags: []int64{}, changed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.changed, tagsChanged(current, tt.tags))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package create_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/categorization_rules"
+	"financo/server/categorization_rules/queries/detailed_query"
+	"financo/server/categorization_rules/types/request"
+	"financo/server/categorization_rules/types/response"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		id  int64
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = categorization_rules.Validate(ctx, conn, c.req.Name, c.req.Condition, c.req.Action)
+	if err != nil {
+		return res, errors.Join(errors.New("invalid categorization rule"), err)
+	}
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO categorization_rules(name, position, condition, action, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`,
+		strings.TrimSpace(c.req.Name),
+		c.req.Position,
+		c.req.Condition,
+		c.req.Action,
+		c.timestamp,
+		c.timestamp,
+	).Scan(&id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted categorization rule"), err)
+	}
+
+	return res, nil
+}
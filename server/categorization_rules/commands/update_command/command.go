@@ -0,0 +1,75 @@
+package update_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/categorization_rules"
+	"financo/server/categorization_rules/queries/detailed_query"
+	"financo/server/categorization_rules/types/request"
+	"financo/server/categorization_rules/types/response"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Update
+	timestamp time.Time
+}
+
+func New(req request.Update) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = categorization_rules.Validate(ctx, conn, c.req.Name, c.req.Condition, c.req.Action)
+	if err != nil {
+		return res, errors.Join(errors.New("invalid categorization rule"), err)
+	}
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			UPDATE categorization_rules SET
+				name = $1,
+				position = $2,
+				condition = $3,
+				action = $4,
+				updated_at = $5
+			WHERE deleted_at IS NULL AND id = $6
+			RETURNING id
+		`,
+		strings.TrimSpace(c.req.Name),
+		c.req.Position,
+		c.req.Condition,
+		c.req.Action,
+		c.timestamp,
+		c.req.ID,
+	).Scan(&c.req.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve response"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,167 @@
+package changes_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/categorize"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/server/categorization_rules/types/response"
+	"financo/server/transactions/queries/detailed_query"
+	"financo/services/postgresql_database"
+	"fmt"
+)
+
+type query struct {
+	rule categorize.Rule
+}
+
+// New returns the existing transactions rule would change and how. The legs
+// of split transactions are left out.
+func New(rule categorize.Rule) queries.Query[[]response.Change] {
+	return &query{
+		rule: rule,
+	}
+}
+
+type candidate struct {
+	id         int64
+	sourceID   int64
+	targetID   int64
+	targetKind account.Kind
+	amount     int64
+	notes      nullable.Type[string]
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Change, error) {
+	var (
+		query = `
+			SELECT
+				tr.id,
+				tr.source_id,
+				tr.target_id,
+				trg.kind,
+				tr.source_amount,
+				tr.notes
+			FROM transactions tr
+				INNER JOIN accounts trg ON trg.id = tr.target_id
+			WHERE tr.deleted_at IS NULL
+				AND tr.split_id IS NULL
+		`
+		condition = q.rule.Condition
+		res       = make([]response.Change, 0, 20)
+		matched   = make([]candidate, 0, 20)
+		filters   = make([]any, 0, 3)
+		filter    = 1
+		postgres  = postgresql_database.New()
+	)
+
+	engine, err := categorize.NewEngine([]categorize.Rule{q.rule})
+	if err != nil {
+		return res, err
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	if condition.SourceID.Valid {
+		filters = append(filters, condition.SourceID.Val)
+		query += fmt.Sprintf(" AND tr.source_id = $%d", filter)
+		filter++
+	}
+
+	if condition.MinAmount.Valid {
+		filters = append(filters, condition.MinAmount.Val)
+		query += fmt.Sprintf(" AND tr.source_amount >= $%d", filter)
+		filter++
+	}
+
+	if condition.MaxAmount.Valid {
+		filters = append(filters, condition.MaxAmount.Val)
+		query += fmt.Sprintf(" AND tr.source_amount <= $%d", filter)
+	}
+
+	rows, err := conn.QueryContext(ctx, query+" ORDER BY tr.id", filters...)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row candidate
+
+		err = rows.Scan(&row.id, &row.sourceID, &row.targetID, &row.targetKind, &row.amount, &row.notes)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		_, ok := engine.Match(categorize.Candidate{SourceID: row.sourceID, Amount: row.amount, Notes: row.notes.Val})
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+
+	for _, row := range matched {
+		change, changed, err := q.change(ctx, row)
+		if err != nil {
+			return res, err
+		}
+
+		if changed {
+			res = append(res, change)
+		}
+	}
+
+	return res, nil
+}
+
+func (q *query) change(ctx context.Context, row candidate) (response.Change, bool, error) {
+	var (
+		action = q.rule.Action
+		change = response.Change{
+			TargetID: row.targetID,
+			Notes:    row.notes,
+		}
+		changed = false
+	)
+
+	if action.TargetID.Valid && action.TargetID.Val != row.sourceID &&
+		account.IsExternal(row.targetKind) && action.TargetID.Val != row.targetID {
+		change.TargetID = action.TargetID.Val
+		changed = true
+	}
+
+	if action.Notes.Valid && (!row.notes.Valid || row.notes.Val != action.Notes.Val) {
+		change.Notes = nullable.New(action.Notes.Val)
+		changed = true
+	}
+
+	detailed, err := detailed_query.New(row.id).Find(ctx)
+	if err != nil {
+		return change, false, errors.Join(fmt.Errorf("failed to find transaction %d", row.id), err)
+	}
+
+	change.Transaction = detailed
+	change.Tags = make([]int64, 0, len(detailed.Tags)+len(action.Tags))
+
+	linked := make(map[int64]bool, len(detailed.Tags))
+
+	for _, tag := range detailed.Tags {
+		linked[tag.ID] = true
+		change.Tags = append(change.Tags, tag.ID)
+	}
+
+	for _, tag := range action.Tags {
+		if !linked[tag] {
+			linked[tag] = true
+			change.Tags = append(change.Tags, tag)
+			changed = true
+		}
+	}
+
+	return change, changed, nil
+}
@@ -0,0 +1,36 @@
+package engine_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/categorize"
+	"financo/server/categorization_rules/queries/list_query"
+)
+
+type query struct{}
+
+// New returns a [categorize.Engine] matching every categorization rule in
+// order.
+func New() queries.Query[categorize.Engine] {
+	return &query{}
+}
+
+func (q *query) Find(ctx context.Context) (categorize.Engine, error) {
+	found, err := list_query.New().Find(ctx)
+	if err != nil {
+		return categorize.Engine{}, errors.Join(errors.New("failed to find categorization rules"), err)
+	}
+
+	rules := make([]categorize.Rule, 0, len(found))
+
+	for _, rule := range found {
+		rules = append(rules, categorize.Rule{
+			ID:        rule.ID,
+			Condition: rule.Condition,
+			Action:    rule.Action,
+		})
+	}
+
+	return categorize.NewEngine(rules)
+}
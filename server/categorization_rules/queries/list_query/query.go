@@ -0,0 +1,71 @@
+package list_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/categorization_rules/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct{}
+
+// New returns the categorization rules in the order they are matched.
+func New() queries.Query[[]response.Detailed] {
+	return &query{}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Detailed, 0, 20)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				name,
+				position,
+				condition,
+				action,
+				created_at,
+				updated_at
+			FROM categorization_rules
+			WHERE deleted_at IS NULL
+			ORDER BY position, id
+		`,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row response.Detailed
+
+		err = rows.Scan(
+			&row.ID,
+			&row.Name,
+			&row.Position,
+			&row.Condition,
+			&row.Action,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,12 @@
+package request
+
+import (
+	"financo/lib/categorize"
+)
+
+type Create struct {
+	Name      string               `json:"name"`
+	Position  int                  `json:"position"`
+	Condition categorize.Condition `json:"condition"`
+	Action    categorize.Action    `json:"action"`
+}
@@ -0,0 +1,13 @@
+package request
+
+import (
+	"financo/lib/categorize"
+)
+
+type Update struct {
+	ID        int64                `json:"id"`
+	Name      string               `json:"name"`
+	Position  int                  `json:"position"`
+	Condition categorize.Condition `json:"condition"`
+	Action    categorize.Action    `json:"action"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import (
+	"financo/lib/nullable"
+	transactions "financo/server/transactions/types/response"
+)
+
+// Change describes how a rule would re-categorize an existing transaction,
+// Transaction is its current state.
+type Change struct {
+	Transaction transactions.Detailed `json:"transaction"`
+	TargetID    int64                 `json:"targetID"`
+	Notes       nullable.Type[string] `json:"notes"`
+	Tags        []int64               `json:"tags"`
+}
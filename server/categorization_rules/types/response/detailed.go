@@ -0,0 +1,16 @@
+package response
+
+import (
+	"financo/lib/categorize"
+	"time"
+)
+
+type Detailed struct {
+	ID        int64                `json:"id"`
+	Name      string               `json:"name"`
+	Position  int                  `json:"position"`
+	Condition categorize.Condition `json:"condition"`
+	Action    categorize.Action    `json:"action"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
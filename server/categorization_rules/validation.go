@@ -0,0 +1,77 @@
+package categorization_rules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/categorize"
+	"financo/models/account"
+	"fmt"
+	"strings"
+)
+
+// Validate checks that a categorization rule can be persisted, the target of
+// its action must be an external account and its tags must exist.
+//
+// It returns an error describing the first invalid field.
+func Validate(ctx context.Context, conn *sql.Conn, name string, condition categorize.Condition, action categorize.Action) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("categorization rule name is required")
+	}
+
+	if err := condition.Validate(); err != nil {
+		return err
+	}
+
+	if err := action.Validate(); err != nil {
+		return err
+	}
+
+	if len(action.Tags) > 0 {
+		var found int
+
+		err := conn.QueryRowContext(
+			ctx,
+			"SELECT COUNT(*) FROM tags WHERE deleted_at IS NULL AND id = ANY ($1)",
+			action.Tags,
+		).Scan(&found)
+		if err != nil {
+			return errors.Join(errors.New("failed to find categorization rule tags"), err)
+		}
+
+		if found != len(unique(action.Tags)) {
+			return errors.New("categorization rule tags not found")
+		}
+	}
+
+	if !action.TargetID.Valid {
+		return nil
+	}
+
+	var kind account.Kind
+
+	err := conn.QueryRowContext(
+		ctx,
+		"SELECT kind FROM accounts WHERE deleted_at IS NULL AND id = $1",
+		action.TargetID.Val,
+	).Scan(&kind)
+	if err != nil {
+		return errors.Join(fmt.Errorf("categorization rule target %d not found", action.TargetID.Val), err)
+	}
+
+	if !account.IsExternal(kind) {
+		return errors.New("categorization rule target must be an external account")
+	}
+
+	return nil
+}
+
+func unique(ids []int64) map[int64]bool {
+	out := make(map[int64]bool, len(ids))
+
+	for _, id := range ids {
+		out[id] = true
+	}
+
+	return out
+}
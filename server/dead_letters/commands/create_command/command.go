@@ -0,0 +1,58 @@
+package create_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/dead_letter"
+	"financo/services/postgresql_database"
+)
+
+type command struct {
+	record dead_letter.Record
+}
+
+func New(record dead_letter.Record) commands.Command[dead_letter.Record] {
+	return &command{
+		record: record,
+	}
+}
+
+func (c *command) Run(ctx context.Context) (dead_letter.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+		record   = c.record
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return record, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO dead_letters (
+				bus,
+				subscription,
+				payload,
+				error,
+				attempts,
+				failed_at
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at
+		`,
+		record.Bus,
+		record.Subscription,
+		[]byte(record.Payload),
+		record.Error,
+		record.Attempts,
+		record.FailedAt,
+	).Scan(&record.ID, &record.CreatedAt)
+	if err != nil {
+		return record, errors.Join(errors.New("failed to insert dead letter"), err)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,43 @@
+package delete_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/dead_letters/queries/detailed_query"
+	"financo/server/dead_letters/types/response"
+	"financo/services/postgresql_database"
+)
+
+type command struct {
+	id int64
+}
+
+// New returns a command that discards a dead letter once it was inspected.
+func New(id int64) commands.Command[response.Detailed] {
+	return &command{
+		id: id,
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var postgres = postgresql_database.New()
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find dead letter"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "DELETE FROM dead_letters WHERE id = $1", c.id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to delete dead letter"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package detailed_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/dead_letters/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+func New(id int64) queries.Query[response.Detailed] {
+	return &query{
+		id: id,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res     response.Detailed
+		payload []byte
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				bus,
+				subscription,
+				payload,
+				error,
+				attempts,
+				failed_at
+			FROM dead_letters
+			WHERE id = $1
+		`,
+		q.id,
+	).Scan(
+		&res.ID,
+		&res.Bus,
+		&res.Subscription,
+		&payload,
+		&res.Error,
+		&res.Attempts,
+		&res.FailedAt,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find dead letter"), err)
+	}
+
+	res.Payload = payload
+
+	return res, nil
+}
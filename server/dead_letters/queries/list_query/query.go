@@ -0,0 +1,73 @@
+package list_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/dead_letters/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct{}
+
+func New() queries.Query[[]response.Detailed] {
+	return &query{}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Detailed, 0, 20)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				bus,
+				subscription,
+				payload,
+				error,
+				attempts,
+				failed_at
+			FROM dead_letters
+			ORDER BY failed_at DESC, id DESC
+		`,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row     response.Detailed
+			payload []byte
+		)
+
+		err = rows.Scan(
+			&row.ID,
+			&row.Bus,
+			&row.Subscription,
+			&payload,
+			&row.Error,
+			&row.Attempts,
+			&row.FailedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		row.Payload = payload
+		res = append(res, row)
+	}
+
+	return res, nil
+}
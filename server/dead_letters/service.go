@@ -0,0 +1,31 @@
+package dead_letters
+
+import (
+	"context"
+	"financo/lib/message_bus"
+	"financo/models/dead_letter"
+	"financo/server/dead_letters/commands/create_command"
+	"time"
+)
+
+const storeTimeout = 5 * time.Second
+
+// NewStore returns a [message_bus.DeadLetterStore] that persists dead letters
+// in the database, so they can be inspected through the admin endpoints.
+func NewStore() message_bus.DeadLetterStore {
+	return message_bus.DeadLetterStoreFunc(func(letter message_bus.DeadLetter) error {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		defer cancel()
+
+		_, err := create_command.New(dead_letter.Record{
+			Bus:          letter.Bus,
+			Subscription: letter.Subscription,
+			Payload:      letter.Payload,
+			Error:        letter.Error,
+			Attempts:     letter.Attempts,
+			FailedAt:     letter.FailedAt,
+		}).Run(ctx)
+
+		return err
+	})
+}
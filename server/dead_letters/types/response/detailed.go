@@ -0,0 +1,16 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Detailed struct {
+	ID           int64           `json:"id"`
+	Bus          string          `json:"bus"`
+	Subscription string          `json:"subscription"`
+	Payload      json.RawMessage `json:"payload"`
+	Error        string          `json:"error"`
+	Attempts     int             `json:"attempts"`
+	FailedAt     time.Time       `json:"failedAt"`
+}
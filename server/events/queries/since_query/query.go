@@ -0,0 +1,82 @@
+package since_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/models/outbox_message"
+	"financo/server/events/types/response"
+	"financo/services/postgresql_database"
+	"log"
+)
+
+type query struct {
+	id    int64
+	limit int
+}
+
+// New returns a query finding up to limit events relayed after the outbox
+// message with the given id, in the order they were written. The relay
+// delivers the messages in that order too, so every message older than id
+// was already sent to the client. Messages still waiting in the outbox are
+// left out, they reach the clients once relayed.
+func New(id int64, limit int) queries.Query[[]response.Event] {
+	return &query{
+		id:    id,
+		limit: limit,
+	}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Event, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Event, 0, q.limit)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT id, topic, payload
+			FROM outbox_messages
+			WHERE id > $1 AND delivered_at IS NOT NULL
+			ORDER BY id
+			LIMIT $2
+		`,
+		q.id,
+		q.limit,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			record  outbox_message.Record
+			payload []byte
+		)
+
+		err = rows.Scan(&record.ID, &record.Topic, &payload)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		record.Payload = payload
+
+		event, err := response.FromOutbox(record)
+		if err != nil {
+			log.Printf("events: skipping outbox message: %s\n", err)
+			continue
+		}
+
+		res = append(res, event)
+	}
+
+	return res, rows.Err()
+}
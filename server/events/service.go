@@ -0,0 +1,142 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/core/scope_accounts/infrastructure/broker_handler"
+	"financo/lib/message_bus"
+	"financo/server/events/types/response"
+	"financo/server/transactions/brokers"
+	"financo/server/transactions/types/message"
+	"sync"
+)
+
+// Stream is a client subscribed to the accounts and transactions buses.
+type Stream struct {
+	name         string
+	accounts     broker_handler.BrokerHandler
+	transactions brokers.Broker
+	events       chan response.Event
+	lagged       chan struct{}
+	lag          sync.Once
+}
+
+// Subscribe subscribes a new [Stream] to every topic of the accounts and
+// transactions brokers, up to buffer events wait for the client.
+//
+// The consumers never wait for the client, so a slow client doesn't hold the
+// buses back. Once its buffer is full the events are dropped and Lagged is
+// closed, the client should reconnect and resume with Last-Event-ID.
+func Subscribe(accounts broker_handler.BrokerHandler, transactions brokers.Broker, buffer int) (*Stream, error) {
+	suffix := make([]byte, 8)
+
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return nil, errors.Join(errors.New("events: failed to name stream"), err)
+	}
+
+	// The name is unique across processes, so every stream gets its own
+	// consumer group when the buses are backed by Redis.
+	s := &Stream{
+		name:         "events_" + hex.EncodeToString(suffix),
+		accounts:     accounts,
+		transactions: transactions,
+		events:       make(chan response.Event, buffer),
+		lagged:       make(chan struct{}),
+	}
+
+	err = errors.Join(
+		accounts.CreatedBroker().Subscribe(
+			message_bus.ConsumerFunc[messages.Created](func(msg messages.Created) error {
+				return s.send(response.FromAccountCreated(msg))
+			}),
+			message_bus.WithName(s.name+"_account_created"),
+		),
+		accounts.UpdatedBroker().Subscribe(
+			message_bus.ConsumerFunc[messages.Updated](func(msg messages.Updated) error {
+				return s.send(response.FromAccountUpdated(msg))
+			}),
+			message_bus.WithName(s.name+"_account_updated"),
+		),
+		accounts.DeletedBroker().Subscribe(
+			message_bus.ConsumerFunc[messages.Deleted](func(msg messages.Deleted) error {
+				return s.send(response.FromAccountDeleted(msg))
+			}),
+			message_bus.WithName(s.name+"_account_deleted"),
+		),
+		transactions.SubscribeToCreated(
+			message_bus.ConsumerFunc[message.Created](func(msg message.Created) error {
+				return s.send(response.FromTransactionCreated(msg))
+			}),
+			message_bus.WithName(s.name+"_transaction_created"),
+		),
+		transactions.SubscribeToUpdated(
+			message_bus.ConsumerFunc[message.Updated](func(msg message.Updated) error {
+				return s.send(response.FromTransactionUpdated(msg))
+			}),
+			message_bus.WithName(s.name+"_transaction_updated"),
+		),
+		transactions.SubscribeToDeleted(
+			message_bus.ConsumerFunc[message.Deleted](func(msg message.Deleted) error {
+				return s.send(response.FromTransactionDeleted(msg))
+			}),
+			message_bus.WithName(s.name+"_transaction_deleted"),
+		),
+	)
+	if err != nil {
+		return nil, errors.Join(errors.New("events: failed to subscribe"), err, s.Close())
+	}
+
+	return s, nil
+}
+
+// Events receives the events published since the [Stream] subscribed.
+func (s *Stream) Events() <-chan response.Event {
+	return s.events
+}
+
+// Lagged is closed once events were dropped because the client fell behind.
+func (s *Stream) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+// Close unsubscribes the [Stream] from every topic.
+func (s *Stream) Close() error {
+	var errs []error
+
+	for suffix, unsubscribe := range map[string]func(string) error{
+		"_account_created":     s.accounts.CreatedBroker().Unsubscribe,
+		"_account_updated":     s.accounts.UpdatedBroker().Unsubscribe,
+		"_account_deleted":     s.accounts.DeletedBroker().Unsubscribe,
+		"_transaction_created": s.transactions.UnsubscribeFromCreated,
+		"_transaction_updated": s.transactions.UnsubscribeFromUpdated,
+		"_transaction_deleted": s.transactions.UnsubscribeFromDeleted,
+	} {
+		err := unsubscribe(s.name + suffix)
+		if err != nil && !errors.Is(err, message_bus.ErrUnknownSubscription) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// send never blocks, once an event was dropped every later one is dropped as
+// well, so the client resumes right after the last event it received.
+func (s *Stream) send(event response.Event) error {
+	select {
+	case <-s.lagged:
+		return nil
+	default:
+	}
+
+	select {
+	case s.events <- event:
+	default:
+		s.lag.Do(func() { close(s.lagged) })
+	}
+
+	return nil
+}
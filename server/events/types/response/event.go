@@ -0,0 +1,163 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/models/outbox_message"
+	"financo/server/transactions/types/message"
+	"fmt"
+	"slices"
+)
+
+// Types lists every [Event] type.
+var Types = []string{
+	AccountCreated,
+	AccountUpdated,
+	AccountDeleted,
+	TransactionCreated,
+	TransactionUpdated,
+	TransactionDeleted,
+}
+
+const (
+	AccountCreated     = "account.created"
+	AccountUpdated     = "account.updated"
+	AccountDeleted     = "account.deleted"
+	TransactionCreated = "transaction.created"
+	TransactionUpdated = "transaction.updated"
+	TransactionDeleted = "transaction.deleted"
+)
+
+// Event is a change pushed to the clients, ID is the id of the outbox message
+// it comes from, so a client resumes after it with Last-Event-ID.
+type Event struct {
+	ID   int64   `json:"id"`
+	Type string  `json:"type"`
+	Data Changed `json:"data"`
+}
+
+// Changed identifies the changed record and every account whose balance or
+// children changed with it, so clients only invalidate what is affected.
+type Changed struct {
+	ID         int64   `json:"id"`
+	AccountIDs []int64 `json:"accountIds"`
+}
+
+func FromAccountCreated(msg messages.Created) Event {
+	return Event{
+		ID:   msg.OutboxID,
+		Type: AccountCreated,
+		Data: changed(msg.Record.ID, msg.Record.ID, msg.Record.ParentID.Val),
+	}
+}
+
+func FromAccountUpdated(msg messages.Updated) Event {
+	return Event{
+		ID:   msg.OutboxID,
+		Type: AccountUpdated,
+		Data: changed(msg.Current.ID, msg.Current.ID, msg.Previous.ParentID.Val, msg.Current.ParentID.Val),
+	}
+}
+
+func FromAccountDeleted(msg messages.Deleted) Event {
+	return Event{
+		ID:   msg.OutboxID,
+		Type: AccountDeleted,
+		Data: changed(msg.Record.ID, msg.Record.ID, msg.Record.ParentID.Val),
+	}
+}
+
+func FromTransactionCreated(msg message.Created) Event {
+	return Event{
+		ID:   msg.OutboxID,
+		Type: TransactionCreated,
+		Data: changed(msg.Record.ID, msg.Record.SourceID, msg.Record.TargetID),
+	}
+}
+
+func FromTransactionUpdated(msg message.Updated) Event {
+	return Event{
+		ID:   msg.OutboxID,
+		Type: TransactionUpdated,
+		Data: changed(
+			msg.ID,
+			msg.PreviousState.SourceID,
+			msg.PreviousState.TargetID,
+			msg.CurrentState.SourceID,
+			msg.CurrentState.TargetID,
+		),
+	}
+}
+
+func FromTransactionDeleted(msg message.Deleted) Event {
+	return Event{
+		ID:   msg.OutboxID,
+		Type: TransactionDeleted,
+		Data: changed(msg.ID, msg.PreviousState.SourceID, msg.PreviousState.TargetID),
+	}
+}
+
+// FromOutbox maps an outbox message to its [Event].
+//
+// It returns an error if the topic is unknown or the payload can't be mapped.
+func FromOutbox(record outbox_message.Record) (Event, error) {
+	switch record.Topic {
+	case messages.CreatedTopic:
+		return decode(record, func(msg *messages.Created) Event {
+			msg.OutboxID = record.ID
+			return FromAccountCreated(*msg)
+		})
+	case messages.UpdatedTopic:
+		return decode(record, func(msg *messages.Updated) Event {
+			msg.OutboxID = record.ID
+			return FromAccountUpdated(*msg)
+		})
+	case messages.DeletedTopic:
+		return decode(record, func(msg *messages.Deleted) Event {
+			msg.OutboxID = record.ID
+			return FromAccountDeleted(*msg)
+		})
+	case message.CreatedTopic:
+		return decode(record, func(msg *message.Created) Event {
+			msg.OutboxID = record.ID
+			return FromTransactionCreated(*msg)
+		})
+	case message.UpdatedTopic:
+		return decode(record, func(msg *message.Updated) Event {
+			msg.OutboxID = record.ID
+			return FromTransactionUpdated(*msg)
+		})
+	case message.DeletedTopic:
+		return decode(record, func(msg *message.Deleted) Event {
+			msg.OutboxID = record.ID
+			return FromTransactionDeleted(*msg)
+		})
+	default:
+		return Event{}, fmt.Errorf("events: unknown topic %s", record.Topic)
+	}
+}
+
+func decode[Payload any](record outbox_message.Record, event func(*Payload) Event) (Event, error) {
+	var payload Payload
+
+	err := json.Unmarshal(record.Payload, &payload)
+	if err != nil {
+		return Event{}, errors.Join(fmt.Errorf("events: message %d can't be mapped", record.ID), err)
+	}
+
+	return event(&payload), nil
+}
+
+// changed lists the unique non zero account ids, in order.
+func changed(id int64, accountIDs ...int64) Changed {
+	ids := make([]int64, 0, len(accountIDs))
+
+	for _, accountID := range accountIDs {
+		if accountID != 0 && !slices.Contains(ids, accountID) {
+			ids = append(ids, accountID)
+		}
+	}
+
+	return Changed{ID: id, AccountIDs: ids}
+}
@@ -0,0 +1,105 @@
+package import_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/exchange"
+	"financo/models/exchange_rate"
+	"financo/server/exchange_rates/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	rates     []exchange.Rate
+	timestamp time.Time
+}
+
+// New returns a command that upserts rates. A rate already stored for the
+// same date and pair is overwritten, so the same file can be imported again.
+func New(rates []exchange.Rate) commands.Command[response.Import] {
+	return &command{
+		rates:     rates,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Import, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Import
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`
+			INSERT INTO exchange_rates(date, from_currency, to_currency, rate, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (date, from_currency, to_currency)
+			DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at
+		`,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to prepare statement"), err, tx.Rollback())
+	}
+	defer stmt.Close()
+
+	for _, rate := range c.rates {
+		record, ok := toRecord(rate, c.timestamp)
+		if !ok {
+			res.Skipped++
+			continue
+		}
+
+		_, err = stmt.ExecContext(
+			ctx,
+			record.Date,
+			record.From,
+			record.To,
+			record.Rate,
+			record.CreatedAt,
+			record.UpdatedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+		}
+
+		res.Imported++
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return res, nil
+}
+
+func toRecord(rate exchange.Rate, timestamp time.Time) (exchange_rate.Record, bool) {
+	record := exchange_rate.Record{
+		ID:        -1,
+		Date:      rate.Date,
+		Rate:      rate.Rate,
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+
+	if record.From.Scan(rate.From) != nil || record.To.Scan(rate.To) != nil || rate.Rate <= 0 {
+		return record, false
+	}
+
+	return record, true
+}
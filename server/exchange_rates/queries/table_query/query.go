@@ -0,0 +1,86 @@
+package table_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/exchange"
+	"financo/models/exchange_rate"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type query struct {
+	from  time.Time
+	until time.Time
+}
+
+// New returns a query that loads every rate needed to convert amounts dated
+// between from and until, including the last rates published before from.
+func New(from time.Time, until time.Time) queries.Query[exchange.Table] {
+	return &query{
+		from:  from,
+		until: until,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (exchange.Table, error) {
+	var (
+		postgres = postgresql_database.New()
+		rates    = make([]exchange.Rate, 0, 200)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return exchange.Table{}, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				er.date,
+				er.from_currency,
+				er.to_currency,
+				er.rate
+			FROM exchange_rates er
+			WHERE er.date <= $2::DATE
+				AND er.date >= COALESCE(
+					(
+						SELECT MAX(prev.date)
+						FROM exchange_rates prev
+						WHERE prev.from_currency = er.from_currency
+							AND prev.to_currency = er.to_currency
+							AND prev.date <= $1::DATE
+					),
+					$1::DATE
+				)
+			ORDER BY er.date
+		`,
+		q.from,
+		q.until,
+	)
+	if err != nil {
+		return exchange.Table{}, errors.Join(errors.New("failed to find exchange rates"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record exchange_rate.Record
+
+		err = rows.Scan(&record.Date, &record.From, &record.To, &record.Rate)
+		if err != nil {
+			return exchange.Table{}, errors.Join(errors.New("failed to scan exchange rate"), err)
+		}
+
+		rates = append(rates, exchange.Rate{
+			Date: record.Date,
+			From: string(record.From),
+			To:   string(record.To),
+			Rate: record.Rate,
+		})
+	}
+
+	return exchange.NewTable(rates), nil
+}
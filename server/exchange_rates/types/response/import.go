@@ -0,0 +1,8 @@
+package response
+
+// Import summarizes an exchange rates import. Rates for currencies financo
+// doesn't support are skipped.
+type Import struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
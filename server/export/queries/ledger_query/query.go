@@ -0,0 +1,331 @@
+package ledger_query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/ledger"
+	"financo/models/account"
+	"financo/models/transaction"
+	"financo/services/postgresql_database"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	openingBalancesName = "Equity:Opening-Balances"
+)
+
+type query struct{}
+
+// New returns a query that walks every account and transaction and maps them
+// into a [ledger.Journal].
+//
+// Accounts are named after their kind, capital accounts are Assets, debt
+// accounts are Liabilities and external accounts are Income or Expenses,
+// followed by their parent's name when they have one. Every history account
+// is mapped to Equity:Opening-Balances.
+func New() queries.Query[ledger.Journal] {
+	return &query{}
+}
+
+func (q *query) Find(ctx context.Context) (ledger.Journal, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		journal ledger.Journal
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return journal, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	accounts, err := q.findAccounts(ctx, conn)
+	if err != nil {
+		return journal, errors.Join(errors.New("failed to find accounts"), err)
+	}
+
+	transactions, err := q.findTransactions(ctx, conn)
+	if err != nil {
+		return journal, errors.Join(errors.New("failed to find transactions"), err)
+	}
+
+	names := accountNames(accounts)
+	journal.Transactions = make([]ledger.Transaction, 0, len(transactions))
+
+	for _, record := range transactions {
+		source, ok := accounts[record.SourceID]
+		if !ok {
+			return journal, fmt.Errorf("transaction %d source account %d not found", record.ID, record.SourceID)
+		}
+
+		target, ok := accounts[record.TargetID]
+		if !ok {
+			return journal, fmt.Errorf("transaction %d target account %d not found", record.ID, record.TargetID)
+		}
+
+		journal.Transactions = append(journal.Transactions, buildTransaction(record, source, target, names))
+	}
+
+	journal.Accounts = buildAccounts(accounts, journal.Transactions, names)
+
+	return journal, nil
+}
+
+// accountNames maps every account ID to its ledger account name. Accounts
+// ending up with the same name are told apart by their ID, except history
+// accounts that all share the opening balances account.
+func accountNames(accounts map[int64]account.Record) map[int64]string {
+	var (
+		names = make(map[int64]string, len(accounts))
+		taken = make(map[string]int64, len(accounts))
+	)
+
+	for _, id := range sortedIDs(accounts) {
+		record := accounts[id]
+
+		if record.Kind == account.SystemHistoric {
+			names[id] = openingBalancesName
+			continue
+		}
+
+		components := []string{root(record.Kind)}
+
+		if record.ParentID.Valid {
+			if parent, ok := accounts[record.ParentID.Val]; ok {
+				components = append(components, parent.Name)
+			}
+		}
+
+		name := ledger.AccountName(append(components, record.Name)...)
+
+		if _, ok := taken[name]; ok {
+			name = ledger.AccountName(append(components, fmt.Sprintf("%s %d", record.Name, record.ID))...)
+		}
+
+		taken[name] = id
+		names[id] = name
+	}
+
+	return names
+}
+
+func root(kind account.Kind) string {
+	switch kind {
+	case account.CapitalNormal, account.CapitalSavings:
+		return "Assets"
+	case account.DebtPersonal, account.DebtLoan, account.DebtCredit:
+		return "Liabilities"
+	case account.ExternalIncome:
+		return "Income"
+	case account.ExternalExpense:
+		return "Expenses"
+	default:
+		return "Equity"
+	}
+}
+
+func buildTransaction(
+	record transaction.Record,
+	source account.Record,
+	target account.Record,
+	names map[int64]string,
+) ledger.Transaction {
+	var (
+		res = ledger.Transaction{
+			Date:    record.ExecutedAt.OrElse(record.IssuedAt),
+			Pending: !record.ExecutedAt.Valid,
+			Notes:   record.Notes.OrElse(""),
+		}
+		targetPosting = ledger.Posting{
+			Account:  names[target.ID],
+			Amount:   record.TargetAmount,
+			Currency: string(target.Currency),
+		}
+		sourcePosting = ledger.Posting{
+			Account:  names[source.ID],
+			Amount:   -record.SourceAmount,
+			Currency: string(source.Currency),
+		}
+	)
+
+	if source.Currency != target.Currency {
+		targetPosting.Price = record.SourceAmount
+		targetPosting.PriceCurrency = string(source.Currency)
+	}
+
+	res.Postings = []ledger.Posting{targetPosting, sourcePosting}
+
+	return res
+}
+
+// buildAccounts declares every account that is either in use or not deleted.
+// Accounts are opened on their first transaction or when they were created,
+// whatever happened first.
+func buildAccounts(
+	accounts map[int64]account.Record,
+	transactions []ledger.Transaction,
+	names map[int64]string,
+) []ledger.Account {
+	var (
+		res        = make([]ledger.Account, 0, len(accounts))
+		openedAt   = make(map[string]time.Time, len(accounts))
+		currencies = make(map[string]string, len(accounts))
+		used       = make(map[string]bool, len(accounts))
+	)
+
+	for _, tr := range transactions {
+		for _, posting := range tr.Postings {
+			used[posting.Account] = true
+
+			if at, ok := openedAt[posting.Account]; !ok || tr.Date.Before(at) {
+				openedAt[posting.Account] = tr.Date
+			}
+		}
+	}
+
+	for _, id := range sortedIDs(accounts) {
+		var (
+			record  = accounts[id]
+			name    = names[id]
+			created = time.Date(record.CreatedAt.Year(), record.CreatedAt.Month(), record.CreatedAt.Day(), 0, 0, 0, 0, time.UTC)
+		)
+
+		if record.DeletedAt.Valid && !used[name] {
+			continue
+		}
+
+		if at, ok := openedAt[name]; !ok || created.Before(at) {
+			openedAt[name] = created
+		}
+
+		currency, ok := currencies[name]
+		if !ok {
+			currencies[name] = string(record.Currency)
+			res = append(res, ledger.Account{Name: name})
+		} else if currency != string(record.Currency) {
+			currencies[name] = ""
+		}
+	}
+
+	for i := range res {
+		res[i].Currency = currencies[res[i].Name]
+		res[i].OpenedAt = openedAt[res[i].Name]
+	}
+
+	return res
+}
+
+func (q *query) findAccounts(ctx context.Context, conn *sql.Conn) (map[int64]account.Record, error) {
+	var (
+		res = make(map[int64]account.Record, 50)
+	)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				parent_id,
+				kind,
+				currency,
+				name,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM accounts
+		`,
+	)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record account.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.ParentID,
+			&record.Kind,
+			&record.Currency,
+			&record.Name,
+			&record.DeletedAt,
+			&record.CreatedAt,
+			&record.UpdatedAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res[record.ID] = record
+	}
+
+	return res, rows.Err()
+}
+
+func (q *query) findTransactions(ctx context.Context, conn *sql.Conn) ([]transaction.Record, error) {
+	var (
+		res = make([]transaction.Record, 0, 200)
+	)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				source_id,
+				target_id,
+				source_amount,
+				target_amount,
+				notes,
+				issued_at,
+				executed_at
+			FROM transactions
+			WHERE deleted_at IS NULL
+			ORDER BY COALESCE(executed_at, issued_at), id
+		`,
+	)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record transaction.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.SourceID,
+			&record.TargetID,
+			&record.SourceAmount,
+			&record.TargetAmount,
+			&record.Notes,
+			&record.IssuedAt,
+			&record.ExecutedAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, record)
+	}
+
+	return res, rows.Err()
+}
+
+func sortedIDs(accounts map[int64]account.Record) []int64 {
+	ids := make([]int64, 0, len(accounts))
+
+	for id := range accounts {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
@@ -0,0 +1,68 @@
+package create_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/import_profiles"
+	"financo/server/import_profiles/queries/detailed_query"
+	"financo/server/import_profiles/types/request"
+	"financo/server/import_profiles/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		id  int64
+		res response.Detailed
+	)
+
+	err := import_profiles.Validate(c.req.Name, c.req.Mapping)
+	if err != nil {
+		return res, errors.Join(errors.New("invalid import profile"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO import_profiles(name, mapping, created_at, updated_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`,
+		c.req.Name,
+		c.req.Mapping,
+		c.timestamp,
+		c.timestamp,
+	).Scan(&id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted import profile"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,54 @@
+package delete_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/import_profiles/queries/detailed_query"
+	"financo/server/import_profiles/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	id        int64
+	timestamp time.Time
+}
+
+func New(id int64) commands.Command[response.Detailed] {
+	return &command{
+		id:        id,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find import profile"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(
+		ctx,
+		"UPDATE import_profiles SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL",
+		c.id,
+		c.timestamp,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to mark import profile as deleted"), err)
+	}
+
+	res.UpdatedAt = c.timestamp
+
+	return res, nil
+}
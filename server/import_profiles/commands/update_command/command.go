@@ -0,0 +1,70 @@
+package update_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/import_profiles"
+	"financo/server/import_profiles/queries/detailed_query"
+	"financo/server/import_profiles/types/request"
+	"financo/server/import_profiles/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	req       request.Update
+	timestamp time.Time
+}
+
+func New(req request.Update) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	err := import_profiles.Validate(c.req.Name, c.req.Mapping)
+	if err != nil {
+		return res, errors.Join(errors.New("invalid import profile"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			UPDATE import_profiles SET
+				name = $1,
+				mapping = $2,
+				updated_at = $3
+			WHERE deleted_at IS NULL AND id = $4
+			RETURNING id
+		`,
+		c.req.Name,
+		c.req.Mapping,
+		c.timestamp,
+		c.req.ID,
+	).Scan(&c.req.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve response"), err)
+	}
+
+	return res, nil
+}
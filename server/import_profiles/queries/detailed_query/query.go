@@ -0,0 +1,60 @@
+package detailed_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/import_profiles/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+func New(id int64) queries.Query[response.Detailed] {
+	return &query{
+		id: id,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				name,
+				mapping,
+				created_at,
+				updated_at
+			FROM import_profiles
+			WHERE deleted_at IS NULL
+				AND id = $1
+		`,
+		q.id,
+	).Scan(
+		&res.ID,
+		&res.Name,
+		&res.Mapping,
+		&res.CreatedAt,
+		&res.UpdatedAt,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute and scan query"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,10 @@
+package request
+
+import (
+	"financo/models/import_profile"
+)
+
+type Create struct {
+	Name    string                 `json:"name"`
+	Mapping import_profile.Mapping `json:"mapping"`
+}
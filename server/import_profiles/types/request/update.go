@@ -0,0 +1,11 @@
+package request
+
+import (
+	"financo/models/import_profile"
+)
+
+type Update struct {
+	ID      int64                  `json:"id"`
+	Name    string                 `json:"name"`
+	Mapping import_profile.Mapping `json:"mapping"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"financo/models/import_profile"
+	"time"
+)
+
+type Detailed struct {
+	ID        int64                  `json:"id"`
+	Name      string                 `json:"name"`
+	Mapping   import_profile.Mapping `json:"mapping"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
@@ -0,0 +1,30 @@
+package import_profiles
+
+import (
+	"errors"
+	"financo/models/import_profile"
+	"strings"
+)
+
+// Validate checks that an import profile can be used to import statements.
+//
+// It returns an error describing the first invalid field.
+func Validate(name string, mapping import_profile.Mapping) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("import profile name is required")
+	}
+
+	if mapping.CSV.Valid {
+		if err := mapping.CSV.Val.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range mapping.Rules {
+		if strings.TrimSpace(rule.Contains) == "" {
+			return errors.New("import profile rules require a text to match")
+		}
+	}
+
+	return nil
+}
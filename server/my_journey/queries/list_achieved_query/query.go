@@ -2,11 +2,11 @@ package list_achieved_query
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
 	"errors"
 	"financo/core/domain/queries"
 	"financo/models/achievement"
-	"financo/models/achievement/savings_goal"
+	"financo/server/achievements"
 	"financo/server/my_journey/types/response"
 	"financo/services/postgresql_database"
 	"time"
@@ -24,6 +24,9 @@ func New(db postgresql_database.Service) queries.Query[[]response.Achievable] {
 	}
 }
 
+// Find returns the achieved achievements of every defined kind, the most
+// recent first. Their settings are returned as stored, following the schema
+// of their kind.
 func (q *query) Find(ctx context.Context) ([]response.Achievable, error) {
 	var (
 		res = make([]response.Achievable, 0, 20)
@@ -35,19 +38,6 @@ func (q *query) Find(ctx context.Context) ([]response.Achievable, error) {
 	}
 	defer conn.Close()
 
-	achievables, err := q.findSavingsGoals(ctx, conn)
-	if err != nil {
-		return nil, errors.Join(errors.New("list_achieved_query: failed to retrieve savings goals"), err)
-	}
-
-	res = append(res, achievables...)
-
-	return res, nil
-}
-
-func (q *query) findSavingsGoals(ctx context.Context, conn *sql.Conn) ([]response.Achievable, error) {
-	res := make([]response.Achievable, 0, 10)
-
 	rows, err := conn.QueryContext(
 		ctx,
 		`
@@ -65,12 +55,12 @@ func (q *query) findSavingsGoals(ctx context.Context, conn *sql.Conn) ([]respons
 		WHERE
 			achieved_at IS NOT NULL
 			AND deleted_at IS NULL
-			AND kind = $1
+			AND kind = ANY ($1)
 			AND achieved_at <= $2
 		ORDER BY
 			achieved_at DESC
 		`,
-		achievement.SavingsGoal,
+		achievements.Kinds(),
 		q.timestamp,
 	)
 	if err != nil {
@@ -79,14 +69,17 @@ func (q *query) findSavingsGoals(ctx context.Context, conn *sql.Conn) ([]respons
 	defer rows.Close()
 
 	for rows.Next() {
-		var r savings_goal.Record
+		var (
+			r        achievement.Record[json.RawMessage]
+			settings []byte
+		)
 
 		err = rows.Scan(
 			&r.ID,
 			&r.Kind,
 			&r.Name,
 			&r.Description,
-			&r.Settings,
+			&settings,
 			&r.AchievedAt,
 			&r.DeletedAt,
 			&r.CreatedAt,
@@ -96,8 +89,13 @@ func (q *query) findSavingsGoals(ctx context.Context, conn *sql.Conn) ([]respons
 			return res, errors.Join(errors.New("list_achieved_query: failed to scan record"), err)
 		}
 
+		r.Settings = settings
 		res = append(res, r)
 	}
 
+	if err = rows.Err(); err != nil {
+		return res, errors.Join(errors.New("list_achieved_query: failed to iterate records"), err)
+	}
+
 	return res, nil
 }
@@ -0,0 +1,244 @@
+package relay_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/message_bus"
+	"financo/models/outbox_message"
+	"financo/server/outbox/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+// Deliver hands a message to the bus subscribed to its topic.
+type Deliver func(record outbox_message.Record) error
+
+// Retry spaces the attempts at delivering a message, from a second up to 5
+// minutes, a message failing 10 times is moved to the dead letters. The
+// messages written after a failed one wait for it meanwhile.
+var Retry = message_bus.RetryPolicy{
+	MaxAttempts:    10,
+	InitialBackoff: time.Second,
+	MaxBackoff:     5 * time.Minute,
+	Multiplier:     2,
+}
+
+// deadLetterBus is the bus recorded on the dead letters of the outbox, their
+// subscription is the topic of the message.
+const deadLetterBus = "outbox"
+
+type command struct {
+	deliver   Deliver
+	limit     int
+	timestamp time.Time
+}
+
+// New returns a command that delivers up to limit due messages in the order
+// they were written. The messages are locked while they are delivered and
+// marked as delivered in the same database transaction, if the process dies
+// before committing they are delivered again.
+//
+// A message is delivered once its bus accepted it. The Redis bus persists it
+// before returning, so its consumers receive every message at least once. The
+// in-memory bus only queues it, messages queued but not consumed yet when the
+// process dies are lost, a graceful shutdown drains the queues first.
+//
+// Messages are delivered strictly in the order they were written, so a client
+// resuming after a message never misses an older one. Only one relay runs at
+// a time and a run stops at the first message that isn't delivered, a failed
+// message is retried following [Retry], then moved to the dead letters so it
+// stops holding back the messages written after it.
+func New(deliver Deliver, limit int) commands.Command[response.Relay] {
+	return &command{
+		deliver:   deliver,
+		limit:     limit,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Relay, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Relay
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	// The relays take turns, so the messages they deliver can't overtake
+	// each other.
+	_, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('outbox_relay'))")
+	if err != nil {
+		return res, errors.Join(errors.New("failed to lock outbox"), err, tx.Rollback())
+	}
+
+	records, err := c.findPending(ctx, tx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find pending messages"), err, tx.Rollback())
+	}
+
+	for _, o := range c.attempt(records) {
+		if o.err == nil {
+			res.Delivered++
+
+			_, err = tx.ExecContext(
+				ctx,
+				"UPDATE outbox_messages SET attempts = $2, delivered_at = $3 WHERE id = $1",
+				o.record.ID,
+				o.attempts,
+				c.timestamp,
+			)
+			if err != nil {
+				return res, errors.Join(errors.New("failed to mark message as delivered"), err, tx.Rollback())
+			}
+
+			continue
+		}
+
+		if o.attempts >= Retry.MaxAttempts {
+			res.DeadLettered++
+
+			err = c.deadLetter(ctx, tx, o.record, o.attempts, o.err)
+			if err != nil {
+				return res, errors.Join(errors.New("failed to move message to dead letters"), err, tx.Rollback())
+			}
+
+			continue
+		}
+
+		res.Failed++
+
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE outbox_messages SET attempts = $2, last_error = $3, next_attempt_at = $4 WHERE id = $1",
+			o.record.ID,
+			o.attempts,
+			o.err.Error(),
+			c.timestamp.Add(Retry.Backoff(o.attempts)),
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to mark message as failed"), err, tx.Rollback())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return res, nil
+}
+
+type outcome struct {
+	record   outbox_message.Record
+	attempts int
+	err      error
+}
+
+// attempt delivers the pending records in order until one is waiting for its
+// backoff or fails without being dead lettered, the records after it are left
+// for a later run.
+func (c *command) attempt(records []outbox_message.Record) []outcome {
+	var output = make([]outcome, 0, len(records))
+
+	for _, record := range records {
+		if record.NextAttemptAt.After(c.timestamp) {
+			break
+		}
+
+		o := outcome{
+			record:   record,
+			attempts: record.Attempts + 1,
+			err:      c.deliver(record),
+		}
+
+		output = append(output, o)
+
+		if o.err != nil && o.attempts < Retry.MaxAttempts {
+			break
+		}
+	}
+
+	return output
+}
+
+// deadLetter removes the message from the outbox and keeps it as a dead
+// letter.
+func (c *command) deadLetter(ctx context.Context, tx *sql.Tx, record outbox_message.Record, attempts int, cause error) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`
+			INSERT INTO dead_letters(bus, subscription, payload, error, attempts, failed_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`,
+		deadLetterBus,
+		record.Topic,
+		string(record.Payload),
+		cause.Error(),
+		attempts,
+		c.timestamp,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM outbox_messages WHERE id = $1", record.ID)
+
+	return err
+}
+
+func (c *command) findPending(ctx context.Context, tx *sql.Tx) ([]outbox_message.Record, error) {
+	var output = make([]outbox_message.Record, 0, c.limit)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT id, topic, payload, attempts, last_error, next_attempt_at, created_at
+			FROM outbox_messages
+			WHERE delivered_at IS NULL
+			ORDER BY id
+			LIMIT $1
+		`,
+		c.limit,
+	)
+	if err != nil {
+		return output, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			record  outbox_message.Record
+			payload []byte
+		)
+
+		err = rows.Scan(
+			&record.ID,
+			&record.Topic,
+			&payload,
+			&record.Attempts,
+			&record.LastError,
+			&record.NextAttemptAt,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			return output, err
+		}
+
+		record.Payload = payload
+		output = append(output, record)
+	}
+
+	return output, rows.Err()
+}
@@ -0,0 +1,88 @@
+package relay_command
+
+import (
+	"errors"
+	"financo/models/outbox_message"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttempt(t *testing.T) {
+	var (
+		now     = time.Date(2024, time.October, 26, 9, 0, 0, 0, time.UTC)
+		due     = now.Add(-time.Second)
+		waiting = now.Add(time.Minute)
+		failing = errors.New("bus unavailable")
+	)
+
+	message := func(id int64, attempts int, nextAttemptAt time.Time) outbox_message.Record {
+		return outbox_message.Record{ID: id, Attempts: attempts, NextAttemptAt: nextAttemptAt}
+	}
+
+	tests := []struct {
+		name      string
+		records   []outbox_message.Record
+		failing   []int64
+		delivered []int64
+		attempted []int64
+	}{
+		{
+			name:      "every message delivered",
+			records:   []outbox_message.Record{message(1, 0, due), message(2, 0, due), message(3, 0, due)},
+			delivered: []int64{1, 2, 3},
+			attempted: []int64{1, 2, 3},
+		},
+		{
+			name:      "failed message holds back the newer ones",
+			records:   []outbox_message.Record{message(1, 0, due), message(2, 0, due), message(3, 0, due)},
+			failing:   []int64{2},
+			delivered: []int64{1},
+			attempted: []int64{1, 2},
+		},
+		{
+			name:      "message waiting for its backoff holds back the newer ones",
+			records:   []outbox_message.Record{message(1, 1, waiting), message(2, 0, due)},
+			delivered: []int64{},
+			attempted: []int64{},
+		},
+		{
+			name:      "dead lettered message lets the newer ones through",
+			records:   []outbox_message.Record{message(1, Retry.MaxAttempts-1, due), message(2, 0, due)},
+			failing:   []int64{1},
+			delivered: []int64{2},
+			attempted: []int64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivered := make([]int64, 0, len(tt.records))
+
+			c := &command{
+				timestamp: now,
+				deliver: func(record outbox_message.Record) error {
+					for _, id := range tt.failing {
+						if id == record.ID {
+							return failing
+						}
+					}
+
+					delivered = append(delivered, record.ID)
+
+					return nil
+				},
+			}
+
+			attempted := make([]int64, 0, len(tt.records))
+			for _, o := range c.attempt(tt.records) {
+				attempted = append(attempted, o.record.ID)
+				assert.Equal(t, o.record.Attempts+1, o.attempts)
+			}
+
+			assert.Equal(t, tt.delivered, delivered)
+			assert.Equal(t, tt.attempted, attempted)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/core/scope_accounts/infrastructure/broker_handler"
+	"financo/lib/poll"
+	"financo/models/outbox_message"
+	"financo/server/outbox/commands/relay_command"
+	"financo/server/transactions/brokers"
+	"financo/server/transactions/types/message"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	relayInterval = time.Second
+	relayLimit    = 100
+)
+
+// StartRelay delivers the messages written to the outbox to the accounts and
+// transactions buses. It runs once on startup, so consumers receive what was
+// written while the server was down, and then every second until ctx is
+// canceled.
+func StartRelay(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	accounts broker_handler.BrokerHandler,
+	transactions brokers.Broker,
+) {
+	defer wg.Done()
+
+	deliver := dispatcher(accounts, transactions)
+
+	log.Println("Starting outbox relay...")
+
+	poll.Loop(ctx, relayInterval, func(ctx context.Context) bool {
+		res, err := relay_command.New(deliver, relayLimit).Run(ctx)
+		if err != nil {
+			log.Printf("failed to relay outbox messages: %s\n", err)
+			return false
+		}
+
+		if res.Failed > 0 {
+			log.Printf("failed to deliver %d outbox messages\n", res.Failed)
+		}
+
+		if res.DeadLettered > 0 {
+			log.Printf("moved %d outbox messages to dead letters\n", res.DeadLettered)
+		}
+
+		// Failed messages wait for their backoff, only a batch delivered in
+		// full means newer messages are waiting.
+		return res.Delivered == relayLimit
+	})
+
+	log.Println("Outbox relay stopped")
+}
+
+func dispatcher(accounts broker_handler.BrokerHandler, transactions brokers.Broker) relay_command.Deliver {
+	return func(record outbox_message.Record) error {
+		switch record.Topic {
+		case messages.CreatedTopic:
+			return decode(record, accounts.CreatedBroker().Publish, func(m *messages.Created) {
+				m.OutboxID = record.ID
+			})
+		case messages.UpdatedTopic:
+			return decode(record, accounts.UpdatedBroker().Publish, func(m *messages.Updated) {
+				m.OutboxID = record.ID
+			})
+		case messages.DeletedTopic:
+			return decode(record, accounts.DeletedBroker().Publish, func(m *messages.Deleted) {
+				m.OutboxID = record.ID
+			})
+		case message.CreatedTopic:
+			return decode(record, transactions.PublishCreated, func(m *message.Created) {
+				m.OutboxID = record.ID
+			})
+		case message.UpdatedTopic:
+			return decode(record, transactions.PublishUpdated, func(m *message.Updated) {
+				m.OutboxID = record.ID
+			})
+		case message.DeletedTopic:
+			return decode(record, transactions.PublishDeleted, func(m *message.Deleted) {
+				m.OutboxID = record.ID
+			})
+		default:
+			return fmt.Errorf("outbox: unknown topic %s", record.Topic)
+		}
+	}
+}
+
+// decode maps the payload of record and publishes it once identify stamped
+// it with the id of record.
+func decode[Payload any](record outbox_message.Record, publish func(Payload) error, identify func(*Payload)) error {
+	var payload Payload
+
+	err := json.Unmarshal(record.Payload, &payload)
+	if err != nil {
+		return errors.Join(fmt.Errorf("outbox: message %d can't be mapped", record.ID), err)
+	}
+
+	identify(&payload)
+
+	return publish(payload)
+}
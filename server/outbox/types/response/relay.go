@@ -0,0 +1,9 @@
+package response
+
+// Relay reports a relay run, failed messages stay pending and are retried once
+// their backoff elapsed, dead lettered ones exhausted their attempts.
+type Relay struct {
+	Delivered    int `json:"delivered"`
+	Failed       int `json:"failed"`
+	DeadLettered int `json:"deadLettered"`
+}
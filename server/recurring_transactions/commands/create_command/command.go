@@ -0,0 +1,197 @@
+package create_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/models/recurring_transaction"
+	"financo/server/recurring_transactions/queries/detailed_query"
+	"financo/server/recurring_transactions/types/request"
+	"financo/server/recurring_transactions/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		record = recurring_transaction.Record{
+			ID:                -1,
+			SourceID:          c.req.SourceID,
+			TargetID:          c.req.TargetID,
+			SourceAmount:      c.req.SourceAmount,
+			TargetAmount:      c.req.TargetAmount,
+			Notes:             c.req.Notes,
+			Rule:              c.req.Rule,
+			StartsAt:          c.req.StartsAt.UTC(),
+			EndsAt:            c.req.EndsAt,
+			MaterializedUntil: nullable.Type[time.Time]{},
+			DeletedAt:         nullable.Type[time.Time]{},
+			CreatedAt:         c.timestamp,
+			UpdatedAt:         c.timestamp,
+		}
+		postgres = postgresql_database.New()
+
+		source account.Record
+		target account.Record
+		res    response.Detailed
+	)
+
+	if record.SourceID == record.TargetID {
+		return res, errors.New("circular transaction")
+	}
+
+	err := record.Rule.Validate()
+	if err != nil {
+		return res, errors.Join(errors.New("invalid recurrence rule"), err)
+	}
+
+	if record.EndsAt.Valid {
+		record.EndsAt = nullable.New(record.EndsAt.Val.UTC())
+
+		if record.EndsAt.Val.Before(record.StartsAt) {
+			return res, errors.New("recurring transaction ends before it starts")
+		}
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	source, err = c.findAccount(ctx, conn, record.SourceID)
+	if err != nil {
+		return res, errors.Join(errors.New("transaction source not found"), err)
+	}
+
+	target, err = c.findAccount(ctx, conn, record.TargetID)
+	if err != nil {
+		return res, errors.Join(errors.New("transaction target not found"), err)
+	}
+
+	if target.Currency == source.Currency {
+		record.TargetAmount = record.SourceAmount
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	record, err = c.persistRecord(ctx, tx, record)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err = detailed_query.New(record.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted recurring transaction"), err)
+	}
+
+	return res, nil
+}
+
+func (c *command) findAccount(ctx context.Context, conn *sql.Conn, id int64) (account.Record, error) {
+	var record account.Record
+
+	err := conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				parent_id,
+				kind,
+				currency,
+				name,
+				description,
+				color,
+				icon,
+				capital,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM accounts
+			WHERE deleted_at IS NULL
+				AND id = $1
+		`,
+		id,
+	).Scan(
+		&record.ID,
+		&record.ParentID,
+		&record.Kind,
+		&record.Currency,
+		&record.Name,
+		&record.Description,
+		&record.Color,
+		&record.Icon,
+		&record.Capital,
+		&record.ArchivedAt,
+		&record.DeletedAt,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+
+	return record, err
+}
+
+func (c *command) persistRecord(
+	ctx context.Context,
+	tx *sql.Tx,
+	r recurring_transaction.Record,
+) (recurring_transaction.Record, error) {
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO recurring_transactions(
+				source_id,
+				target_id,
+				source_amount,
+				target_amount,
+				notes,
+				frequency,
+				frequency_interval,
+				frequency_day,
+				starts_at,
+				ends_at,
+				created_at,
+				updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`,
+		r.SourceID,
+		r.TargetID,
+		r.SourceAmount,
+		r.TargetAmount,
+		r.Notes,
+		r.Rule.Frequency,
+		r.Rule.Interval,
+		r.Rule.Day,
+		r.StartsAt,
+		r.EndsAt,
+		r.CreatedAt,
+		r.UpdatedAt,
+	).Scan(&r.ID)
+
+	return r, err
+}
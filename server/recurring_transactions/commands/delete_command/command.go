@@ -0,0 +1,66 @@
+package delete_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/recurring_transactions/queries/detailed_query"
+	"financo/server/recurring_transactions/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	id        int64
+	timestamp time.Time
+}
+
+// New returns a command that soft deletes a recurring transaction. Already
+// materialized transactions are kept.
+func New(id int64) commands.Command[response.Detailed] {
+	return &command{
+		id:        id,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find recurring transaction"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE recurring_transactions SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL",
+		c.id,
+		c.timestamp,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to mark recurring transaction as deleted"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+
+	res.UpdatedAt = c.timestamp
+
+	return res, nil
+}
@@ -0,0 +1,217 @@
+package materialize_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/models/recurring_transaction"
+	"financo/models/transaction"
+	"financo/server/transactions/commands/create_command"
+	"financo/server/transactions/types/message"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	horizon   time.Duration
+	timestamp time.Time
+}
+
+// New returns a command that materializes the occurrences of every recurring
+// transaction up to horizon from now as pending transactions. Each
+// materialized transaction is written to the outbox in the same database
+// transaction, the relay publishes it to the transactions bus.
+//
+// Occurrences are materialized only once, each recurring transaction
+// remembers the last date it was materialized until.
+func New(horizon time.Duration) commands.Command[[]transaction.Record] {
+	return &command{
+		horizon:   horizon,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) ([]transaction.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+		until    = c.timestamp.Add(c.horizon)
+		horizon  = time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+		res      = make([]transaction.Record, 0, 10)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	schedules, err := c.findDueSchedules(ctx, tx, horizon)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find due recurring transactions"), err, tx.Rollback())
+	}
+
+	for _, schedule := range schedules {
+		records, err := c.materialize(ctx, tx, schedule, horizon)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to materialize recurring transaction"), err, tx.Rollback())
+		}
+
+		res = append(res, records...)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return res, nil
+}
+
+func (c *command) materialize(
+	ctx context.Context,
+	tx *sql.Tx,
+	schedule recurring_transaction.Record,
+	horizon time.Time,
+) ([]transaction.Record, error) {
+	var (
+		from  = schedule.StartsAt
+		until = horizon
+	)
+
+	if schedule.MaterializedUntil.Valid {
+		from = schedule.MaterializedUntil.Val.AddDate(0, 0, 1)
+	}
+
+	if schedule.EndsAt.Valid && schedule.EndsAt.Val.Before(until) {
+		until = schedule.EndsAt.Val
+	}
+
+	dates := schedule.Rule.Between(schedule.StartsAt, from, until)
+	records := make([]transaction.Record, 0, len(dates))
+
+	for _, date := range dates {
+		record, err := create_command.Prepare(ctx, tx, transaction.Record{
+			ID:           -1,
+			SourceID:     schedule.SourceID,
+			TargetID:     schedule.TargetID,
+			SourceAmount: schedule.SourceAmount,
+			TargetAmount: schedule.TargetAmount,
+			Notes:        schedule.Notes,
+			IssuedAt:     date,
+			ExecutedAt:   nullable.Type[time.Time]{},
+			DeletedAt:    nullable.Type[time.Time]{},
+			CreatedAt:    c.timestamp,
+			UpdatedAt:    c.timestamp,
+		})
+		if err != nil {
+			return records, err
+		}
+
+		record, err = create_command.Persist(ctx, tx, record)
+		if err != nil {
+			return records, err
+		}
+
+		err = outbox.Write(ctx, tx, message.CreatedTopic, message.Created{Record: record})
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, record)
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		"UPDATE recurring_transactions SET materialized_until = $2, updated_at = $3 WHERE id = $1",
+		schedule.ID,
+		until,
+		c.timestamp,
+	)
+
+	return records, err
+}
+
+func (c *command) findDueSchedules(
+	ctx context.Context,
+	tx *sql.Tx,
+	horizon time.Time,
+) ([]recurring_transaction.Record, error) {
+	var (
+		res = make([]recurring_transaction.Record, 0, 10)
+	)
+
+	// Recurring transactions locked by another materializer are skipped, they
+	// will be picked up on the next run.
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT
+				rt.id,
+				rt.source_id,
+				rt.target_id,
+				rt.source_amount,
+				rt.target_amount,
+				rt.notes,
+				rt.frequency,
+				rt.frequency_interval,
+				rt.frequency_day,
+				rt.starts_at,
+				rt.ends_at,
+				rt.materialized_until,
+				rt.deleted_at,
+				rt.created_at,
+				rt.updated_at
+			FROM recurring_transactions rt
+				INNER JOIN accounts src ON src.id = rt.source_id AND src.deleted_at IS NULL
+				INNER JOIN accounts trg ON trg.id = rt.target_id AND trg.deleted_at IS NULL
+			WHERE rt.deleted_at IS NULL
+				AND rt.starts_at <= $1
+				AND (rt.materialized_until IS NULL OR rt.materialized_until < $1)
+				AND (rt.ends_at IS NULL OR rt.materialized_until IS NULL OR rt.materialized_until < rt.ends_at)
+			ORDER BY rt.id
+			FOR UPDATE OF rt SKIP LOCKED
+		`,
+		horizon,
+	)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record recurring_transaction.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.SourceID,
+			&record.TargetID,
+			&record.SourceAmount,
+			&record.TargetAmount,
+			&record.Notes,
+			&record.Rule.Frequency,
+			&record.Rule.Interval,
+			&record.Rule.Day,
+			&record.StartsAt,
+			&record.EndsAt,
+			&record.MaterializedUntil,
+			&record.DeletedAt,
+			&record.CreatedAt,
+			&record.UpdatedAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, record)
+	}
+
+	return res, rows.Err()
+}
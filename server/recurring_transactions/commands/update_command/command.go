@@ -0,0 +1,172 @@
+package update_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/server/recurring_transactions/queries/detailed_query"
+	"financo/server/recurring_transactions/types/request"
+	"financo/server/recurring_transactions/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	req       request.Update
+	timestamp time.Time
+}
+
+// New returns a command that updates a recurring transaction. Changes only
+// affect the occurrences that haven't been materialized yet.
+func New(req request.Update) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		endsAt   = c.req.EndsAt
+		startsAt = c.req.StartsAt.UTC()
+		amount   = c.req.TargetAmount
+
+		res response.Detailed
+	)
+
+	if c.req.SourceID == c.req.TargetID {
+		return res, errors.New("circular transaction")
+	}
+
+	err := c.req.Rule.Validate()
+	if err != nil {
+		return res, errors.Join(errors.New("invalid recurrence rule"), err)
+	}
+
+	if endsAt.Valid {
+		endsAt = nullable.New(endsAt.Val.UTC())
+
+		if endsAt.Val.Before(startsAt) {
+			return res, errors.New("recurring transaction ends before it starts")
+		}
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	source, err := c.findAccount(ctx, conn, c.req.SourceID)
+	if err != nil {
+		return res, errors.Join(errors.New("source account not found"), err)
+	}
+
+	target, err := c.findAccount(ctx, conn, c.req.TargetID)
+	if err != nil {
+		return res, errors.Join(errors.New("target account not found"), err)
+	}
+
+	if source.Currency == target.Currency {
+		amount = c.req.SourceAmount
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin transaction"), err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`
+			UPDATE recurring_transactions SET
+				source_id = $1,
+				target_id = $2,
+				source_amount = $3,
+				target_amount = $4,
+				notes = $5,
+				frequency = $6,
+				frequency_interval = $7,
+				frequency_day = $8,
+				starts_at = $9,
+				ends_at = $10,
+				updated_at = $11
+			WHERE deleted_at IS NULL AND id = $12
+		`,
+		c.req.SourceID,
+		c.req.TargetID,
+		c.req.SourceAmount,
+		amount,
+		c.req.Notes,
+		c.req.Rule.Frequency,
+		c.req.Rule.Interval,
+		c.req.Rule.Day,
+		startsAt,
+		endsAt,
+		c.timestamp,
+		c.req.ID,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+
+	res, err = detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve response"), err)
+	}
+
+	return res, nil
+}
+
+func (c *command) findAccount(ctx context.Context, conn *sql.Conn, id int64) (account.Record, error) {
+	var record account.Record
+
+	err := conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				parent_id,
+				kind,
+				currency,
+				name,
+				description,
+				color,
+				icon,
+				capital,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM accounts
+			WHERE deleted_at IS NULL
+				AND id = $1
+		`,
+		id,
+	).Scan(
+		&record.ID,
+		&record.ParentID,
+		&record.Kind,
+		&record.Currency,
+		&record.Name,
+		&record.Description,
+		&record.Color,
+		&record.Icon,
+		&record.Capital,
+		&record.ArchivedAt,
+		&record.DeletedAt,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+
+	return record, err
+}
@@ -0,0 +1,42 @@
+package detailed_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	base "financo/server/recurring_transactions/queries"
+	"financo/server/recurring_transactions/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+func New(id int64) queries.Query[response.Detailed] {
+	return &query{
+		id: id,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Detailed, error) {
+	var (
+		query    = base.BaseQueryList + " AND rt.id = $1"
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	res, err = base.ScanDetailed(conn.QueryRowContext(ctx, query, q.id))
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute and scan query"), err)
+	}
+
+	return res, nil
+}
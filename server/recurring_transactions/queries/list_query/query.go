@@ -0,0 +1,61 @@
+package list_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	base "financo/server/recurring_transactions/queries"
+	"financo/server/recurring_transactions/types/response"
+	"financo/services/postgresql_database"
+	"fmt"
+)
+
+type query struct {
+	accounts []int64
+}
+
+func New(accounts []int64) queries.Query[[]response.Detailed] {
+	return &query{
+		accounts: accounts,
+	}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Detailed, error) {
+	var (
+		query    = base.BaseQueryList
+		res      = make([]response.Detailed, 0, 20)
+		filters  = make([]any, 0, 1)
+		filter   = 1
+		postgres = postgresql_database.New()
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	if len(q.accounts) > 0 {
+		query += fmt.Sprintf(" AND (rt.source_id = ANY ($%d) OR rt.target_id = ANY ($%d))", filter, filter)
+		filters = append(filters, q.accounts)
+	}
+
+	query += " ORDER BY rt.starts_at, rt.id"
+
+	rows, err := conn.QueryContext(ctx, query, filters...)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := base.ScanDetailed(rows)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, nil
+}
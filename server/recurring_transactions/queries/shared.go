@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"financo/server/recurring_transactions/types/response"
+)
+
+const (
+	BaseQueryList = `
+SELECT
+    rt.id,
+    rt.starts_at,
+    rt.ends_at,
+    rt.materialized_until,
+    rt.frequency,
+    rt.frequency_interval,
+    rt.frequency_day,
+    rt.source_amount,
+    rt.target_amount,
+    rt.notes,
+    rt.created_at,
+    rt.updated_at,
+    src.id,
+    src.kind,
+    src.currency,
+    src.name,
+    src.color,
+    src.icon,
+    src.archived_at,
+    trg.id,
+    trg.kind,
+    trg.currency,
+    trg.name,
+    trg.color,
+    trg.icon,
+    trg.archived_at
+FROM
+    recurring_transactions rt
+    INNER JOIN accounts src ON src.id = rt.source_id
+    INNER JOIN accounts trg ON trg.id = rt.target_id
+WHERE
+    rt.deleted_at IS NULL
+	`
+)
+
+// Row is satisfied by both [sql.Row] and [sql.Rows].
+type Row interface {
+	Scan(dest ...any) error
+}
+
+// ScanDetailed scans a row returned by [BaseQueryList] into a
+// [response.Detailed].
+func ScanDetailed(row Row) (response.Detailed, error) {
+	var res response.Detailed
+
+	err := row.Scan(
+		&res.ID,
+		&res.StartsAt,
+		&res.EndsAt,
+		&res.MaterializedUntil,
+		&res.Rule.Frequency,
+		&res.Rule.Interval,
+		&res.Rule.Day,
+		&res.SourceAmount,
+		&res.TargetAmount,
+		&res.Notes,
+		&res.CreatedAt,
+		&res.UpdatedAt,
+		&res.Source.ID,
+		&res.Source.Kind,
+		&res.Source.Currency,
+		&res.Source.Name,
+		&res.Source.Color,
+		&res.Source.Icon,
+		&res.Source.ArchivedAt,
+		&res.Target.ID,
+		&res.Target.Kind,
+		&res.Target.Currency,
+		&res.Target.Name,
+		&res.Target.Color,
+		&res.Target.Icon,
+		&res.Target.ArchivedAt,
+	)
+
+	return res, err
+}
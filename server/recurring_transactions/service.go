@@ -0,0 +1,66 @@
+package recurring_transactions
+
+import (
+	"context"
+	"financo/server/recurring_transactions/commands/materialize_command"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHorizonDays  = 30
+	materializeInterval = time.Hour
+)
+
+// StartMaterializer materializes the occurrences of every recurring
+// transaction as pending transactions. It runs once on startup and then every
+// hour until ctx is canceled.
+//
+// How far ahead occurrences are materialized is configured in days with the
+// RECURRING_TRANSACTIONS_HORIZON_DAYS environment variable, it defaults to 30
+// days.
+func StartMaterializer(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	var (
+		horizon = horizonFromEnv()
+		ticker  = time.NewTicker(materializeInterval)
+	)
+	defer ticker.Stop()
+
+	log.Println("Starting recurring transactions materializer...")
+
+	for {
+		records, err := materialize_command.New(horizon).Run(ctx)
+		if err != nil {
+			log.Printf("failed to materialize recurring transactions: %s\n", err)
+		} else if len(records) > 0 {
+			log.Printf("materialized %d recurring transactions\n", len(records))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Recurring transactions materializer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func horizonFromEnv() time.Duration {
+	days := defaultHorizonDays
+
+	if raw, ok := os.LookupEnv("RECURRING_TRANSACTIONS_HORIZON_DAYS"); ok {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			log.Printf("invalid RECURRING_TRANSACTIONS_HORIZON_DAYS \"%s\", using %d days\n", raw, defaultHorizonDays)
+		} else {
+			days = parsed
+		}
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
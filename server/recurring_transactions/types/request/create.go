@@ -0,0 +1,18 @@
+package request
+
+import (
+	"financo/lib/nullable"
+	"financo/lib/recurrence"
+	"time"
+)
+
+type Create struct {
+	StartsAt     time.Time                `json:"startsAt"`
+	EndsAt       nullable.Type[time.Time] `json:"endsAt"`
+	Rule         recurrence.Rule          `json:"rule"`
+	Notes        nullable.Type[string]    `json:"notes"`
+	SourceID     int64                    `json:"sourceID"`
+	TargetID     int64                    `json:"targetID"`
+	SourceAmount int64                    `json:"sourceAmount"`
+	TargetAmount int64                    `json:"targetAmount"`
+}
@@ -0,0 +1,36 @@
+package response
+
+import (
+	"financo/lib/color"
+	"financo/lib/currency"
+	"financo/lib/icon"
+	"financo/lib/nullable"
+	"financo/lib/recurrence"
+	"financo/models/account"
+	"time"
+)
+
+type Detailed struct {
+	ID                int64                    `json:"id"`
+	StartsAt          time.Time                `json:"startsAt"`
+	EndsAt            nullable.Type[time.Time] `json:"endsAt"`
+	MaterializedUntil nullable.Type[time.Time] `json:"materializedUntil"`
+	Rule              recurrence.Rule          `json:"rule"`
+	Source            Account                  `json:"source"`
+	SourceAmount      int64                    `json:"sourceAmount"`
+	Target            Account                  `json:"target"`
+	TargetAmount      int64                    `json:"targetAmount"`
+	Notes             nullable.Type[string]    `json:"notes"`
+	CreatedAt         time.Time                `json:"createdAt"`
+	UpdatedAt         time.Time                `json:"updatedAt"`
+}
+
+type Account struct {
+	ID         int64                    `json:"id"`
+	Kind       account.Kind             `json:"kind"`
+	Currency   currency.Type            `json:"currency"`
+	Name       string                   `json:"name"`
+	Color      color.Type               `json:"color"`
+	Icon       icon.Type                `json:"icon"`
+	ArchivedAt nullable.Type[time.Time] `json:"archivedAt"`
+}
@@ -0,0 +1,82 @@
+package archive_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/achievement/savings_goal"
+	"financo/server/savings_goals"
+	"financo/server/savings_goals/queries/detailed_query"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	id        int64
+	timestamp time.Time
+}
+
+func New(id int64) commands.Command[savings_goal.Record] {
+	return &command{
+		id:        id,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run hides the goal from the active ones, freeing its position and its
+// savings for the other goals. Archiving an archived goal does nothing.
+func (c *command) Run(ctx context.Context) (savings_goal.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return savings_goal.Record{}, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return savings_goal.Record{}, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	err = savings_goals.Lock(ctx, tx)
+	if err != nil {
+		return savings_goal.Record{}, errors.Join(errors.New("failed to lock savings goals"), err, tx.Rollback())
+	}
+
+	current, err := savings_goals.LockGoal(ctx, tx, c.id)
+	if err != nil {
+		return current, errors.Join(errors.New("failed to lock savings goal"), err, tx.Rollback())
+	}
+
+	if !current.ArchivedAt.Valid {
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE achievements SET archived_at = $2, updated_at = $2 WHERE id = $1",
+			c.id,
+			c.timestamp,
+		)
+		if err != nil {
+			return current, errors.Join(errors.New("failed to archive record"), err, tx.Rollback())
+		}
+	}
+
+	_, err = savings_goals.Fund(ctx, tx, current.Settings.Currency, c.timestamp)
+	if err != nil {
+		return current, errors.Join(errors.New("failed to fund savings goals"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return current, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find archived savings goal"), err)
+	}
+
+	return res, nil
+}
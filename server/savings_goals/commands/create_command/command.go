@@ -0,0 +1,104 @@
+package create_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/achievement"
+	"financo/models/achievement/savings_goal"
+	"financo/server/savings_goals"
+	"financo/server/savings_goals/queries/detailed_query"
+	"financo/server/savings_goals/types/request"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[savings_goal.Record] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run persists the goal after the last active goal of its currency and funds
+// it from what the goals before it leave.
+func (c *command) Run(ctx context.Context) (savings_goal.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		id  int64
+		res savings_goal.Record
+	)
+
+	err := savings_goals.Validate(c.req.Name, c.req.Target, c.req.Currency)
+	if err != nil {
+		return res, err
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	err = savings_goals.Lock(ctx, tx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to lock savings goals"), err, tx.Rollback())
+	}
+
+	position, err := savings_goals.NextPosition(ctx, tx, c.req.Currency)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find next position"), err, tx.Rollback())
+	}
+
+	err = tx.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO achievements(kind, name, description, settings, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`,
+		achievement.SavingsGoal,
+		strings.TrimSpace(c.req.Name),
+		c.req.Description,
+		savings_goal.Settings{
+			Position: position,
+			Target:   c.req.Target,
+			Currency: c.req.Currency,
+			Deadline: c.req.Deadline,
+		},
+		c.timestamp,
+		c.timestamp,
+	).Scan(&id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+	}
+
+	_, err = savings_goals.Fund(ctx, tx, c.req.Currency, c.timestamp)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to fund savings goals"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err = detailed_query.New(id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted savings goal"), err)
+	}
+
+	return res, nil
+}
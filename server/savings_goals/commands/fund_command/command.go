@@ -0,0 +1,104 @@
+package fund_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/currency"
+	"financo/models/account"
+	"financo/models/achievement/savings_goal"
+	"financo/server/savings_goals"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	accountIDs []int64
+	timestamp  time.Time
+}
+
+// New funds the goals of the currencies of the given accounts, accounts that
+// aren't capital_savings are ignored.
+func New(accountIDs []int64) commands.Command[[]savings_goal.Record] {
+	return &command{
+		accountIDs: accountIDs,
+		timestamp:  time.Now().UTC(),
+	}
+}
+
+// Run recomputes the savings of the active goals of every affected currency.
+//
+// It returns the goals whose savings changed.
+func (c *command) Run(ctx context.Context) ([]savings_goal.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		currencies = make([]currency.Type, 0, 2)
+		res        = make([]savings_goal.Record, 0, 10)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	err = savings_goals.Lock(ctx, tx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to lock savings goals"), err, tx.Rollback())
+	}
+
+	// Deleted and archived accounts are included, their transactions stop
+	// counting so the goals of their currency change as well.
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT DISTINCT currency
+			FROM accounts
+			WHERE id = ANY($1) AND kind = $2
+			ORDER BY currency
+		`,
+		c.accountIDs,
+		account.CapitalSavings,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to query savings currencies"), err, tx.Rollback())
+	}
+
+	for rows.Next() {
+		var cur currency.Type
+
+		err = rows.Scan(&cur)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan savings currency"), err, rows.Close(), tx.Rollback())
+		}
+
+		currencies = append(currencies, cur)
+	}
+
+	err = errors.Join(rows.Err(), rows.Close())
+	if err != nil {
+		return res, errors.Join(errors.New("failed to iterate savings currencies"), err, tx.Rollback())
+	}
+
+	for _, cur := range currencies {
+		funded, err := savings_goals.Fund(ctx, tx, cur, c.timestamp)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to fund savings goals"), err, tx.Rollback())
+		}
+
+		res = append(res, funded...)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,162 @@
+package reorder_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/achievement"
+	"financo/models/achievement/savings_goal"
+	"financo/server/savings_goals"
+	"financo/server/savings_goals/queries/list_active_query"
+	"financo/server/savings_goals/types/request"
+	"financo/server/savings_goals/types/response"
+	"financo/services/postgresql_database"
+	"fmt"
+	"time"
+)
+
+type command struct {
+	req       request.Reorder
+	timestamp time.Time
+}
+
+func New(req request.Reorder) commands.Command[response.Active] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run renumbers the active goals of the currency from 1 following the order of
+// the ids, which must list every active goal of the currency exactly once, and
+// funds them again in their new order.
+func (c *command) Run(ctx context.Context) (response.Active, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res = response.Active{Currency: c.req.Currency, Goals: []savings_goal.Record{}}
+	)
+
+	if _, ok := c.req.Currency.Entry(); !ok {
+		return res, fmt.Errorf("%w: currency \"%s\" is not supported", savings_goals.ErrInvalid, c.req.Currency)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	err = savings_goals.Lock(ctx, tx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to lock savings goals"), err, tx.Rollback())
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT id
+			FROM achievements
+			WHERE kind = $1
+				AND settings->>'currency' = $2
+				AND achieved_at IS NULL
+				AND archived_at IS NULL
+				AND deleted_at IS NULL
+			FOR UPDATE
+		`,
+		achievement.SavingsGoal,
+		string(c.req.Currency),
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to query active goals"), err, tx.Rollback())
+	}
+
+	active := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan active goal"), err, rows.Close(), tx.Rollback())
+		}
+
+		active[id] = true
+	}
+
+	err = errors.Join(rows.Err(), rows.Close())
+	if err != nil {
+		return res, errors.Join(errors.New("failed to iterate active goals"), err, tx.Rollback())
+	}
+
+	if len(c.req.IDs) != len(active) {
+		return res, errors.Join(
+			fmt.Errorf("%w: expected %d goals, got %d", savings_goals.ErrInvalid, len(active), len(c.req.IDs)),
+			tx.Rollback(),
+		)
+	}
+
+	positions := make([]int32, len(c.req.IDs))
+	for i, id := range c.req.IDs {
+		if !active[id] {
+			return res, errors.Join(
+				fmt.Errorf("%w: goal %d is not active in %s or is repeated", savings_goals.ErrInvalid, id, c.req.Currency),
+				tx.Rollback(),
+			)
+		}
+
+		// Seen ids are removed so a repeated one is rejected.
+		delete(active, id)
+		positions[i] = int32(i + 1)
+	}
+
+	// The unique index on the positions is checked row by row, the goals are
+	// moved out of the way to negative positions before taking the new ones.
+	for _, sign := range []int32{-1, 1} {
+		_, err = tx.ExecContext(
+			ctx,
+			`
+				UPDATE achievements
+				SET
+					settings = jsonb_set(settings, '{position}', to_jsonb(p.position * $3::INT)),
+					updated_at = $4
+				FROM unnest($1::BIGINT[], $2::INT[]) AS p(id, position)
+				WHERE achievements.id = p.id
+			`,
+			c.req.IDs,
+			positions,
+			sign,
+			c.timestamp,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to update positions"), err, tx.Rollback())
+		}
+	}
+
+	_, err = savings_goals.Fund(ctx, tx, c.req.Currency, c.timestamp)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to fund savings goals"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	all, err := list_active_query.New(postgres).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find active savings goals"), err)
+	}
+
+	for _, a := range all {
+		if a.Currency == c.req.Currency {
+			return a, nil
+		}
+	}
+
+	return res, nil
+}
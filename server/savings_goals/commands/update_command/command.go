@@ -0,0 +1,126 @@
+package update_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/currency"
+	"financo/models/achievement/savings_goal"
+	"financo/server/savings_goals"
+	"financo/server/savings_goals/queries/detailed_query"
+	"financo/server/savings_goals/types/request"
+	"financo/services/postgresql_database"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Update
+	timestamp time.Time
+}
+
+func New(req request.Update) commands.Command[savings_goal.Record] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run replaces the goal fields and funds the goals again. A goal moved to
+// another currency is placed after the last active goal of that currency.
+func (c *command) Run(ctx context.Context) (savings_goal.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	err := savings_goals.Validate(c.req.Name, c.req.Target, c.req.Currency)
+	if err != nil {
+		return savings_goal.Record{}, err
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return savings_goal.Record{}, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return savings_goal.Record{}, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	err = savings_goals.Lock(ctx, tx)
+	if err != nil {
+		return savings_goal.Record{}, errors.Join(errors.New("failed to lock savings goals"), err, tx.Rollback())
+	}
+
+	current, err := savings_goals.LockGoal(ctx, tx, c.req.ID)
+	if err != nil {
+		return current, errors.Join(errors.New("failed to lock savings goal"), err, tx.Rollback())
+	}
+
+	settings := current.Settings
+	settings.Target = c.req.Target
+	settings.Deadline = c.req.Deadline
+
+	if settings.Currency != c.req.Currency {
+		if current.AchievedAt.Valid {
+			return current, errors.Join(
+				fmt.Errorf("%w: currency of an achieved goal can't change", savings_goals.ErrInvalid),
+				tx.Rollback(),
+			)
+		}
+
+		settings.Currency = c.req.Currency
+		settings.Saved = 0
+
+		settings.Position, err = savings_goals.NextPosition(ctx, tx, c.req.Currency)
+		if err != nil {
+			return current, errors.Join(errors.New("failed to find next position"), err, tx.Rollback())
+		}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`
+			UPDATE achievements
+			SET name = $2, description = $3, settings = $4, updated_at = $5
+			WHERE id = $1
+		`,
+		c.req.ID,
+		strings.TrimSpace(c.req.Name),
+		c.req.Description,
+		settings,
+		c.timestamp,
+	)
+	if err != nil {
+		return current, errors.Join(errors.New("failed to update record"), err, tx.Rollback())
+	}
+
+	// A goal moved to another currency releases its savings in the previous
+	// one and is funded again in the new one.
+	currencies := []currency.Type{c.req.Currency}
+	if current.Settings.Currency != c.req.Currency {
+		currencies = append(currencies, current.Settings.Currency)
+	}
+
+	for _, cur := range currencies {
+		_, err = savings_goals.Fund(ctx, tx, cur, c.timestamp)
+		if err != nil {
+			return current, errors.Join(errors.New("failed to fund savings goals"), err, tx.Rollback())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return current, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err := detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find updated savings goal"), err)
+	}
+
+	return res, nil
+}
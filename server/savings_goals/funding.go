@@ -0,0 +1,145 @@
+package savings_goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"financo/lib/waterfall"
+	"financo/models/account"
+	"financo/models/achievement"
+	"financo/models/achievement/savings_goal"
+	"time"
+)
+
+// Fund recomputes how much of the capital_savings balance of the currency is
+// saved for each active goal, filling them in position order. Goals reaching
+// their target are achieved and keep holding what they saved until they are
+// archived or deleted, call [Lock] first.
+//
+// It returns the goals whose savings changed.
+func Fund(ctx context.Context, tx *sql.Tx, c currency.Type, timestamp time.Time) ([]savings_goal.Record, error) {
+	var (
+		balance  int64
+		reserved int64
+		goals    = make([]savings_goal.Record, 0, 10)
+		funded   = make([]savings_goal.Record, 0, 10)
+	)
+
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			SELECT COALESCE(SUM(
+				CASE
+					WHEN tr.target_id = acc.id THEN tr.target_amount
+					WHEN tr.source_id = acc.id THEN - tr.source_amount
+					ELSE 0
+				END
+			), 0)
+			FROM transactions tr
+				INNER JOIN accounts acc ON acc.id = tr.target_id OR acc.id = tr.source_id
+			WHERE
+				acc.kind = $1
+				AND acc.currency = $2
+				AND tr.deleted_at IS NULL
+				AND acc.deleted_at IS NULL
+				AND acc.archived_at IS NULL
+				AND (tr.executed_at IS NULL OR tr.executed_at <= NOW())
+				AND tr.issued_at <= NOW()
+		`,
+		account.CapitalSavings,
+		c,
+	).Scan(&balance)
+	if err != nil {
+		return funded, errors.Join(errors.New("failed to calculate savings balance"), err)
+	}
+
+	err = tx.QueryRowContext(
+		ctx,
+		`
+			SELECT COALESCE(SUM((settings->>'saved')::BIGINT), 0)
+			FROM achievements
+			WHERE kind = $1
+				AND settings->>'currency' = $2
+				AND achieved_at IS NOT NULL
+				AND archived_at IS NULL
+				AND deleted_at IS NULL
+		`,
+		achievement.SavingsGoal,
+		string(c),
+	).Scan(&reserved)
+	if err != nil {
+		return funded, errors.Join(errors.New("failed to calculate achieved savings"), err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			SELECT id, settings
+			FROM achievements
+			WHERE kind = $1
+				AND settings->>'currency' = $2
+				AND achieved_at IS NULL
+				AND archived_at IS NULL
+				AND deleted_at IS NULL
+			ORDER BY (settings->>'position')::INT
+		`,
+		achievement.SavingsGoal,
+		string(c),
+	)
+	if err != nil {
+		return funded, errors.Join(errors.New("failed to query active goals"), err)
+	}
+
+	for rows.Next() {
+		var goal savings_goal.Record
+
+		err = rows.Scan(&goal.ID, &goal.Settings)
+		if err != nil {
+			return funded, errors.Join(errors.New("failed to scan active goal"), err, rows.Close())
+		}
+
+		goals = append(goals, goal)
+	}
+
+	err = errors.Join(rows.Err(), rows.Close())
+	if err != nil {
+		return funded, errors.Join(errors.New("failed to iterate active goals"), err)
+	}
+
+	targets := make([]int64, len(goals))
+	for i, goal := range goals {
+		targets[i] = goal.Settings.Target
+	}
+
+	saved := waterfall.Fill(balance-reserved, targets)
+
+	for i, goal := range goals {
+		reached := saved[i] >= goal.Settings.Target
+		if goal.Settings.Saved == saved[i] && !reached {
+			continue
+		}
+
+		goal.Settings.Saved = saved[i]
+		if reached {
+			goal.AchievedAt = nullable.New(timestamp)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE achievements SET settings = $2, achieved_at = $3, updated_at = $4 WHERE id = $1",
+			goal.ID,
+			goal.Settings,
+			goal.AchievedAt,
+			timestamp,
+		)
+		if err != nil {
+			return funded, errors.Join(errors.New("failed to update goal savings"), err)
+		}
+
+		funded = append(funded, goal)
+	}
+
+	return funded, nil
+}
@@ -0,0 +1,64 @@
+// Package funding keeps the savings of the goals following the balance of the
+// capital_savings accounts.
+package funding
+
+import (
+	"context"
+	"financo/lib/message_bus"
+	"financo/models/transaction"
+	"financo/server/savings_goals/commands/fund_command"
+	"financo/server/transactions/brokers"
+	"financo/server/transactions/types/message"
+	"time"
+)
+
+const fundTimeout = 5 * time.Second
+
+// Subscribe registers the funding consumers on the transactions broker, every
+// transaction created, updated or deleted from then on funds again the goals
+// of the currencies of its accounts.
+//
+// The savings are recomputed from the balances rather than from the amounts
+// of the messages, so retried or reordered messages give the same result.
+func Subscribe(transactions brokers.Broker) error {
+	err := transactions.SubscribeToCreated(
+		message_bus.ConsumerFunc[message.Created](func(msg message.Created) error {
+			return fund(msg.Record)
+		}),
+		message_bus.WithName("savings_goals_funding_transaction_created"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToUpdated(
+		message_bus.ConsumerFunc[message.Updated](func(msg message.Updated) error {
+			return fund(msg.PreviousState, msg.CurrentState)
+		}),
+		message_bus.WithName("savings_goals_funding_transaction_updated"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return transactions.SubscribeToDeleted(
+		message_bus.ConsumerFunc[message.Deleted](func(msg message.Deleted) error {
+			return fund(msg.PreviousState, msg.CurrentState)
+		}),
+		message_bus.WithName("savings_goals_funding_transaction_deleted"),
+	)
+}
+
+func fund(records ...transaction.Record) error {
+	ids := make([]int64, 0, 2*len(records))
+	for _, record := range records {
+		ids = append(ids, record.SourceID, record.TargetID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fundTimeout)
+	defer cancel()
+
+	_, err := fund_command.New(ids).Run(ctx)
+
+	return err
+}
@@ -0,0 +1,50 @@
+package savings_goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/models/achievement"
+	"financo/models/achievement/savings_goal"
+)
+
+// ErrNotFound is returned when the savings goal doesn't exist or was deleted.
+var ErrNotFound = errors.New("savings goal not found")
+
+// LockGoal locks the savings goal until tx ends and returns its settings and
+// state, the other fields of the record are left empty.
+//
+// It returns [ErrNotFound] if the goal doesn't exist or was deleted.
+func LockGoal(ctx context.Context, tx *sql.Tx, id int64) (savings_goal.Record, error) {
+	var record savings_goal.Record
+
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			SELECT id, settings, achieved_at, archived_at
+			FROM achievements
+			WHERE kind = $1
+				AND id = $2
+				AND deleted_at IS NULL
+			FOR UPDATE
+		`,
+		achievement.SavingsGoal,
+		id,
+	).Scan(
+		&record.ID,
+		&record.Settings,
+		&record.AchievedAt,
+		&record.ArchivedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return record, ErrNotFound
+	}
+
+	return record, err
+}
+
+// Active reports whether the goal is neither achieved, archived nor deleted,
+// only active goals hold a position.
+func Active(record savings_goal.Record) bool {
+	return !record.AchievedAt.Valid && !record.ArchivedAt.Valid && !record.DeletedAt.Valid
+}
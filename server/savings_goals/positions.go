@@ -0,0 +1,41 @@
+package savings_goals
+
+import (
+	"context"
+	"database/sql"
+	"financo/lib/currency"
+	"financo/models/achievement"
+)
+
+// Lock serializes the changes to the positions and the funding of the goals
+// until tx ends, so concurrent changes can't hand out the same position or
+// the same money. Take it before locking any goal so no two transactions can
+// wait on each other.
+func Lock(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('savings_goals'))")
+
+	return err
+}
+
+// NextPosition returns the position after the last active goal of the
+// currency, call [Lock] first.
+func NextPosition(ctx context.Context, tx *sql.Tx, c currency.Type) (int16, error) {
+	var position int16
+
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			SELECT COALESCE(MAX((settings->>'position')::INT), 0) + 1
+			FROM achievements
+			WHERE kind = $1
+				AND settings->>'currency' = $2
+				AND achieved_at IS NULL
+				AND archived_at IS NULL
+				AND deleted_at IS NULL
+		`,
+		achievement.SavingsGoal,
+		string(c),
+	).Scan(&position)
+
+	return position, err
+}
@@ -0,0 +1,79 @@
+package detailed_query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/models/achievement"
+	"financo/models/achievement/savings_goal"
+	"financo/server/savings_goals"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+func New(id int64) queries.Query[savings_goal.Record] {
+	return &query{
+		id: id,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (savings_goal.Record, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		record savings_goal.Record
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return record, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				kind,
+				name,
+				description,
+				settings,
+				achieved_at,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM achievements
+			WHERE kind = $1
+				AND id = $2
+				AND deleted_at IS NULL
+		`,
+		achievement.SavingsGoal,
+		q.id,
+	).Scan(
+		&record.ID,
+		&record.Kind,
+		&record.Name,
+		&record.Description,
+		&record.Settings,
+		&record.AchievedAt,
+		&record.ArchivedAt,
+		&record.DeletedAt,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return record, savings_goals.ErrNotFound
+	}
+
+	if err != nil {
+		return record, errors.Join(errors.New("failed to execute and scan query"), err)
+	}
+
+	return record, nil
+}
@@ -42,6 +42,7 @@ func (q *query) Find(ctx context.Context) ([]response.Active, error) {
 			description,
 			settings,
 			achieved_at,
+			archived_at,
 			deleted_at,
 			created_at,
 			updated_at
@@ -49,6 +50,7 @@ func (q *query) Find(ctx context.Context) ([]response.Active, error) {
 		WHERE
 			kind = $1
 			AND achieved_at IS NULL
+			AND archived_at IS NULL
 			AND deleted_at IS NULL
 		ORDER BY
 			settings->'currency', settings->'position' ASC
@@ -70,6 +72,7 @@ func (q *query) Find(ctx context.Context) ([]response.Active, error) {
 			&record.Description,
 			&record.Settings,
 			&record.AchievedAt,
+			&record.ArchivedAt,
 			&record.DeletedAt,
 			&record.CreatedAt,
 			&record.UpdatedAt,
@@ -0,0 +1,142 @@
+package projections_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/currency"
+	"financo/lib/projection"
+	"financo/models/account"
+	"financo/server/savings_goals/queries/list_active_query"
+	"financo/server/savings_goals/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type query struct {
+	months    int
+	timestamp time.Time
+}
+
+// New projects the active goals from the net inflow of the last months.
+func New(months int) queries.Query[[]response.Projection] {
+	return &query{
+		months:    months,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Find estimates when every active goal is reached if the capital_savings
+// accounts keep growing at their average monthly pace, goals are funded in
+// position order like the savings are.
+func (q *query) Find(ctx context.Context) ([]response.Projection, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res     = make([]response.Projection, 0, 10)
+		inflows = make(map[currency.Type]int64, 10)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				acc.currency,
+				SUM(
+					CASE
+						WHEN tr.target_id = acc.id THEN tr.target_amount
+						WHEN tr.source_id = acc.id THEN - tr.source_amount
+						ELSE 0
+					END
+				)
+			FROM transactions tr
+				INNER JOIN accounts acc ON acc.id = tr.target_id OR acc.id = tr.source_id
+			WHERE
+				acc.kind = $1
+				AND tr.deleted_at IS NULL
+				AND acc.deleted_at IS NULL
+				AND acc.archived_at IS NULL
+				AND (tr.executed_at IS NULL OR tr.executed_at <= $2)
+				AND tr.issued_at <= $2
+				AND tr.issued_at > $3
+			GROUP BY
+				acc.currency
+		`,
+		account.CapitalSavings,
+		q.timestamp,
+		q.timestamp.AddDate(0, -q.months, 0),
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to calculate inflows"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cur    currency.Type
+			amount int64
+		)
+
+		err = rows.Scan(&cur, &amount)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan inflows"), err)
+		}
+
+		inflows[cur] = amount / int64(q.months)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, errors.Join(errors.New("failed to iterate inflows"), err)
+	}
+
+	active, err := list_active_query.New(postgres).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find active goals"), err)
+	}
+
+	for _, a := range active {
+		var (
+			p = response.Projection{
+				Currency:      a.Currency,
+				Months:        q.months,
+				MonthlyInflow: inflows[a.Currency],
+				Goals:         make([]response.GoalProjection, len(a.Goals)),
+			}
+			goals = make([]projection.Goal, len(a.Goals))
+		)
+
+		for i, goal := range a.Goals {
+			goals[i] = projection.Goal{
+				Target:   goal.Settings.Target,
+				Saved:    goal.Settings.Saved,
+				Deadline: goal.Settings.Deadline,
+			}
+		}
+
+		for i, estimate := range projection.Project(q.timestamp, p.MonthlyInflow, goals) {
+			goal := a.Goals[i]
+
+			p.Goals[i] = response.GoalProjection{
+				ID:              goal.ID,
+				Name:            goal.Name,
+				Position:        goal.Settings.Position,
+				Target:          goal.Settings.Target,
+				Saved:           goal.Settings.Saved,
+				Deadline:        goal.Settings.Deadline,
+				EstimatedAt:     estimate.ReachedAt,
+				RequiredMonthly: estimate.Required,
+				OnTrack:         estimate.OnTrack,
+			}
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,16 @@
+package request
+
+import (
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"time"
+)
+
+// Create adds a goal after the last active goal of its currency.
+type Create struct {
+	Name        string                   `json:"name"`
+	Description nullable.Type[string]    `json:"description"`
+	Target      int64                    `json:"target"`
+	Currency    currency.Type            `json:"currency"`
+	Deadline    nullable.Type[time.Time] `json:"deadline"`
+}
@@ -0,0 +1,11 @@
+package request
+
+import (
+	"financo/lib/currency"
+)
+
+// Reorder lists every active goal of Currency in their new order.
+type Reorder struct {
+	Currency currency.Type `json:"currency"`
+	IDs      []int64       `json:"ids"`
+}
@@ -0,0 +1,18 @@
+package request
+
+import (
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"time"
+)
+
+// Update replaces the goal fields, its position is changed through
+// [Reorder]. The currency of an achieved goal can't change.
+type Update struct {
+	ID          int64                    `json:"id"`
+	Name        string                   `json:"name"`
+	Description nullable.Type[string]    `json:"description"`
+	Target      int64                    `json:"target"`
+	Currency    currency.Type            `json:"currency"`
+	Deadline    nullable.Type[time.Time] `json:"deadline"`
+}
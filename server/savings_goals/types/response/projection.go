@@ -0,0 +1,30 @@
+package response
+
+import (
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"time"
+)
+
+// Projection estimates the active goals of a currency from the average net
+// inflow into its capital_savings accounts over the last Months months.
+type Projection struct {
+	Currency      currency.Type    `json:"currency"`
+	Months        int              `json:"months"`
+	MonthlyInflow int64            `json:"monthlyInflow"`
+	Goals         []GoalProjection `json:"goals"`
+}
+
+// GoalProjection is the estimate of an active goal in waterfall order.
+// RequiredMonthly and OnTrack are only set for goals with a deadline.
+type GoalProjection struct {
+	ID              int64                    `json:"id"`
+	Name            string                   `json:"name"`
+	Position        int16                    `json:"position"`
+	Target          int64                    `json:"target"`
+	Saved           int64                    `json:"saved"`
+	Deadline        nullable.Type[time.Time] `json:"deadline"`
+	EstimatedAt     nullable.Type[time.Time] `json:"estimatedAt"`
+	RequiredMonthly nullable.Type[int64]     `json:"requiredMonthly"`
+	OnTrack         nullable.Type[bool]      `json:"onTrack"`
+}
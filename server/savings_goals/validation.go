@@ -0,0 +1,31 @@
+package savings_goals
+
+import (
+	"errors"
+	"financo/lib/currency"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalid is returned when a savings goal can't be persisted.
+var ErrInvalid = errors.New("invalid savings goal")
+
+// Validate checks that a savings goal can be persisted.
+//
+// It returns an error wrapping [ErrInvalid] describing the first invalid
+// field.
+func Validate(name string, target int64, c currency.Type) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalid)
+	}
+
+	if target <= 0 {
+		return fmt.Errorf("%w: target must be greater than zero", ErrInvalid)
+	}
+
+	if _, ok := c.Entry(); !ok {
+		return fmt.Errorf("%w: currency \"%s\" is not supported", ErrInvalid, c)
+	}
+
+	return nil
+}
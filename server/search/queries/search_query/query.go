@@ -0,0 +1,313 @@
+package search_query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/core/scope_accounts/domain/responses"
+	"financo/models/account"
+	"financo/models/achievement"
+	"financo/models/achievement/savings_goal"
+	"financo/server/search/types/response"
+	base "financo/server/transactions/queries"
+	transactions "financo/server/transactions/types/response"
+	"financo/services/postgresql_database"
+	"slices"
+)
+
+// Limit is the maximum number of hits of each kind.
+const Limit = 20
+
+type query struct {
+	q string
+}
+
+// New returns the transactions whose notes, the accounts whose name or
+// description and the savings goals whose name or description match q. q
+// follows the web search syntax: quoted phrases, "or" and "-" to exclude
+// words.
+func New(q string) queries.Query[response.Result] {
+	return &query{
+		q: q,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Result, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res = response.Result{
+			Transactions: make([]transactions.Detailed, 0),
+			Accounts:     make([]responses.PreviewChild, 0),
+			SavingsGoals: make([]savings_goal.Record, 0),
+		}
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	res.Transactions, err = q.transactions(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to search transactions"), err)
+	}
+
+	res.Accounts, err = q.accounts(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to search accounts"), err)
+	}
+
+	res.SavingsGoals, err = q.savingsGoals(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to search savings goals"), err)
+	}
+
+	return res, nil
+}
+
+func (q *query) transactions(ctx context.Context, conn *sql.Conn) ([]transactions.Detailed, error) {
+	var (
+		ids   = make([]int64, 0, Limit)
+		found = make([]transactions.Detailed, 0, Limit)
+	)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT id
+			FROM transactions
+			WHERE deleted_at IS NULL
+				AND search @@ websearch_to_tsquery('simple', $1)
+			ORDER BY ts_rank(search, websearch_to_tsquery('simple', $1)) DESC, issued_at DESC, id DESC
+			LIMIT $2
+		`,
+		q.q,
+		Limit,
+	)
+	if err != nil {
+		return found, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+
+		if err = rows.Scan(&id); err != nil {
+			return found, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	rows, err = conn.QueryContext(ctx, base.BaseQueryList+" AND tr.id = ANY ($1)", ids)
+	if err != nil {
+		return found, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row base.BaseQueryListRow
+
+		err = rows.Scan(
+			&row.ID,
+			&row.IssuedAt,
+			&row.ExecutedAt,
+			&row.SourceAmount,
+			&row.TargetAmount,
+			&row.Notes,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+			&row.SrcID,
+			&row.SrcKind,
+			&row.SrcCurrency,
+			&row.SrcName,
+			&row.SrcColor,
+			&row.SrcIcon,
+			&row.SrcArchivedAt,
+			&row.SrcCreatedAt,
+			&row.SrcUpdatedAt,
+			&row.SrcParentID,
+			&row.SrcParentKind,
+			&row.SrcParentCurrency,
+			&row.SrcParentName,
+			&row.SrcParentColor,
+			&row.SrcParentIcon,
+			&row.SrcParentArchivedAt,
+			&row.SrcParentCreatedAt,
+			&row.SrcParentUpdatedAt,
+			&row.TrgID,
+			&row.TrgKind,
+			&row.TrgCurrency,
+			&row.TrgName,
+			&row.TrgColor,
+			&row.TrgIcon,
+			&row.TrgArchivedAt,
+			&row.TrgCreatedAt,
+			&row.TrgUpdatedAt,
+			&row.TrgParentID,
+			&row.TrgParentKind,
+			&row.TrgParentCurrency,
+			&row.TrgParentName,
+			&row.TrgParentColor,
+			&row.TrgParentIcon,
+			&row.TrgParentArchivedAt,
+			&row.TrgParentCreatedAt,
+			&row.TrgParentUpdatedAt,
+			&row.SplitID,
+			&row.SplitNotes,
+		)
+		if err != nil {
+			return found, err
+		}
+
+		found = append(found, base.BuildTransactions(row))
+	}
+
+	// keep the rank order of the first query
+	slices.SortFunc(found, func(a, b transactions.Detailed) int {
+		return slices.Index(ids, a.ID) - slices.Index(ids, b.ID)
+	})
+
+	return base.LoadTags(ctx, conn, found)
+}
+
+func (q *query) accounts(ctx context.Context, conn *sql.Conn) ([]responses.PreviewChild, error) {
+	found := make([]responses.PreviewChild, 0, Limit)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				acc.id,
+				acc.kind,
+				acc.currency,
+				acc.name,
+				acc.description,
+				COALESCE(
+					SUM(
+						CASE
+							WHEN tr.source_id = acc.id THEN - tr.source_amount
+							WHEN tr.target_id = acc.id THEN tr.target_amount
+							ELSE 0
+						END
+					),
+					0
+				)::bigint AS balance,
+				acc.capital,
+				acc.color,
+				acc.icon,
+				acc.archived_at,
+				acc.created_at,
+				acc.updated_at
+			FROM
+				accounts acc
+				LEFT JOIN transactions tr ON (tr.source_id = acc.id OR tr.target_id = acc.id)
+				AND tr.deleted_at IS NULL
+				AND (tr.executed_at IS NULL OR tr.executed_at <= NOW())
+				AND tr.issued_at <= NOW()
+			WHERE
+				acc.deleted_at IS NULL
+				AND acc.kind != $2
+				AND acc.search @@ websearch_to_tsquery('simple', $1)
+			GROUP BY acc.id
+			ORDER BY ts_rank(acc.search, websearch_to_tsquery('simple', $1)) DESC, acc.name, acc.id
+			LIMIT $3
+		`,
+		q.q,
+		account.SystemHistoric,
+		Limit,
+	)
+	if err != nil {
+		return found, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row responses.PreviewChild
+
+		err = rows.Scan(
+			&row.ID,
+			&row.Kind,
+			&row.Currency,
+			&row.Name,
+			&row.Description,
+			&row.Balance,
+			&row.Capital,
+			&row.Color,
+			&row.Icon,
+			&row.ArchivedAt,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+		)
+		if err != nil {
+			return found, err
+		}
+
+		found = append(found, row)
+	}
+
+	return found, nil
+}
+
+func (q *query) savingsGoals(ctx context.Context, conn *sql.Conn) ([]savings_goal.Record, error) {
+	found := make([]savings_goal.Record, 0, Limit)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				kind,
+				name,
+				description,
+				settings,
+				achieved_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM achievements
+			WHERE kind = $2
+				AND deleted_at IS NULL
+				AND search @@ websearch_to_tsquery('simple', $1)
+			ORDER BY ts_rank(search, websearch_to_tsquery('simple', $1)) DESC, name, id
+			LIMIT $3
+		`,
+		q.q,
+		achievement.SavingsGoal,
+		Limit,
+	)
+	if err != nil {
+		return found, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record savings_goal.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.Kind,
+			&record.Name,
+			&record.Description,
+			&record.Settings,
+			&record.AchievedAt,
+			&record.DeletedAt,
+			&record.CreatedAt,
+			&record.UpdatedAt,
+		)
+		if err != nil {
+			return found, err
+		}
+
+		found = append(found, record)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"financo/core/scope_accounts/domain/responses"
+	"financo/models/achievement/savings_goal"
+	transactions "financo/server/transactions/types/response"
+)
+
+// Result groups the hits of a search by kind, the best ranked first.
+type Result struct {
+	Transactions []transactions.Detailed  `json:"transactions"`
+	Accounts     []responses.PreviewChild `json:"accounts"`
+	SavingsGoals []savings_goal.Record    `json:"savingsGoals"`
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"financo/core/domain/queries"
 	"financo/lib/currency"
+	"financo/lib/money"
 	"financo/models/account"
 	"financo/server/summaries/queries/summary_for_kind_query"
 	"financo/server/summaries/types/response"
@@ -92,10 +93,16 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 			continue
 		}
 
-		res[i].Amount += c.capital
+		res[i].Amount, err = res[i].Amount.Add(money.New(c.capital, c.currency))
+		if err != nil {
+			return res, errors.Join(errors.New("failed to add capital"), err)
+		}
 
 		for j := 0; j < len(res[i].Series); j++ {
-			res[i].Series[j].Amount = -res[i].Series[j].Amount
+			res[i].Series[j].Amount, err = res[i].Series[j].Amount.Negate()
+			if err != nil {
+				return res, errors.Join(errors.New("failed to negate series"), err)
+			}
 		}
 	}
 
@@ -0,0 +1,89 @@
+package converted_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/currency"
+	"financo/lib/money"
+	"financo/server/exchange_rates/queries/table_query"
+	"financo/server/summaries/types/response"
+	"time"
+)
+
+type query struct {
+	in        currency.Type
+	summary   queries.Query[[]response.Global]
+	timestamp time.Time
+}
+
+// New returns a query that converts the result of summary into the currency
+// in.
+func New(in currency.Type, summary queries.Query[[]response.Global]) queries.Query[response.Converted] {
+	return &query{
+		in:        in,
+		summary:   summary,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Converted, error) {
+	var (
+		res = response.Converted{
+			Currency: q.in,
+			Amount:   money.New(0, q.in),
+			Series:   make([]response.SeriesEntry, 0, 31),
+		}
+		from = q.timestamp
+	)
+
+	breakdown, err := q.summary.Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("query failed"), err)
+	}
+
+	res.Breakdown = breakdown
+
+	for _, global := range breakdown {
+		for _, entry := range global.Series {
+			if entry.Date.Before(from) {
+				from = entry.Date
+			}
+		}
+	}
+
+	table, err := table_query.New(from, q.timestamp).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find exchange rates"), err)
+	}
+
+	for _, global := range breakdown {
+		amount, err := table.Exchange(global.Amount, q.in, q.timestamp)
+		if err != nil {
+			return res, err
+		}
+
+		res.Amount, err = res.Amount.Add(amount)
+		if err != nil {
+			return res, err
+		}
+
+		for i, entry := range global.Series {
+			amount, err := table.Exchange(entry.Amount, q.in, entry.Date)
+			if err != nil {
+				return res, err
+			}
+
+			if i == len(res.Series) {
+				res.Series = append(res.Series, response.SeriesEntry{Date: entry.Date, Amount: money.New(0, q.in)})
+			}
+
+			res.Series[i].Amount, err = res.Series[i].Amount.Add(amount)
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"financo/core/domain/queries"
+	"financo/lib/money"
 	"financo/server/summaries/types/response"
 	"financo/services/postgresql_database"
 	"time"
@@ -65,13 +66,17 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		r := response.Global{Series: make([]response.SeriesEntry, 0, 31)}
+		var (
+			r      = response.Global{Series: make([]response.SeriesEntry, 0, 31)}
+			amount int64
+		)
 
-		err = rows.Scan(&r.Currency, &r.Amount)
+		err = rows.Scan(&r.Currency, &amount)
 		if err != nil {
 			return res, errors.Join(errors.New("failed to scan balances"), err)
 		}
 
+		r.Amount = money.New(amount, r.Currency)
 		res = append(res, r)
 	}
 
@@ -129,22 +134,32 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 		defer rows.Close()
 
 		for rows.Next() {
-			var r response.SeriesEntry
+			var (
+				r      response.SeriesEntry
+				amount int64
+			)
 
-			err = rows.Scan(&r.Date, &r.Amount)
+			err = rows.Scan(&r.Date, &amount)
 			if err != nil {
 				return res, errors.Join(errors.New("failed to scan series entry for currency"), err)
 			}
 
+			r.Amount = money.New(amount, res[i].Currency)
 			res[i].Series = append(res[i].Series, r)
 		}
 
 		rows.Close()
 
-		res[i].Amount = -res[i].Amount
+		res[i].Amount, err = res[i].Amount.Negate()
+		if err != nil {
+			return res, errors.Join(errors.New("failed to negate amount"), err)
+		}
 
 		for j := 0; j < len(res[i].Series); j++ {
-			res[i].Series[j].Amount = -res[i].Series[j].Amount
+			res[i].Series[j].Amount, err = res[i].Series[j].Amount.Negate()
+			if err != nil {
+				return res, errors.Join(errors.New("failed to negate series"), err)
+			}
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"financo/core/domain/queries"
 	"financo/lib/currency"
+	"financo/lib/money"
 	"financo/models/account"
 	"financo/server/summaries/types/response"
 	"financo/services/postgresql_database"
@@ -43,7 +44,29 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 	defer conn.Close()
 
 	// account record
-	err = conn.QueryRowContext(ctx, `SELECT * FROM accounts WHERE deleted_at IS NULL AND id = $1`, q.id).Scan(
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				parent_id,
+				kind,
+				currency,
+				name,
+				description,
+				color,
+				icon,
+				capital,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM accounts
+			WHERE deleted_at IS NULL
+				AND id = $1
+		`,
+		q.id,
+	).Scan(
 		&acc.ID,
 		&acc.ParentID,
 		&acc.Kind,
@@ -98,13 +121,17 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		r := response.Global{Series: make([]response.SeriesEntry, 0, 31)}
+		var (
+			r      = response.Global{Series: make([]response.SeriesEntry, 0, 31)}
+			amount int64
+		)
 
-		err = rows.Scan(&r.Currency, &r.Amount)
+		err = rows.Scan(&r.Currency, &amount)
 		if err != nil {
 			return res, errors.Join(errors.New("failed to scan balances"), err)
 		}
 
+		r.Amount = money.New(amount, r.Currency)
 		res = append(res, r)
 	}
 
@@ -164,13 +191,17 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 		defer rows.Close()
 
 		for rows.Next() {
-			var r response.SeriesEntry
+			var (
+				r      response.SeriesEntry
+				amount int64
+			)
 
-			err = rows.Scan(&r.Date, &r.Amount)
+			err = rows.Scan(&r.Date, &amount)
 			if err != nil {
 				return res, errors.Join(errors.New("failed to scan series entry for currency"), err)
 			}
 
+			r.Amount = money.New(amount, res[i].Currency)
 			res[i].Series = append(res[i].Series, r)
 		}
 
@@ -222,17 +253,25 @@ func (q *query) Find(ctx context.Context) ([]response.Global, error) {
 		rows.Close()
 
 		for j := 0; j < len(res[i].Series); j++ {
-			if j == 0 {
-				res[i].Series[j].Amount += blc.amount
-			} else {
-				res[i].Series[j].Amount += res[i].Series[j-1].Amount
+			previous := money.New(blc.amount, res[i].Currency)
+
+			if j > 0 {
+				previous = res[i].Series[j-1].Amount
+			}
+
+			res[i].Series[j].Amount, err = res[i].Series[j].Amount.Add(previous)
+			if err != nil {
+				return res, errors.Join(errors.New("failed to accumulate series"), err)
 			}
 		}
 	}
 
 	if acc.Capital >= 0 && len(res) > 0 {
 		for i := 0; i < len(res[0].Series); i++ {
-			res[0].Series[i].Amount = -res[0].Series[i].Amount
+			res[0].Series[i].Amount, err = res[0].Series[i].Amount.Negate()
+			if err != nil {
+				return res, errors.Join(errors.New("failed to negate series"), err)
+			}
 		}
 	}
 
@@ -2,16 +2,27 @@ package response
 
 import (
 	"financo/lib/currency"
+	"financo/lib/money"
 	"time"
 )
 
 type SeriesEntry struct {
-	Date   time.Time `json:"date"`
-	Amount int64     `json:"amount"`
+	Date   time.Time   `json:"date"`
+	Amount money.Money `json:"amount"`
 }
 
 type Global struct {
 	Currency currency.Type `json:"currency"`
-	Amount   int64         `json:"amount"`
+	Amount   money.Money   `json:"amount"`
 	Series   []SeriesEntry `json:"series"`
 }
+
+// Converted is the sum of every [Global] converted into Currency. Every series
+// point is converted with the rate effective on its date, Breakdown keeps the
+// amounts in their own currency.
+type Converted struct {
+	Currency  currency.Type `json:"currency"`
+	Amount    money.Money   `json:"amount"`
+	Series    []SeriesEntry `json:"series"`
+	Breakdown []Global      `json:"breakdown"`
+}
@@ -0,0 +1,69 @@
+package create_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/tags"
+	"financo/server/tags/queries/detailed_query"
+	"financo/server/tags/types/request"
+	"financo/server/tags/types/response"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		id  int64
+		res response.Detailed
+	)
+
+	err := tags.Validate(c.req.Name, c.req.Color)
+	if err != nil {
+		return res, errors.Join(errors.New("invalid tag"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO tags(name, color, created_at, updated_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`,
+		strings.TrimSpace(c.req.Name),
+		c.req.Color,
+		c.timestamp,
+		c.timestamp,
+	).Scan(&id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted tag"), err)
+	}
+
+	return res, nil
+}
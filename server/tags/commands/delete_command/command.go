@@ -0,0 +1,71 @@
+package delete_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/tags/queries/detailed_query"
+	"financo/server/tags/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	id        int64
+	timestamp time.Time
+}
+
+func New(id int64) commands.Command[response.Detailed] {
+	return &command{
+		id:        id,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run marks the tag as deleted and unlinks it from every transaction, so it
+// doesn't show up in filters and summaries anymore.
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find tag"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM transaction_tags WHERE tag_id = $1", c.id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to unlink tag"), err, tx.Rollback())
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE tags SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL",
+		c.id,
+		c.timestamp,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to mark tag as deleted"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res.UpdatedAt = c.timestamp
+
+	return res, nil
+}
@@ -0,0 +1,71 @@
+package update_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/tags"
+	"financo/server/tags/queries/detailed_query"
+	"financo/server/tags/types/request"
+	"financo/server/tags/types/response"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Update
+	timestamp time.Time
+}
+
+func New(req request.Update) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	err := tags.Validate(c.req.Name, c.req.Color)
+	if err != nil {
+		return res, errors.Join(errors.New("invalid tag"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			UPDATE tags SET
+				name = $1,
+				color = $2,
+				updated_at = $3
+			WHERE deleted_at IS NULL AND id = $4
+			RETURNING id
+		`,
+		strings.TrimSpace(c.req.Name),
+		c.req.Color,
+		c.timestamp,
+		c.req.ID,
+	).Scan(&c.req.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve response"), err)
+	}
+
+	return res, nil
+}
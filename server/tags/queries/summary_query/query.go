@@ -0,0 +1,101 @@
+package summary_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/currency"
+	"financo/lib/money"
+	"financo/lib/nullable"
+	"financo/server/tags/types/response"
+	"financo/services/postgresql_database"
+	"fmt"
+	"time"
+)
+
+type query struct {
+	from nullable.Type[time.Time]
+	to   nullable.Type[time.Time]
+}
+
+// New returns, for every tag used by an executed transaction between from and
+// to, the sum of the source amounts of its transactions in each of the source
+// currencies.
+func New(from nullable.Type[time.Time], to nullable.Type[time.Time]) queries.Query[[]response.Summary] {
+	return &query{
+		from: from,
+		to:   to,
+	}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Summary, error) {
+	var (
+		query = `
+			SELECT
+				t.id,
+				t.name,
+				t.color,
+				src.currency,
+				SUM(tr.source_amount)
+			FROM tags t
+				INNER JOIN transaction_tags tt ON tt.tag_id = t.id
+				INNER JOIN transactions tr ON tr.id = tt.transaction_id
+				INNER JOIN accounts src ON src.id = tr.source_id
+			WHERE t.deleted_at IS NULL
+				AND tr.deleted_at IS NULL
+				AND tr.executed_at IS NOT NULL
+		`
+		res      = make([]response.Summary, 0, 20)
+		filters  = make([]any, 0, 2)
+		filter   = 1
+		postgres = postgresql_database.New()
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	if q.from.Valid {
+		filters = append(filters, q.from.Val)
+		query += fmt.Sprintf(" AND tr.executed_at >= $%d", filter)
+		filter++
+	}
+
+	if q.to.Valid {
+		filters = append(filters, q.to.Val)
+		query += fmt.Sprintf(" AND tr.executed_at <= $%d", filter)
+	}
+
+	query += " GROUP BY t.id, t.name, t.color, src.currency ORDER BY t.name, t.id, src.currency"
+
+	rows, err := conn.QueryContext(ctx, query, filters...)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row    response.Summary
+			cur    currency.Type
+			amount int64
+		)
+
+		err = rows.Scan(&row.ID, &row.Name, &row.Color, &cur, &amount)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		if len(res) > 0 && res[len(res)-1].ID == row.ID {
+			res[len(res)-1].Amounts = append(res[len(res)-1].Amounts, money.New(amount, cur))
+			continue
+		}
+
+		row.Amounts = []money.Money{money.New(amount, cur)}
+		res = append(res, row)
+	}
+
+	return res, nil
+}
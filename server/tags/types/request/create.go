@@ -0,0 +1,10 @@
+package request
+
+import (
+	"financo/lib/color"
+)
+
+type Create struct {
+	Name  string     `json:"name"`
+	Color color.Type `json:"color"`
+}
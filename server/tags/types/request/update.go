@@ -0,0 +1,11 @@
+package request
+
+import (
+	"financo/lib/color"
+)
+
+type Update struct {
+	ID    int64      `json:"id"`
+	Name  string     `json:"name"`
+	Color color.Type `json:"color"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"financo/lib/color"
+	"time"
+)
+
+type Detailed struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Color     color.Type `json:"color"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
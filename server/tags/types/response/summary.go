@@ -0,0 +1,15 @@
+package response
+
+import (
+	"financo/lib/color"
+	"financo/lib/money"
+)
+
+// Summary holds the totals of the transactions tagged with a tag, one per
+// currency.
+type Summary struct {
+	ID      int64         `json:"id"`
+	Name    string        `json:"name"`
+	Color   color.Type    `json:"color"`
+	Amounts []money.Money `json:"amounts"`
+}
@@ -0,0 +1,25 @@
+package tags
+
+import (
+	"errors"
+	"financo/lib/color"
+	"regexp"
+	"strings"
+)
+
+var colorPattern = regexp.MustCompile("^#[0-9a-fA-F]{6}$")
+
+// Validate checks that a tag can be persisted.
+//
+// It returns an error describing the first invalid field.
+func Validate(name string, c color.Type) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("tag name is required")
+	}
+
+	if !colorPattern.MatchString(string(c)) {
+		return errors.New("tag color must be an hex color")
+	}
+
+	return nil
+}
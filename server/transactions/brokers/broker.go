@@ -2,6 +2,7 @@ package brokers
 
 import (
 	"context"
+	"errors"
 	"financo/lib/message_bus"
 	"financo/server/transactions/types/message"
 	"fmt"
@@ -9,9 +10,12 @@ import (
 )
 
 type Broker interface {
-	SubscribeToCreated(consumer message_bus.Consumer[message.Created]) error
-	SubscribeToUpdated(consumer message_bus.Consumer[message.Updated]) error
-	SubscribeToDeleted(consumer message_bus.Consumer[message.Deleted]) error
+	SubscribeToCreated(consumer message_bus.Consumer[message.Created], opts ...message_bus.Option) error
+	SubscribeToUpdated(consumer message_bus.Consumer[message.Updated], opts ...message_bus.Option) error
+	SubscribeToDeleted(consumer message_bus.Consumer[message.Deleted], opts ...message_bus.Option) error
+	UnsubscribeFromCreated(name string) error
+	UnsubscribeFromUpdated(name string) error
+	UnsubscribeFromDeleted(name string) error
 	PublishCreated(msg message.Created) error
 	PublishUpdated(msg message.Updated) error
 	PublishDeleted(msg message.Deleted) error
@@ -39,47 +43,90 @@ func New(wg *sync.WaitGroup) Broker {
 		return instance
 	}
 
+	instance = newBroker(
+		wg,
+		message_bus.New[message.Created](wg, message.CreatedTopic),
+		message_bus.New[message.Updated](wg, message.UpdatedTopic),
+		message_bus.New[message.Deleted](wg, message.DeletedTopic),
+	)
+
+	return instance
+}
+
+// NewRedis is like [New] but the buses are backed by Redis streams, so every
+// process using it shares the messages, see [message_bus.NewRedis].
+func NewRedis(wg *sync.WaitGroup, dial message_bus.Dialer) Broker {
+	if instance != nil {
+		return instance
+	}
+
+	instance = newBroker(
+		wg,
+		message_bus.NewRedis[message.Created](wg, message.CreatedTopic, dial),
+		message_bus.NewRedis[message.Updated](wg, message.UpdatedTopic, dial),
+		message_bus.NewRedis[message.Deleted](wg, message.DeletedTopic, dial),
+	)
+
+	return instance
+}
+
+func newBroker(
+	wg *sync.WaitGroup,
+	createdBus message_bus.Bus[message.Created],
+	updatedBus message_bus.Bus[message.Updated],
+	deletedBus message_bus.Bus[message.Deleted],
+) *broker {
 	newCtx, cancel := context.WithCancel(context.Background())
 
-	instance = &broker{
+	return &broker{
 		ctx:        newCtx,
 		cancel:     cancel,
 		wg:         wg,
-		createdBus: message_bus.New[message.Created](wg, "account_created"),
-		updatedBus: message_bus.New[message.Updated](wg, "account_updated"),
-		deletedBus: message_bus.New[message.Deleted](wg, "account_deleted"),
+		createdBus: createdBus,
+		updatedBus: updatedBus,
+		deletedBus: deletedBus,
 	}
-
-	return instance
 }
 
-func (b *broker) SubscribeToCreated(consumer message_bus.Consumer[message.Created]) error {
+func (b *broker) SubscribeToCreated(consumer message_bus.Consumer[message.Created], opts ...message_bus.Option) error {
 	select {
 	case <-b.ctx.Done():
 		return fmt.Errorf("accounts: broker: %s", b.ctx.Err())
 	default:
-		return b.createdBus.Subscribe(consumer)
+		return b.createdBus.Subscribe(consumer, opts...)
 	}
 }
 
-func (b *broker) SubscribeToUpdated(consumer message_bus.Consumer[message.Updated]) error {
+func (b *broker) SubscribeToUpdated(consumer message_bus.Consumer[message.Updated], opts ...message_bus.Option) error {
 	select {
 	case <-b.ctx.Done():
 		return fmt.Errorf("accounts: broker: %s", b.ctx.Err())
 	default:
-		return b.updatedBus.Subscribe(consumer)
+		return b.updatedBus.Subscribe(consumer, opts...)
 	}
 }
 
-func (b *broker) SubscribeToDeleted(consumer message_bus.Consumer[message.Deleted]) error {
+func (b *broker) SubscribeToDeleted(consumer message_bus.Consumer[message.Deleted], opts ...message_bus.Option) error {
 	select {
 	case <-b.ctx.Done():
 		return fmt.Errorf("accounts: broker: %s", b.ctx.Err())
 	default:
-		return b.deletedBus.Subscribe(consumer)
+		return b.deletedBus.Subscribe(consumer, opts...)
 	}
 }
 
+func (b *broker) UnsubscribeFromCreated(name string) error {
+	return b.createdBus.Unsubscribe(name)
+}
+
+func (b *broker) UnsubscribeFromUpdated(name string) error {
+	return b.updatedBus.Unsubscribe(name)
+}
+
+func (b *broker) UnsubscribeFromDeleted(name string) error {
+	return b.deletedBus.Unsubscribe(name)
+}
+
 func (b *broker) PublishCreated(msg message.Created) error {
 	select {
 	case <-b.ctx.Done():
@@ -107,6 +154,8 @@ func (b *broker) PublishDeleted(msg message.Deleted) error {
 	}
 }
 
+// Shutdown stops accepting messages and closes the buses, their queues are
+// drained by workers tracked in the [*sync.WaitGroup] given to [New].
 func (b *broker) Shutdown() error {
 	select {
 	case <-b.ctx.Done():
@@ -114,6 +163,10 @@ func (b *broker) Shutdown() error {
 	default:
 		b.cancel()
 
-		return nil
+		return errors.Join(
+			b.createdBus.Close(),
+			b.updatedBus.Close(),
+			b.deletedBus.Close(),
+		)
 	}
 }
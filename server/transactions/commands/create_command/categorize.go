@@ -0,0 +1,79 @@
+package create_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/categorize"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/models/transaction"
+)
+
+// Execer is satisfied by both [sql.Conn] and [sql.Tx].
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Categorize applies the action of the first rule of engine matching a new
+// transaction.Record. The target is only replaced when the current one is an
+// external account, so transfers between own accounts are left untouched.
+//
+// It returns the categorized record and the tags it should be linked to once
+// persisted, or an error if its target can't be found.
+func Categorize(
+	ctx context.Context,
+	q Querier,
+	engine categorize.Engine,
+	record transaction.Record,
+) (transaction.Record, []int64, error) {
+	rule, ok := engine.Match(categorize.Candidate{
+		SourceID: record.SourceID,
+		Amount:   record.SourceAmount,
+		Notes:    record.Notes.Val,
+	})
+	if !ok {
+		return record, nil, nil
+	}
+
+	if rule.Action.TargetID.Valid && rule.Action.TargetID.Val != record.SourceID {
+		target, err := findAccount(ctx, q, record.TargetID)
+		if err != nil {
+			return record, nil, errors.Join(errors.New("transaction target not found"), err)
+		}
+
+		if account.IsExternal(target.Kind) {
+			record.TargetID = rule.Action.TargetID.Val
+		}
+	}
+
+	if rule.Action.Notes.Valid {
+		record.Notes = nullable.New(rule.Action.Notes.Val)
+	}
+
+	return record, rule.Action.Tags, nil
+}
+
+// LinkTags links a persisted transaction to the given tags, the deleted ones
+// and the ones already linked are skipped.
+func LinkTags(ctx context.Context, e Execer, id int64, tags []int64) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := e.ExecContext(
+		ctx,
+		`
+			INSERT INTO transaction_tags(transaction_id, tag_id)
+			SELECT $1, id
+			FROM tags
+			WHERE deleted_at IS NULL
+				AND id = ANY ($2)
+			ON CONFLICT DO NOTHING
+		`,
+		id,
+		tags,
+	)
+
+	return err
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"financo/core/domain/commands"
 	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
 	"financo/models/account"
 	"financo/models/transaction"
-	"financo/server/transactions/brokers"
+	"financo/server/categorization_rules/queries/engine_query"
 	"financo/server/transactions/queries/detailed_query"
 	"financo/server/transactions/types/message"
 	"financo/server/transactions/types/request"
@@ -30,6 +32,10 @@ func New(req request.Create) commands.Command[response.Detailed] {
 }
 
 func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	if len(c.req.Legs) > 0 {
+		return c.runSplit(ctx)
+	}
+
 	var (
 		record = transaction.Record{
 			ID:           -1,
@@ -45,39 +51,29 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 			UpdatedAt:    c.timestamp,
 		}
 		postgres = postgresql_database.New()
-		broker   = brokers.New(nil)
 
-		source account.Record
-		target account.Record
-		res    response.Detailed
+		res response.Detailed
 	)
 
-	if record.SourceID == record.TargetID {
-		return res, errors.New("circular transaction")
-	}
-
 	conn, err := postgres.Conn(ctx)
 	if err != nil {
 		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
 	}
 	defer conn.Close()
 
-	source, err = c.findAccount(ctx, conn, record.SourceID)
+	engine, err := engine_query.New().Find(ctx)
 	if err != nil {
-		return res, errors.Join(errors.New("transaction source not found"), err)
+		return res, errors.Join(errors.New("failed to load categorization rules"), err)
 	}
 
-	target, err = c.findAccount(ctx, conn, record.TargetID)
+	record, tags, err := Categorize(ctx, conn, engine, record)
 	if err != nil {
-		return res, errors.Join(errors.New("transaction target not found"), err)
-	}
-
-	if target.Currency == source.Currency {
-		record.TargetAmount = record.SourceAmount
+		return res, err
 	}
 
-	if record.ExecutedAt.Valid {
-		record.ExecutedAt = nullable.New(record.ExecutedAt.Val.UTC())
+	record, err = Prepare(ctx, conn, record)
+	if err != nil {
+		return res, err
 	}
 
 	tx, err := conn.BeginTx(ctx, nil)
@@ -85,11 +81,24 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 		return res, errors.Join(errors.New("failed to begin database transaction"), err)
 	}
 
-	record, err = c.persistRecord(ctx, tx, record)
+	record, err = Persist(ctx, tx, record)
 	if err != nil {
 		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
 	}
 
+	err = LinkTags(ctx, tx, record.ID, tags)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to link tags"), err, tx.Rollback())
+	}
+
+	err = outbox.Write(ctx, tx, message.CreatedTopic, message.Created{
+		Record:    record,
+		RequestID: request_id.FromContext(ctx),
+	})
+	if err != nil {
+		return res, errors.Join(errors.New("failed to write created message"), err, tx.Rollback())
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return res, errors.Join(errors.New("failed to commit database transaction"), err)
@@ -100,13 +109,91 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 		return res, errors.Join(errors.New("failed to find persisted account"), err)
 	}
 
-	return res, broker.PublishCreated(message.Created{Record: record})
+	return res, nil
+}
+
+// Querier is satisfied by both [sql.Conn] and [sql.Tx].
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Prepare validates a new transaction.Record and normalizes it before it is
+// persisted. Every new transaction goes through it, so imported and generated
+// transactions follow the same rules as the ones created by hand.
+//
+// When the source and the target accounts share the same currency the
+// TargetAmount is set to the SourceAmount.
+//
+// It returns an error if the transaction is circular or if any of its accounts
+// can't be found.
+func Prepare(ctx context.Context, q Querier, record transaction.Record) (transaction.Record, error) {
+	if record.SourceID == record.TargetID {
+		return record, errors.New("circular transaction")
+	}
+
+	source, err := findAccount(ctx, q, record.SourceID)
+	if err != nil {
+		return record, errors.Join(errors.New("transaction source not found"), err)
+	}
+
+	target, err := findAccount(ctx, q, record.TargetID)
+	if err != nil {
+		return record, errors.Join(errors.New("transaction target not found"), err)
+	}
+
+	if target.Currency == source.Currency {
+		record.TargetAmount = record.SourceAmount
+	}
+
+	if record.ExecutedAt.Valid {
+		record.ExecutedAt = nullable.New(record.ExecutedAt.Val.UTC())
+	}
+
+	return record, nil
 }
 
-func (c *command) findAccount(ctx context.Context, conn *sql.Conn, id int64) (account.Record, error) {
+// Persist inserts a prepared transaction.Record and returns it with its ID.
+func Persist(ctx context.Context, q Querier, t transaction.Record) (transaction.Record, error) {
+	err := q.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO transactions(
+				source_id,
+				target_id,
+				source_amount,
+				target_amount,
+				notes,
+				issued_at,
+				executed_at,
+				statement_account_id,
+				external_id,
+				split_id,
+				created_at,
+				updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`,
+		t.SourceID,
+		t.TargetID,
+		t.SourceAmount,
+		t.TargetAmount,
+		t.Notes,
+		t.IssuedAt,
+		t.ExecutedAt,
+		t.StatementAccountID,
+		t.ExternalID,
+		t.SplitID,
+		t.CreatedAt,
+		t.UpdatedAt,
+	).Scan(&t.ID)
+
+	return t, err
+}
+
+func findAccount(ctx context.Context, q Querier, id int64) (account.Record, error) {
 	var record account.Record
 
-	err := conn.QueryRowContext(
+	err := q.QueryRowContext(
 		ctx,
 		`
 			SELECT
@@ -146,34 +233,3 @@ func (c *command) findAccount(ctx context.Context, conn *sql.Conn, id int64) (ac
 
 	return record, err
 }
-
-func (c *command) persistRecord(ctx context.Context, tx *sql.Tx, t transaction.Record) (transaction.Record, error) {
-	err := tx.QueryRowContext(
-		ctx,
-		`
-			INSERT INTO transactions(
-				source_id,
-				target_id,
-				source_amount,
-				target_amount,
-				notes,
-				issued_at,
-				executed_at,
-				created_at,
-				updated_at
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-			RETURNING id
-		`,
-		t.SourceID,
-		t.TargetID,
-		t.SourceAmount,
-		t.TargetAmount,
-		t.Notes,
-		t.IssuedAt,
-		t.ExecutedAt,
-		t.CreatedAt,
-		t.UpdatedAt,
-	).Scan(&t.ID)
-
-	return t, err
-}
@@ -0,0 +1,239 @@
+package create_command
+
+import (
+	"context"
+	"errors"
+	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
+	"financo/models/account"
+	"financo/models/transaction"
+	"financo/models/transaction_split"
+	"financo/server/transactions/queries/split_query"
+	"financo/server/transactions/types/message"
+	"financo/server/transactions/types/request"
+	"financo/server/transactions/types/response"
+	"financo/services/postgresql_database"
+	"fmt"
+)
+
+// ErrInvalidSplit is returned when a split transaction doesn't satisfy the
+// rules described in [PrepareSplit].
+var ErrInvalidSplit = errors.New("invalid split transaction")
+
+func (c *command) runSplit(ctx context.Context) (response.Detailed, error) {
+	var (
+		split = transaction_split.Record{
+			ID:           -1,
+			SourceID:     c.req.SourceID,
+			SourceAmount: c.req.SourceAmount,
+			Notes:        c.req.Notes,
+			IssuedAt:     c.req.IssuedAt.UTC(),
+			ExecutedAt:   c.req.ExecutedAt,
+			CreatedAt:    c.timestamp,
+			UpdatedAt:    c.timestamp,
+		}
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	split, legs, err := PrepareSplit(ctx, conn, split, c.req.Legs)
+	if err != nil {
+		return res, err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	split, legs, err = PersistSplit(ctx, tx, split, legs)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist split"), err, tx.Rollback())
+	}
+
+	for _, leg := range legs {
+		err = outbox.Write(ctx, tx, message.CreatedTopic, message.Created{
+			Record:    leg,
+			RequestID: request_id.FromContext(ctx),
+		})
+		if err != nil {
+			return res, errors.Join(errors.New("failed to write created message"), err, tx.Rollback())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err = split_query.New(split.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted split"), err)
+	}
+
+	return res, nil
+}
+
+// PrepareSplit validates a new transaction_split.Record and builds the
+// transaction.Record of each of its legs.
+//
+// The source must be a capital or debt account and every leg must target an
+// external expense account in the same currency. A split has at least two
+// legs, the amount of each of them is positive and they add up to the
+// SourceAmount of the split.
+//
+// It returns an error wrapping [ErrInvalidSplit] if any of the rules isn't
+// satisfied, or an error if any of its accounts can't be found.
+func PrepareSplit(
+	ctx context.Context,
+	q Querier,
+	split transaction_split.Record,
+	legs []request.Leg,
+) (transaction_split.Record, []transaction.Record, error) {
+	if len(legs) < 2 {
+		return split, nil, fmt.Errorf("%w: at least two legs are required", ErrInvalidSplit)
+	}
+
+	source, err := findAccount(ctx, q, split.SourceID)
+	if err != nil {
+		return split, nil, errors.Join(errors.New("split source not found"), err)
+	}
+
+	targets := make([]account.Record, 0, len(legs))
+
+	for i, leg := range legs {
+		target, err := findAccount(ctx, q, leg.TargetID)
+		if err != nil {
+			return split, nil, errors.Join(fmt.Errorf("leg %d target not found", i), err)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return buildLegs(split, source, targets, legs)
+}
+
+// buildLegs applies the rules of [PrepareSplit] to the split, its source and
+// the target of each leg, in the order of legs.
+func buildLegs(
+	split transaction_split.Record,
+	source account.Record,
+	targets []account.Record,
+	legs []request.Leg,
+) (transaction_split.Record, []transaction.Record, error) {
+	records := make([]transaction.Record, 0, len(legs))
+
+	if len(legs) < 2 {
+		return split, records, fmt.Errorf("%w: at least two legs are required", ErrInvalidSplit)
+	}
+
+	if account.IsExternal(source.Kind) || source.Kind == account.SystemHistoric {
+		return split, records, fmt.Errorf("%w: source must be a capital or debt account", ErrInvalidSplit)
+	}
+
+	var sum int64
+
+	for i, leg := range legs {
+		if leg.Amount <= 0 {
+			return split, records, fmt.Errorf("%w: leg %d amount must be positive", ErrInvalidSplit, i)
+		}
+
+		target := targets[i]
+
+		if target.Kind != account.ExternalExpense {
+			return split, records, fmt.Errorf("%w: leg %d target must be an expense account", ErrInvalidSplit, i)
+		}
+
+		if target.Currency != source.Currency {
+			return split, records, fmt.Errorf("%w: leg %d target must be in %s", ErrInvalidSplit, i, source.Currency)
+		}
+
+		sum += leg.Amount
+
+		records = append(records, transaction.Record{
+			ID:           -1,
+			SourceID:     split.SourceID,
+			TargetID:     leg.TargetID,
+			SourceAmount: leg.Amount,
+			TargetAmount: leg.Amount,
+			Notes:        leg.Notes,
+			IssuedAt:     split.IssuedAt,
+			ExecutedAt:   split.ExecutedAt,
+			CreatedAt:    split.CreatedAt,
+			UpdatedAt:    split.UpdatedAt,
+		})
+	}
+
+	if sum != split.SourceAmount {
+		return split, records, fmt.Errorf(
+			"%w: legs add up to %d instead of %d",
+			ErrInvalidSplit,
+			sum,
+			split.SourceAmount,
+		)
+	}
+
+	if split.ExecutedAt.Valid {
+		split.ExecutedAt = nullable.New(split.ExecutedAt.Val.UTC())
+	}
+
+	for i := range records {
+		records[i].ExecutedAt = split.ExecutedAt
+	}
+
+	return split, records, nil
+}
+
+// PersistSplit inserts a prepared transaction_split.Record and its legs, it
+// returns them with their IDs.
+func PersistSplit(
+	ctx context.Context,
+	q Querier,
+	split transaction_split.Record,
+	legs []transaction.Record,
+) (transaction_split.Record, []transaction.Record, error) {
+	err := q.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO transaction_splits(
+				source_id,
+				source_amount,
+				notes,
+				issued_at,
+				executed_at,
+				created_at,
+				updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`,
+		split.SourceID,
+		split.SourceAmount,
+		split.Notes,
+		split.IssuedAt,
+		split.ExecutedAt,
+		split.CreatedAt,
+		split.UpdatedAt,
+	).Scan(&split.ID)
+	if err != nil {
+		return split, legs, err
+	}
+
+	for i, leg := range legs {
+		leg.SplitID = nullable.New(split.ID)
+
+		legs[i], err = Persist(ctx, q, leg)
+		if err != nil {
+			return split, legs, err
+		}
+	}
+
+	return split, legs, nil
+}
@@ -0,0 +1,110 @@
+package create_command
+
+import (
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/models/transaction_split"
+	"financo/server/transactions/types/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLegs(t *testing.T) {
+	var (
+		executedAt = time.Date(2024, time.October, 15, 9, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+		split      = transaction_split.Record{
+			ID:           -1,
+			SourceID:     1,
+			SourceAmount: 3000,
+			IssuedAt:     time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC),
+			ExecutedAt:   nullable.New(executedAt),
+		}
+		checking  = account.Record{ID: 1, Kind: account.CapitalNormal, Currency: currency.CAD}
+		groceries = account.Record{ID: 2, Kind: account.ExternalExpense, Currency: currency.CAD}
+		pharmacy  = account.Record{ID: 3, Kind: account.ExternalExpense, Currency: currency.CAD}
+	)
+
+	tests := []struct {
+		name    string
+		source  account.Record
+		targets []account.Record
+		legs    []request.Leg
+		valid   bool
+	}{
+		{
+			name:    "legs add up to the split amount",
+			source:  checking,
+			targets: []account.Record{groceries, pharmacy},
+			legs:    []request.Leg{{TargetID: 2, Amount: 2000}, {TargetID: 3, Amount: 1000}},
+			valid:   true,
+		},
+		{
+			name:    "single leg",
+			source:  checking,
+			targets: []account.Record{groceries},
+			legs:    []request.Leg{{TargetID: 2, Amount: 3000}},
+		},
+		{
+			name:    "legs add up to less than the split amount",
+			source:  checking,
+			targets: []account.Record{groceries, pharmacy},
+			legs:    []request.Leg{{TargetID: 2, Amount: 2000}, {TargetID: 3, Amount: 999}},
+		},
+		{
+			name:    "legs add up to more than the split amount",
+			source:  checking,
+			targets: []account.Record{groceries, pharmacy},
+			legs:    []request.Leg{{TargetID: 2, Amount: 2000}, {TargetID: 3, Amount: 1001}},
+		},
+		{
+			name:    "negative leg compensated by another",
+			source:  checking,
+			targets: []account.Record{groceries, pharmacy},
+			legs:    []request.Leg{{TargetID: 2, Amount: 4000}, {TargetID: 3, Amount: -1000}},
+		},
+		{
+			name:    "external source",
+			source:  account.Record{ID: 1, Kind: account.ExternalIncome, Currency: currency.CAD},
+			targets: []account.Record{groceries, pharmacy},
+			legs:    []request.Leg{{TargetID: 2, Amount: 2000}, {TargetID: 3, Amount: 1000}},
+		},
+		{
+			name:    "target is not an expense",
+			source:  checking,
+			targets: []account.Record{groceries, {ID: 3, Kind: account.CapitalSavings, Currency: currency.CAD}},
+			legs:    []request.Leg{{TargetID: 2, Amount: 2000}, {TargetID: 3, Amount: 1000}},
+		},
+		{
+			name:    "target in another currency",
+			source:  checking,
+			targets: []account.Record{groceries, {ID: 3, Kind: account.ExternalExpense, Currency: currency.USD}},
+			legs:    []request.Leg{{TargetID: 2, Amount: 2000}, {TargetID: 3, Amount: 1000}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, records, err := buildLegs(split, tt.source, tt.targets, tt.legs)
+
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidSplit)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, executedAt.UTC(), res.ExecutedAt.Val)
+			assert.Len(t, records, len(tt.legs))
+
+			for i, record := range records {
+				assert.Equal(t, split.SourceID, record.SourceID)
+				assert.Equal(t, tt.legs[i].TargetID, record.TargetID)
+				assert.Equal(t, tt.legs[i].Amount, record.SourceAmount)
+				assert.Equal(t, tt.legs[i].Amount, record.TargetAmount)
+				assert.Equal(t, res.ExecutedAt, record.ExecutedAt)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"financo/core/domain/commands"
 	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
+	"financo/models/deletion"
 	"financo/models/transaction"
-	"financo/server/transactions/brokers"
 	"financo/server/transactions/queries/detailed_query"
 	"financo/server/transactions/types/message"
 	"financo/server/transactions/types/response"
@@ -27,14 +29,14 @@ func New(id int64) commands.Command[response.Detailed] {
 	}
 }
 
+// Run soft deletes the transaction, a leg of a split transaction is deleted
+// along with the split and its other legs so they are restored as a whole.
 func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 	var (
 		findTransactionQuery = detailed_query.New(c.id)
 		postgres             = postgresql_database.New()
-		broker               = brokers.New(nil)
 
 		res response.Detailed
-		msg message.Deleted
 	)
 
 	conn, err := postgres.Conn(ctx)
@@ -43,18 +45,13 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 	}
 	defer conn.Close()
 
-	record, err := c.findTransaction(ctx, conn)
+	records, err := c.findTransactions(ctx, conn)
 	if err != nil {
 		return res, errors.Join(errors.New("failed to find record"), err)
 	}
-
-	msg.ID = record.ID
-	msg.PreviousState = record
-
-	record.UpdatedAt = c.timestamp
-	record.DeletedAt = nullable.New(c.timestamp)
-
-	msg.CurrentState = record
+	if len(records) == 0 {
+		return res, errors.Join(errors.New("failed to find record"), sql.ErrNoRows)
+	}
 
 	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
@@ -63,11 +60,29 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 
 	res.UpdatedAt = c.timestamp
 
-	err = c.markTransactionAsDeleted(ctx, tx)
+	err = c.markTransactionsAsDeleted(ctx, tx, records)
 	if err != nil {
 		return res, errors.Join(errors.New("failed to mark transaction as deleted"), err, tx.Rollback())
 	}
 
+	for _, record := range records {
+		msg := message.Deleted{
+			ID:            record.ID,
+			PreviousState: record,
+			RequestID:     request_id.FromContext(ctx),
+		}
+
+		record.UpdatedAt = c.timestamp
+		record.DeletedAt = nullable.New(c.timestamp)
+
+		msg.CurrentState = record
+
+		err = outbox.Write(ctx, tx, message.DeletedTopic, msg)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to write deleted message"), err, tx.Rollback())
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return res, errors.Join(errors.New("failed to commit transaction"), err)
@@ -78,24 +93,61 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 		return res, errors.Join(errors.New("failed to find transaction"), err)
 	}
 
-	return res, broker.PublishDeleted(msg)
+	return res, nil
 }
 
-func (c *command) markTransactionAsDeleted(ctx context.Context, tx *sql.Tx) error {
-	_, err := tx.ExecContext(
+func (c *command) markTransactionsAsDeleted(ctx context.Context, tx *sql.Tx, records []transaction.Record) error {
+	var (
+		deletionID int64
+		ids        = make([]int64, 0, len(records))
+	)
+
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+
+	err := tx.QueryRowContext(
 		ctx,
-		"UPDATE transactions SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL",
+		"INSERT INTO deletions (kind, record_id, deleted_at) VALUES ($1, $2, $3) RETURNING id",
+		deletion.Transaction,
 		c.id,
 		c.timestamp,
+	).Scan(&deletionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE transactions SET deleted_at = $2, updated_at = $2, deletion_id = $3 WHERE id = ANY ($1) AND deleted_at IS NULL",
+		ids,
+		c.timestamp,
+		deletionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !records[0].SplitID.Valid {
+		return nil
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE transaction_splits SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL",
+		records[0].SplitID.Val,
+		c.timestamp,
 	)
 
 	return err
 }
 
-func (c *command) findTransaction(ctx context.Context, conn *sql.Conn) (transaction.Record, error) {
-	var record transaction.Record
+// findTransactions returns the transaction, or every leg of its split when
+// it is a leg of a split transaction.
+func (c *command) findTransactions(ctx context.Context, conn *sql.Conn) ([]transaction.Record, error) {
+	var output = make([]transaction.Record, 0, 2)
 
-	err := conn.QueryRowContext(
+	rows, err := conn.QueryContext(
 		ctx,
 		`
 			SELECT
@@ -107,27 +159,48 @@ func (c *command) findTransaction(ctx context.Context, conn *sql.Conn) (transact
 				notes,
 				issued_at,
 				executed_at,
+				split_id,
 				deleted_at,
 				created_at,
 				updated_at
 			FROM transactions
 			WHERE deleted_at IS NULL
-				AND id = $1
+				AND (
+					id = $1
+					OR split_id = (SELECT split_id FROM transactions WHERE deleted_at IS NULL AND id = $1)
+				)
+			ORDER BY id
 		`,
 		c.id,
-	).Scan(
-		&record.ID,
-		&record.SourceID,
-		&record.TargetID,
-		&record.SourceAmount,
-		&record.TargetAmount,
-		&record.Notes,
-		&record.IssuedAt,
-		&record.ExecutedAt,
-		&record.DeletedAt,
-		&record.CreatedAt,
-		&record.UpdatedAt,
 	)
+	if err != nil {
+		return output, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record transaction.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.SourceID,
+			&record.TargetID,
+			&record.SourceAmount,
+			&record.TargetAmount,
+			&record.Notes,
+			&record.IssuedAt,
+			&record.ExecutedAt,
+			&record.SplitID,
+			&record.DeletedAt,
+			&record.CreatedAt,
+			&record.UpdatedAt,
+		)
+		if err != nil {
+			return output, err
+		}
+
+		output = append(output, record)
+	}
 
-	return record, err
+	return output, rows.Err()
 }
@@ -0,0 +1,351 @@
+package import_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
+	"financo/lib/statement"
+	"financo/models/account"
+	"financo/models/import_profile"
+	"financo/models/transaction"
+	"financo/server/categorization_rules/queries/engine_query"
+	"financo/server/transactions/commands/create_command"
+	"financo/server/transactions/queries/detailed_query"
+	"financo/server/transactions/types/message"
+	"financo/server/transactions/types/request"
+	"financo/server/transactions/types/response"
+	"financo/services/postgresql_database"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Import
+	statement io.Reader
+	timestamp time.Time
+}
+
+// New returns a command that imports a bank statement into the account
+// req.AccountID using the import profile req.ProfileID. Every statement entry
+// is validated like a transaction created by hand and all of them are created
+// in a single database transaction, if one fails none is created.
+//
+// Statements can be CSV, parsed with the profile's CSV mapping, OFX/QFX or
+// CAMT.053. Entries carrying a bank identifier that was already imported into
+// the account are skipped, so importing the same statement twice doesn't
+// duplicate transactions.
+//
+// Categorization rules run on every entry after the profile picked its counter
+// account, see [create_command.Categorize].
+//
+// When req.Preview is true the entries are validated but nothing is created.
+func New(req request.Import, statement io.Reader) commands.Command[response.Import] {
+	return &command{
+		req:       req,
+		statement: statement,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Import, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res = response.Import{
+			Preview:      c.req.Preview,
+			Entries:      make([]response.ImportEntry, 0, 50),
+			Transactions: make([]response.Detailed, 0, 50),
+		}
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	profile, err := c.findProfile(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("import profile not found"), err)
+	}
+
+	acc, err := c.findAccount(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("statement account not found"), err)
+	}
+
+	if account.IsExternal(acc.Kind) {
+		return res, errors.New("statements can only be imported into capital or debt accounts")
+	}
+
+	entries, err := c.parse(profile.Mapping, acc.Currency.Exponent())
+	if err != nil {
+		return res, errors.Join(errors.New("failed to parse statement"), err)
+	}
+
+	if len(entries) == 0 {
+		return res, errors.New("statement has no entries")
+	}
+
+	engine, err := engine_query.New().Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to load categorization rules"), err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	records := make([]transaction.Record, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+
+	for i, entry := range entries {
+		if entry.ExternalID != "" {
+			imported, err := c.alreadyImported(ctx, tx, entry.ExternalID)
+			if err != nil {
+				return res, errors.Join(fmt.Errorf("failed to look up statement entry %d", i+1), err, tx.Rollback())
+			}
+
+			if imported || seen[entry.ExternalID] {
+				res.Duplicated++
+				continue
+			}
+
+			seen[entry.ExternalID] = true
+		}
+
+		record, err := c.buildRecord(profile.Mapping, entry)
+		if err != nil {
+			return res, errors.Join(fmt.Errorf("failed to map statement entry %d", i+1), err, tx.Rollback())
+		}
+
+		record, tags, err := create_command.Categorize(ctx, tx, engine, record)
+		if err != nil {
+			return res, errors.Join(fmt.Errorf("failed to categorize statement entry %d", i+1), err, tx.Rollback())
+		}
+
+		record, err = create_command.Prepare(ctx, tx, record)
+		if err != nil {
+			return res, errors.Join(fmt.Errorf("invalid statement entry %d", i+1), err, tx.Rollback())
+		}
+
+		if !c.req.Preview {
+			record, err = create_command.Persist(ctx, tx, record)
+			if err != nil {
+				return res, errors.Join(fmt.Errorf("failed to persist statement entry %d", i+1), err, tx.Rollback())
+			}
+
+			err = outbox.Write(ctx, tx, message.CreatedTopic, message.Created{
+				Record:    record,
+				RequestID: request_id.FromContext(ctx),
+			})
+			if err != nil {
+				return res, errors.Join(fmt.Errorf("failed to write created message of statement entry %d", i+1), err, tx.Rollback())
+			}
+
+			err = create_command.LinkTags(ctx, tx, record.ID, tags)
+			if err != nil {
+				return res, errors.Join(fmt.Errorf("failed to tag statement entry %d", i+1), err, tx.Rollback())
+			}
+		}
+
+		records = append(records, record)
+		res.Entries = append(res.Entries, response.ImportEntry{
+			ExternalID:   record.ExternalID,
+			IssuedAt:     record.IssuedAt,
+			ExecutedAt:   record.ExecutedAt,
+			Notes:        record.Notes,
+			SourceID:     record.SourceID,
+			TargetID:     record.TargetID,
+			SourceAmount: record.SourceAmount,
+			TargetAmount: record.TargetAmount,
+		})
+	}
+
+	if c.req.Preview {
+		return res, tx.Rollback()
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	for _, record := range records {
+		detailed, err := detailed_query.New(record.ID).Find(ctx)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to find persisted transaction"), err)
+		}
+
+		res.Transactions = append(res.Transactions, detailed)
+	}
+
+	return res, nil
+}
+
+// parse reads the statement with the parser of the requested format, amounts
+// are read in minor units of a currency with the given exponent.
+func (c *command) parse(mapping import_profile.Mapping, exponent int) ([]statement.Entry, error) {
+	switch c.req.Format {
+	case statement.OFX:
+		return statement.ParseOFX(c.statement, exponent)
+	case statement.CAMT053:
+		return statement.ParseCAMT053(c.statement, exponent)
+	case statement.CSV, "":
+		if !mapping.CSV.Valid {
+			return nil, errors.New("import profile has no csv mapping")
+		}
+
+		return statement.ParseCSV(c.statement, mapping.CSV.Val, exponent)
+	default:
+		return nil, fmt.Errorf("unsupported statement format \"%s\"", c.req.Format)
+	}
+}
+
+// alreadyImported reports if a statement entry with the given externalID has
+// already been imported into the statement's account, even if it was deleted
+// afterwards.
+func (c *command) alreadyImported(ctx context.Context, tx *sql.Tx, externalID string) (bool, error) {
+	var exists bool
+
+	err := tx.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM transactions WHERE statement_account_id = $1 AND external_id = $2)",
+		c.req.AccountID,
+		externalID,
+	).Scan(&exists)
+
+	return exists, err
+}
+
+// buildRecord maps a statement entry onto a transaction.Record between the
+// statement's account and the counter account picked by the profile's
+// mapping.
+func (c *command) buildRecord(mapping import_profile.Mapping, entry statement.Entry) (transaction.Record, error) {
+	var (
+		record = transaction.Record{
+			ID:                 -1,
+			SourceID:           c.req.AccountID,
+			TargetID:           c.req.AccountID,
+			SourceAmount:       entry.Amount,
+			TargetAmount:       entry.Amount,
+			IssuedAt:           entry.Date,
+			ExecutedAt:         nullable.New(entry.Date),
+			StatementAccountID: nullable.New(c.req.AccountID),
+			DeletedAt:          nullable.Type[time.Time]{},
+			CreatedAt:          c.timestamp,
+			UpdatedAt:          c.timestamp,
+		}
+
+		counter nullable.Type[int64]
+	)
+
+	if entry.Notes != "" {
+		record.Notes = nullable.New(entry.Notes)
+	}
+
+	if entry.ExternalID != "" {
+		record.ExternalID = nullable.New(entry.ExternalID)
+	}
+
+	for _, rule := range mapping.Rules {
+		if strings.Contains(strings.ToLower(entry.Notes), strings.ToLower(rule.Contains)) {
+			counter = nullable.New(rule.AccountID)
+			break
+		}
+	}
+
+	if entry.Amount < 0 {
+		record.SourceAmount = -entry.Amount
+		record.TargetAmount = -entry.Amount
+
+		if !counter.Valid {
+			counter = mapping.DefaultOutflowID
+		}
+
+		record.TargetID = counter.Val
+	} else {
+		if !counter.Valid {
+			counter = mapping.DefaultInflowID
+		}
+
+		record.SourceID = counter.Val
+	}
+
+	if !counter.Valid {
+		return record, fmt.Errorf("no counter account matches \"%s\"", entry.Notes)
+	}
+
+	return record, nil
+}
+
+func (c *command) findProfile(ctx context.Context, conn *sql.Conn) (import_profile.Record, error) {
+	var record import_profile.Record
+
+	err := conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				name,
+				mapping,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM import_profiles
+			WHERE deleted_at IS NULL
+				AND id = $1
+		`,
+		c.req.ProfileID,
+	).Scan(
+		&record.ID,
+		&record.Name,
+		&record.Mapping,
+		&record.DeletedAt,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+
+	return record, err
+}
+
+func (c *command) findAccount(ctx context.Context, conn *sql.Conn) (account.Record, error) {
+	var record account.Record
+
+	err := conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				kind,
+				currency,
+				name,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+			FROM accounts
+			WHERE deleted_at IS NULL
+				AND id = $1
+		`,
+		c.req.AccountID,
+	).Scan(
+		&record.ID,
+		&record.Kind,
+		&record.Currency,
+		&record.Name,
+		&record.ArchivedAt,
+		&record.DeletedAt,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+
+	return record, err
+}
@@ -0,0 +1,115 @@
+package tag_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/transactions/queries/detailed_query"
+	"financo/server/transactions/types/request"
+	"financo/server/transactions/types/response"
+	"financo/services/postgresql_database"
+	"fmt"
+)
+
+type command struct {
+	req request.Tag
+}
+
+// New replaces the tags of a transaction with the ones of req.
+func New(req request.Tag) commands.Command[response.Detailed] {
+	return &command{
+		req: req,
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		tags     = unique(c.req.Tags)
+
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	err = tx.QueryRowContext(
+		ctx,
+		"SELECT id FROM transactions WHERE deleted_at IS NULL AND id = $1",
+		c.req.ID,
+	).Scan(&c.req.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find transaction"), err, tx.Rollback())
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM transaction_tags WHERE transaction_id = $1", c.req.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to unlink tags"), err, tx.Rollback())
+	}
+
+	if len(tags) > 0 {
+		result, err := tx.ExecContext(
+			ctx,
+			`
+				INSERT INTO transaction_tags(transaction_id, tag_id)
+				SELECT $1, id
+				FROM tags
+				WHERE deleted_at IS NULL
+					AND id = ANY ($2)
+			`,
+			c.req.ID,
+			tags,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to link tags"), err, tx.Rollback())
+		}
+
+		linked, err := result.RowsAffected()
+		if err != nil {
+			return res, errors.Join(errors.New("failed to count linked tags"), err, tx.Rollback())
+		}
+
+		if linked != int64(len(tags)) {
+			return res, errors.Join(
+				fmt.Errorf("%d of the %d tags not found", int64(len(tags))-linked, len(tags)),
+				tx.Rollback(),
+			)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	res, err = detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find tagged transaction"), err)
+	}
+
+	return res, nil
+}
+
+func unique(ids []int64) []int64 {
+	var (
+		out  = make([]int64, 0, len(ids))
+		seen = make(map[int64]bool, len(ids))
+	)
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+
+	return out
+}
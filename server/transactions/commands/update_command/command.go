@@ -6,17 +6,25 @@ import (
 	"errors"
 	"financo/core/domain/commands"
 	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
 	"financo/models/account"
 	"financo/models/transaction"
-	"financo/server/transactions/brokers"
+	"financo/models/transaction_split"
 	"financo/server/transactions/queries/detailed_query"
 	"financo/server/transactions/types/message"
 	"financo/server/transactions/types/request"
 	"financo/server/transactions/types/response"
 	"financo/services/postgresql_database"
+	"fmt"
 	"time"
 )
 
+// ErrInvalidLeg is returned when an update would move a leg of a split
+// transaction to another source or an account that can't be the target of a
+// split.
+var ErrInvalidLeg = errors.New("invalid split leg")
+
 type command struct {
 	req       request.Update
 	timestamp time.Time
@@ -32,7 +40,6 @@ func New(req request.Update) commands.Command[response.Detailed] {
 func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 	var (
 		postgres = postgresql_database.New()
-		broker   = brokers.New(nil)
 
 		res response.Detailed
 		msg message.Updated
@@ -56,11 +63,71 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 		return res, errors.Join(errors.New("source account not found"), err)
 	}
 
-	target, err := c.findAccount(ctx, conn, c.req.SourceID)
+	target, err := c.findAccount(ctx, conn, c.req.TargetID)
 	if err != nil {
 		return res, errors.Join(errors.New("target account not found"), err)
 	}
 
+	if record.SplitID.Valid {
+		err = c.validateLeg(record, source, target)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	record = c.apply(record, source, target)
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin transaction"), err)
+	}
+
+	if record.SplitID.Valid {
+		split, err := c.lockSplit(ctx, tx, record.SplitID.Val)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to lock split"), err, tx.Rollback())
+		}
+
+		record = withSplitDates(record, split)
+	}
+
+	err = c.persistRecord(ctx, tx, record)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+	}
+
+	if record.SplitID.Valid {
+		err = c.updateSplitAmount(ctx, tx, record.SplitID.Val)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to update split amount"), err, tx.Rollback())
+		}
+	}
+
+	msg.ID = record.ID
+	msg.CurrentState = record
+	msg.RequestID = request_id.FromContext(ctx)
+
+	err = outbox.Write(ctx, tx, message.UpdatedTopic, msg)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to write updated message"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+
+	res, err = detailed_query.New(record.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve response"), err)
+	}
+
+	return res, nil
+}
+
+// apply returns the record updated with the request, the target amount of a
+// transaction between accounts of the same currency is its source amount.
+func (c *command) apply(record transaction.Record, source, target account.Record) transaction.Record {
 	record.SourceID = c.req.SourceID
 	record.TargetID = c.req.TargetID
 	record.SourceAmount = c.req.SourceAmount
@@ -77,30 +144,84 @@ func (c *command) Run(ctx context.Context) (response.Detailed, error) {
 		record.ExecutedAt = nullable.New(c.req.ExecutedAt.Val.UTC())
 	}
 
-	tx, err := conn.BeginTx(ctx, nil)
-	if err != nil {
-		return res, errors.Join(errors.New("failed to begin transaction"), err)
+	return record
+}
+
+// validateLeg checks that an updated leg keeps the source of its split and
+// still targets an external expense account in the source currency. The
+// amount of the split is recomputed from its legs and the leg keeps the dates
+// of the split, see [withSplitDates].
+func (c *command) validateLeg(record transaction.Record, source, target account.Record) error {
+	if c.req.SourceID != record.SourceID {
+		return fmt.Errorf("%w: the source of a leg can't be changed", ErrInvalidLeg)
 	}
 
-	err = c.persistRecord(ctx, tx, record)
-	if err != nil {
-		return res, errors.Join(errors.New("failed to persist record"), err, tx.Rollback())
+	if c.req.SourceAmount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidLeg)
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return res, errors.Join(errors.New("failed to commit transaction"), err)
+	if target.Kind != account.ExternalExpense {
+		return fmt.Errorf("%w: target must be an expense account", ErrInvalidLeg)
 	}
 
-	res, err = detailed_query.New(record.ID).Find(ctx)
-	if err != nil {
-		return res, errors.Join(errors.New("failed to retrieve response"), err)
+	if target.Currency != source.Currency {
+		return fmt.Errorf("%w: target must be in %s", ErrInvalidLeg, source.Currency)
 	}
 
-	msg.ID = record.ID
-	msg.CurrentState = record
+	return nil
+}
+
+// withSplitDates returns the leg with the dates of its split, the legs of a
+// split are issued and executed together.
+func withSplitDates(leg transaction.Record, split transaction_split.Record) transaction.Record {
+	leg.IssuedAt = split.IssuedAt
+	leg.ExecutedAt = split.ExecutedAt
+
+	return leg
+}
+
+func (c *command) lockSplit(ctx context.Context, tx *sql.Tx, id int64) (transaction_split.Record, error) {
+	var record transaction_split.Record
+
+	err := tx.QueryRowContext(
+		ctx,
+		`
+			SELECT id, source_id, source_amount, notes, issued_at, executed_at
+			FROM transaction_splits
+			WHERE deleted_at IS NULL AND id = $1
+			FOR UPDATE
+		`,
+		id,
+	).Scan(
+		&record.ID,
+		&record.SourceID,
+		&record.SourceAmount,
+		&record.Notes,
+		&record.IssuedAt,
+		&record.ExecutedAt,
+	)
+
+	return record, err
+}
+
+func (c *command) updateSplitAmount(ctx context.Context, tx *sql.Tx, id int64) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`
+			UPDATE transaction_splits SET
+				source_amount = (
+					SELECT COALESCE(SUM(source_amount), 0)
+					FROM transactions
+					WHERE deleted_at IS NULL AND split_id = $1
+				),
+				updated_at = $2
+			WHERE id = $1
+		`,
+		id,
+		c.timestamp,
+	)
 
-	return res, broker.PublishUpdated(msg)
+	return err
 }
 
 func (c *command) findAccount(ctx context.Context, conn *sql.Conn, id int64) (account.Record, error) {
@@ -162,6 +283,7 @@ func (c *command) findTransaction(ctx context.Context, conn *sql.Conn) (transact
 				notes,
 				issued_at,
 				executed_at,
+				split_id,
 				deleted_at,
 				created_at,
 				updated_at
@@ -179,6 +301,7 @@ func (c *command) findTransaction(ctx context.Context, conn *sql.Conn) (transact
 		&record.Notes,
 		&record.IssuedAt,
 		&record.ExecutedAt,
+		&record.SplitID,
 		&record.DeletedAt,
 		&record.CreatedAt,
 		&record.UpdatedAt,
@@ -0,0 +1,69 @@
+package update_command
+
+import (
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"financo/models/transaction"
+	"financo/models/transaction_split"
+	"financo/server/transactions/types/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	var (
+		checking = account.Record{ID: 1, Kind: account.CapitalNormal, Currency: currency.CAD}
+		savings  = account.Record{ID: 2, Kind: account.CapitalSavings, Currency: currency.CAD}
+		dollars  = account.Record{ID: 3, Kind: account.CapitalNormal, Currency: currency.USD}
+	)
+
+	tests := []struct {
+		name   string
+		target account.Record
+		amount int64
+	}{
+		{name: "same currency", target: savings, amount: 1000},
+		{name: "cross currency keeps the target amount", target: dollars, amount: 730},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(request.Update{
+				ID:           10,
+				IssuedAt:     time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC),
+				SourceID:     checking.ID,
+				TargetID:     tt.target.ID,
+				SourceAmount: 1000,
+				TargetAmount: 730,
+			}).(*command)
+
+			record := c.apply(transaction.Record{ID: 10}, checking, tt.target)
+
+			assert.Equal(t, checking.ID, record.SourceID)
+			assert.Equal(t, tt.target.ID, record.TargetID)
+			assert.Equal(t, int64(1000), record.SourceAmount)
+			assert.Equal(t, tt.amount, record.TargetAmount)
+		})
+	}
+}
+
+func TestWithSplitDates(t *testing.T) {
+	var (
+		issuedAt   = time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+		executedAt = time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC)
+		split      = transaction_split.Record{ID: 7, IssuedAt: issuedAt, ExecutedAt: nullable.New(executedAt)}
+	)
+
+	leg := withSplitDates(transaction.Record{
+		ID:         10,
+		IssuedAt:   issuedAt.AddDate(0, 0, 5),
+		ExecutedAt: nullable.Type[time.Time]{},
+		SplitID:    nullable.New(split.ID),
+	}, split)
+
+	assert.Equal(t, issuedAt, leg.IssuedAt)
+	assert.Equal(t, nullable.New(executedAt), leg.ExecutedAt)
+}
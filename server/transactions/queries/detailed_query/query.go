@@ -79,10 +79,19 @@ func (q *query) Find(ctx context.Context) (response.Detailed, error) {
 		&row.TrgParentArchivedAt,
 		&row.TrgParentCreatedAt,
 		&row.TrgParentUpdatedAt,
+		&row.SplitID,
+		&row.SplitNotes,
 	)
 	if err != nil {
 		return res, errors.Join(errors.New("failed to execute ans scan query"), err)
 	}
 
-	return base.BuildTransactions(row), nil
+	res = base.BuildTransactions(row)
+
+	tagged, err := base.LoadTags(ctx, conn, []response.Detailed{res})
+	if err != nil {
+		return res, err
+	}
+
+	return tagged[0], nil
 }
@@ -6,6 +6,7 @@ import (
 	"financo/core/domain/queries"
 	"financo/lib/nullable"
 	base "financo/server/transactions/queries"
+	"financo/server/transactions/types/request"
 	"financo/server/transactions/types/response"
 	"financo/services/postgresql_database"
 	"fmt"
@@ -17,6 +18,8 @@ type query struct {
 	to         nullable.Type[time.Time]
 	accounts   []int64
 	categories []int64
+	tags       request.TagFilter
+	page       request.Page
 }
 
 func New(
@@ -24,19 +27,23 @@ func New(
 	to nullable.Type[time.Time],
 	accounts []int64,
 	categories []int64,
-) queries.Query[[]response.Detailed] {
+	tags request.TagFilter,
+	page request.Page,
+) queries.Query[response.Page] {
 	return &query{
 		from:       from,
 		to:         to,
 		accounts:   accounts,
 		categories: categories,
+		tags:       tags,
+		page:       page,
 	}
 }
 
-func (q *query) Find(ctx context.Context) ([]response.Detailed, error) {
+func (q *query) Find(ctx context.Context) (response.Page, error) {
 	var (
 		query    = base.BaseQueryList + " AND tr.executed_at IS NOT NULL"
-		res      = make([]response.Detailed, 0, 20)
+		res      = response.Page{Data: make([]response.Detailed, 0)}
 		filters  = make([]any, 0, 3)
 		filter   = 1
 		postgres = postgresql_database.New()
@@ -74,67 +81,24 @@ func (q *query) Find(ctx context.Context) ([]response.Detailed, error) {
 		filter++
 	}
 
-	rows, err := conn.QueryContext(ctx, query, filters...)
+	tagsQuery, tagsFilters, _ := base.TagFilterQuery(q.tags, filter)
+	query += tagsQuery
+	filters = append(filters, tagsFilters...)
+
+	found, next, prev, err := base.FindPage(ctx, conn, base.ByExecutedAt, q.page, query, filters)
 	if err != nil {
-		return res, errors.Join(errors.New("failed to execute query"), err)
+		return res, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var row base.BaseQueryListRow
 
-		err = rows.Scan(
-			&row.ID,
-			&row.IssuedAt,
-			&row.ExecutedAt,
-			&row.SourceAmount,
-			&row.TargetAmount,
-			&row.Notes,
-			&row.CreatedAt,
-			&row.UpdatedAt,
-			&row.SrcID,
-			&row.SrcKind,
-			&row.SrcCurrency,
-			&row.SrcName,
-			&row.SrcColor,
-			&row.SrcIcon,
-			&row.SrcArchivedAt,
-			&row.SrcCreatedAt,
-			&row.SrcUpdatedAt,
-			&row.SrcParentID,
-			&row.SrcParentKind,
-			&row.SrcParentCurrency,
-			&row.SrcParentName,
-			&row.SrcParentColor,
-			&row.SrcParentIcon,
-			&row.SrcParentArchivedAt,
-			&row.SrcParentCreatedAt,
-			&row.SrcParentUpdatedAt,
-			&row.TrgID,
-			&row.TrgKind,
-			&row.TrgCurrency,
-			&row.TrgName,
-			&row.TrgColor,
-			&row.TrgIcon,
-			&row.TrgArchivedAt,
-			&row.TrgCreatedAt,
-			&row.TrgUpdatedAt,
-			&row.TrgParentID,
-			&row.TrgParentKind,
-			&row.TrgParentCurrency,
-			&row.TrgParentName,
-			&row.TrgParentColor,
-			&row.TrgParentIcon,
-			&row.TrgParentArchivedAt,
-			&row.TrgParentCreatedAt,
-			&row.TrgParentUpdatedAt,
-		)
-		if err != nil {
-			return res, errors.Join(errors.New("failed to scan query row"), err)
-		}
+	data, err := base.BuildGroupedTransactions(found)
+	if err != nil {
+		return res, err
+	}
 
-		res = append(res, base.BuildTransactions(row))
+	data, err = base.LoadTags(ctx, conn, data)
+	if err != nil {
+		return res, err
 	}
 
-	return res, nil
+	return response.Page{Data: data, Next: next, Prev: prev}, nil
 }
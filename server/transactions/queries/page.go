@@ -0,0 +1,324 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/lib/cursor"
+	"financo/lib/nullable"
+	"financo/server/transactions/types/request"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SortKey is the date column a listing is paginated on, along with the row's
+// ID.
+type SortKey struct {
+	column string
+	value  func(row BaseQueryListRow) time.Time
+}
+
+var (
+	// ByExecutedAt paginates executed transactions.
+	ByExecutedAt = SortKey{
+		column: "tr.executed_at",
+		value:  func(row BaseQueryListRow) time.Time { return row.ExecutedAt.Val },
+	}
+	// ByIssuedAt paginates pending transactions, they aren't executed yet.
+	ByIssuedAt = SortKey{
+		column: "tr.issued_at",
+		value:  func(row BaseQueryListRow) time.Time { return row.IssuedAt },
+	}
+)
+
+// PageQuery returns the keyset condition, the order and the limit selecting
+// page, along with its arguments. One more row than the limit is selected to
+// know if there's a page after it.
+func PageQuery(key SortKey, page request.Page, index int) (string, []any) {
+	var (
+		query = ""
+		args  = make([]any, 0, 3)
+		order = "DESC"
+		cmp   = "<"
+	)
+
+	if page.Sort == request.Ascending {
+		order = "ASC"
+		cmp = ">"
+	}
+
+	// Previous pages are read backwards from the cursor and reversed in
+	// [Paginate].
+	if page.Cursor.Valid && page.Cursor.Val.Direction == cursor.Before {
+		order, cmp = reverse(order, cmp)
+	}
+
+	if page.Cursor.Valid {
+		query += fmt.Sprintf(" AND (%s, tr.id) %s ($%d, $%d)", key.column, cmp, index, index+1)
+		args = append(args, page.Cursor.Val.Key, page.Cursor.Val.ID)
+		index += 2
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, tr.id %s LIMIT $%d", key.column, order, order, index)
+	args = append(args, page.Limit+1)
+
+	return query, args
+}
+
+func reverse(order string, cmp string) (string, string) {
+	if order == "ASC" {
+		return "DESC", "<"
+	}
+
+	return "ASC", ">"
+}
+
+// FindPage selects the page of the rows matched by query, it is built from
+// [BaseQueryList] and its filters use the first len(args) placeholders.
+//
+// When a single split transaction fills the page, the rows are selected again
+// with a larger limit until every leg of the split is found. The legs of the
+// splits in the page that the filters of query left out are added as well, so
+// the entries of the splits add up to their whole amount.
+func FindPage(
+	ctx context.Context,
+	conn *sql.Conn,
+	key SortKey,
+	page request.Page,
+	query string,
+	args []any,
+) ([]BaseQueryListRow, nullable.Type[string], nullable.Type[string], error) {
+	selected := page
+
+	for {
+		pageQuery, pageArgs := PageQuery(key, selected, len(args)+1)
+
+		rows, err := selectRows(ctx, conn, query+pageQuery, append(slices.Clip(args), pageArgs...))
+		if err != nil {
+			return rows, nullable.Type[string]{}, nullable.Type[string]{}, err
+		}
+
+		found, next, prev, complete := Paginate(key, page, rows)
+
+		// Every row left in the listing is a leg of the split.
+		if !complete && len(rows) <= selected.Limit {
+			page.Limit = len(rows)
+			found, next, prev, complete = Paginate(key, page, rows)
+		}
+
+		if complete {
+			found, err = completeSplits(ctx, conn, found)
+
+			return found, next, prev, err
+		}
+
+		selected.Limit *= 2
+	}
+}
+
+// Paginate trims the rows selected with [PageQuery] to the page and builds
+// its cursors.
+//
+// The legs of a split transaction are kept in the same page: when the page
+// boundary falls inside a split, the split is left for the next page. A split
+// filling the whole page is kept whole instead, the page is then longer than
+// its limit. It returns false if the legs of that split continue past rows,
+// they have to be selected again with a larger limit.
+func Paginate(
+	key SortKey,
+	page request.Page,
+	rows []BaseQueryListRow,
+) ([]BaseQueryListRow, nullable.Type[string], nullable.Type[string], bool) {
+	var (
+		more      = len(rows) > page.Limit
+		backwards = page.Cursor.Valid && page.Cursor.Val.Direction == cursor.Before
+
+		next nullable.Type[string]
+		prev nullable.Type[string]
+	)
+
+	if more {
+		end := page.Limit
+
+		if boundary := rows[end]; boundary.SplitID.Valid {
+			for end > 0 && sameSplit(rows[end-1], boundary) {
+				end--
+			}
+
+			if end == 0 {
+				end = page.Limit
+
+				for end < len(rows) && sameSplit(rows[end], boundary) {
+					end++
+				}
+
+				if end == len(rows) {
+					return rows, next, prev, false
+				}
+			}
+		}
+
+		rows = rows[:end]
+	}
+
+	if backwards {
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+	}
+
+	if len(rows) == 0 {
+		return rows, next, prev, true
+	}
+
+	first, last := rows[0], rows[len(rows)-1]
+
+	if more || backwards {
+		next = nullable.New(cursor.New(key.value(last), last.ID, cursor.After).Encode())
+	}
+
+	if (backwards && more) || (!backwards && page.Cursor.Valid) {
+		prev = nullable.New(cursor.New(key.value(first), first.ID, cursor.Before).Encode())
+	}
+
+	return rows, next, prev, true
+}
+
+// completeSplits adds the legs of the splits in rows that rows misses.
+func completeSplits(ctx context.Context, conn *sql.Conn, rows []BaseQueryListRow) ([]BaseQueryListRow, error) {
+	var (
+		splits = make([]int64, 0, len(rows))
+		found  = make([]int64, 0, len(rows))
+	)
+
+	for _, row := range rows {
+		if row.SplitID.Valid {
+			splits = append(splits, row.SplitID.Val)
+			found = append(found, row.ID)
+		}
+	}
+
+	if len(splits) == 0 {
+		return rows, nil
+	}
+
+	legs, err := selectRows(
+		ctx,
+		conn,
+		BaseQueryList+" AND tr.split_id = ANY ($1) AND tr.id <> ALL ($2) ORDER BY tr.id",
+		[]any{splits, found},
+	)
+	if err != nil {
+		return rows, errors.Join(errors.New("failed to find split legs"), err)
+	}
+
+	return withLegs(rows, legs), nil
+}
+
+// withLegs inserts every leg right after the last row of its split in rows,
+// legs of splits missing from rows are dropped.
+func withLegs(rows []BaseQueryListRow, legs []BaseQueryListRow) []BaseQueryListRow {
+	if len(legs) == 0 {
+		return rows
+	}
+
+	var (
+		output  = make([]BaseQueryListRow, 0, len(rows)+len(legs))
+		last    = make(map[int64]int, len(rows))
+		bySplit = make(map[int64][]BaseQueryListRow, len(legs))
+	)
+
+	for i, row := range rows {
+		if row.SplitID.Valid {
+			last[row.SplitID.Val] = i
+		}
+	}
+
+	for _, leg := range legs {
+		bySplit[leg.SplitID.Val] = append(bySplit[leg.SplitID.Val], leg)
+	}
+
+	for i, row := range rows {
+		output = append(output, row)
+
+		if row.SplitID.Valid && last[row.SplitID.Val] == i {
+			output = append(output, bySplit[row.SplitID.Val]...)
+		}
+	}
+
+	return output
+}
+
+func sameSplit(row BaseQueryListRow, other BaseQueryListRow) bool {
+	return row.SplitID.Valid && other.SplitID.Valid && row.SplitID.Val == other.SplitID.Val
+}
+
+func selectRows(ctx context.Context, conn *sql.Conn, query string, args []any) ([]BaseQueryListRow, error) {
+	var output = make([]BaseQueryListRow, 0, 20)
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return output, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row BaseQueryListRow
+
+		err = rows.Scan(
+			&row.ID,
+			&row.IssuedAt,
+			&row.ExecutedAt,
+			&row.SourceAmount,
+			&row.TargetAmount,
+			&row.Notes,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+			&row.SrcID,
+			&row.SrcKind,
+			&row.SrcCurrency,
+			&row.SrcName,
+			&row.SrcColor,
+			&row.SrcIcon,
+			&row.SrcArchivedAt,
+			&row.SrcCreatedAt,
+			&row.SrcUpdatedAt,
+			&row.SrcParentID,
+			&row.SrcParentKind,
+			&row.SrcParentCurrency,
+			&row.SrcParentName,
+			&row.SrcParentColor,
+			&row.SrcParentIcon,
+			&row.SrcParentArchivedAt,
+			&row.SrcParentCreatedAt,
+			&row.SrcParentUpdatedAt,
+			&row.TrgID,
+			&row.TrgKind,
+			&row.TrgCurrency,
+			&row.TrgName,
+			&row.TrgColor,
+			&row.TrgIcon,
+			&row.TrgArchivedAt,
+			&row.TrgCreatedAt,
+			&row.TrgUpdatedAt,
+			&row.TrgParentID,
+			&row.TrgParentKind,
+			&row.TrgParentCurrency,
+			&row.TrgParentName,
+			&row.TrgParentColor,
+			&row.TrgParentIcon,
+			&row.TrgParentArchivedAt,
+			&row.TrgParentCreatedAt,
+			&row.TrgParentUpdatedAt,
+			&row.SplitID,
+			&row.SplitNotes,
+		)
+		if err != nil {
+			return output, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		output = append(output, row)
+	}
+
+	return output, rows.Err()
+}
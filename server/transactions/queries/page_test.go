@@ -0,0 +1,233 @@
+package queries
+
+import (
+	"financo/lib/cursor"
+	"financo/lib/nullable"
+	"financo/server/transactions/types/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	var (
+		after  = nullable.New(cursor.New(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), 10, cursor.After))
+		before = nullable.New(cursor.New(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), 10, cursor.Before))
+	)
+
+	tests := []struct {
+		name     string
+		page     request.Page
+		rows     []BaseQueryListRow
+		complete bool
+		ids      []int64
+		next     int64
+		prev     int64
+	}{
+		{
+			name:     "single page",
+			page:     request.Page{Limit: 3},
+			rows:     []BaseQueryListRow{row(1, 100), row(2, 100)},
+			complete: true,
+			ids:      []int64{1, 2},
+		},
+		{
+			name:     "first page",
+			page:     request.Page{Limit: 2},
+			rows:     []BaseQueryListRow{row(1, 100), row(2, 100), row(3, 100)},
+			complete: true,
+			ids:      []int64{1, 2},
+			next:     2,
+		},
+		{
+			name:     "next page",
+			page:     request.Page{Limit: 2, Cursor: after},
+			rows:     []BaseQueryListRow{row(3, 100), row(4, 100), row(5, 100)},
+			complete: true,
+			ids:      []int64{3, 4},
+			next:     4,
+			prev:     3,
+		},
+		{
+			name:     "last page",
+			page:     request.Page{Limit: 2, Cursor: after},
+			rows:     []BaseQueryListRow{row(3, 100)},
+			complete: true,
+			ids:      []int64{3},
+			prev:     3,
+		},
+		{
+			name:     "backward page",
+			page:     request.Page{Limit: 2, Cursor: before},
+			rows:     []BaseQueryListRow{row(9, 100), row(8, 100), row(7, 100)},
+			complete: true,
+			ids:      []int64{8, 9},
+			next:     9,
+			prev:     8,
+		},
+		{
+			name:     "backward first page",
+			page:     request.Page{Limit: 2, Cursor: before},
+			rows:     []BaseQueryListRow{row(9, 100), row(8, 100)},
+			complete: true,
+			ids:      []int64{8, 9},
+			next:     9,
+		},
+		{
+			name:     "empty backward page",
+			page:     request.Page{Limit: 2, Cursor: before},
+			rows:     []BaseQueryListRow{},
+			complete: true,
+			ids:      []int64{},
+		},
+		{
+			name:     "split after the boundary",
+			page:     request.Page{Limit: 2},
+			rows:     []BaseQueryListRow{row(1, 100), row(2, 100), row(3, 100, 7)},
+			complete: true,
+			ids:      []int64{1, 2},
+			next:     2,
+		},
+		{
+			name:     "split across the boundary",
+			page:     request.Page{Limit: 3},
+			rows:     []BaseQueryListRow{row(1, 100), row(2, 100, 7), row(3, 100, 7), row(4, 100, 7)},
+			complete: true,
+			ids:      []int64{1},
+			next:     1,
+		},
+		{
+			name:     "backward split across the boundary",
+			page:     request.Page{Limit: 2, Cursor: before},
+			rows:     []BaseQueryListRow{row(9, 100), row(8, 100, 7), row(7, 100, 7)},
+			complete: true,
+			ids:      []int64{9},
+			next:     9,
+			prev:     9,
+		},
+		{
+			name:     "split filling the page",
+			page:     request.Page{Limit: 2},
+			rows:     []BaseQueryListRow{row(1, 100, 7), row(2, 100, 7), row(3, 100, 7), row(4, 100), row(5, 100)},
+			complete: true,
+			ids:      []int64{1, 2, 3},
+			next:     3,
+		},
+		{
+			name:     "backward split filling the page",
+			page:     request.Page{Limit: 2, Cursor: before},
+			rows:     []BaseQueryListRow{row(9, 100, 7), row(8, 100, 7), row(7, 100, 7), row(6, 100)},
+			complete: true,
+			ids:      []int64{7, 8, 9},
+			next:     9,
+			prev:     7,
+		},
+		{
+			name:     "split continuing past the rows",
+			page:     request.Page{Limit: 2},
+			rows:     []BaseQueryListRow{row(1, 100, 7), row(2, 100, 7), row(3, 100, 7)},
+			complete: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, next, prev, complete := Paginate(ByIssuedAt, tt.page, tt.rows)
+
+			assert.Equal(t, tt.complete, complete)
+
+			if !complete {
+				return
+			}
+
+			ids := make([]int64, 0, len(rows))
+			for _, r := range rows {
+				ids = append(ids, r.ID)
+			}
+
+			assert.Equal(t, tt.ids, ids)
+			assertCursor(t, tt.next, cursor.After, next)
+			assertCursor(t, tt.prev, cursor.Before, prev)
+		})
+	}
+}
+
+func assertCursor(t *testing.T, id int64, direction cursor.Direction, raw nullable.Type[string]) {
+	if id == 0 {
+		assert.False(t, raw.Valid)
+		return
+	}
+
+	if !assert.True(t, raw.Valid) {
+		return
+	}
+
+	c, err := cursor.Decode(raw.Val)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, c.ID)
+	assert.Equal(t, direction, c.Direction)
+}
+
+func TestWithLegs(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   []BaseQueryListRow
+		legs   []BaseQueryListRow
+		ids    []int64
+		amount int64
+	}{
+		{
+			name:   "split complete",
+			rows:   []BaseQueryListRow{row(1, 100), row(2, 200, 7), row(3, 300, 7)},
+			legs:   []BaseQueryListRow{},
+			ids:    []int64{1, 2, 3},
+			amount: 500,
+		},
+		{
+			name:   "legs left out by the filters",
+			rows:   []BaseQueryListRow{row(2, 200, 7), row(5, 100)},
+			legs:   []BaseQueryListRow{row(3, 300, 7), row(4, 400, 7)},
+			ids:    []int64{2, 3, 4, 5},
+			amount: 900,
+		},
+		{
+			name:   "legs added after the last matched leg",
+			rows:   []BaseQueryListRow{row(2, 200, 7), row(5, 100), row(6, 600, 7)},
+			legs:   []BaseQueryListRow{row(3, 300, 7)},
+			ids:    []int64{2, 5, 6, 3},
+			amount: 1100,
+		},
+		{
+			name:   "legs of another split",
+			rows:   []BaseQueryListRow{row(2, 200, 7)},
+			legs:   []BaseQueryListRow{row(3, 300, 8)},
+			ids:    []int64{2},
+			amount: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := withLegs(tt.rows, tt.legs)
+
+			ids := make([]int64, 0, len(rows))
+			for _, r := range rows {
+				ids = append(ids, r.ID)
+			}
+
+			assert.Equal(t, tt.ids, ids)
+
+			res, err := BuildGroupedTransactions(rows)
+
+			assert.NoError(t, err)
+
+			for _, entry := range res {
+				if entry.SplitID.Valid && entry.SplitID.Val == 7 {
+					assert.Equal(t, tt.amount, entry.SourceAmount.Amount())
+				}
+			}
+		})
+	}
+}
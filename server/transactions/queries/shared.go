@@ -1,9 +1,11 @@
 package queries
 
 import (
+	"errors"
 	"financo/lib/color"
 	"financo/lib/currency"
 	"financo/lib/icon"
+	"financo/lib/money"
 	"financo/lib/nullable"
 	"financo/models/account"
 	"financo/server/transactions/types/response"
@@ -56,13 +58,16 @@ SELECT
     trgp.icon,
     trgp.archived_at,
     trgp.created_at,
-    trgp.updated_at
+    trgp.updated_at,
+    tr.split_id,
+    sp.notes
 FROM
     transactions tr
     INNER JOIN accounts src ON src.id = tr.source_id
     LEFT JOIN accounts srcp ON srcp.id = src.parent_id
     INNER JOIN accounts trg ON trg.id = tr.target_id
     LEFT JOIN accounts trgp ON trgp.id = trg.parent_id
+    LEFT JOIN transaction_splits sp ON sp.id = tr.split_id
 WHERE
     tr.deleted_at IS NULL
 	`
@@ -113,6 +118,8 @@ type BaseQueryListRow struct {
 	TrgParentArchivedAt nullable.Type[time.Time]
 	TrgParentCreatedAt  nullable.Type[time.Time]
 	TrgParentUpdatedAt  nullable.Type[time.Time]
+	SplitID             nullable.Type[int64]
+	SplitNotes          nullable.Type[string]
 }
 
 func BuildTransactions(row BaseQueryListRow) response.Detailed {
@@ -121,15 +128,78 @@ func BuildTransactions(row BaseQueryListRow) response.Detailed {
 		IssuedAt:     row.IssuedAt,
 		ExecutedAt:   row.ExecutedAt,
 		Source:       buildSourceAccount(row),
-		SourceAmount: row.SourceAmount,
+		SourceAmount: money.New(row.SourceAmount, row.SrcCurrency),
 		Target:       buildTargetAccount(row),
-		TargetAmount: row.TargetAmount,
+		TargetAmount: money.New(row.TargetAmount, row.TrgCurrency),
 		Notes:        row.Notes,
+		SplitID:      row.SplitID,
 		CreatedAt:    row.CreatedAt,
 		UpdatedAt:    row.UpdatedAt,
 	}
 }
 
+// BuildGroupedTransactions builds the rows like [BuildTransactions] but groups
+// the legs of a split transaction into a single entry, placed where its first
+// leg is. The entry takes the notes of the split, its amounts are the sum of
+// the amounts of its legs and its target is the target of the first leg.
+//
+// It returns an error if the amounts of the legs can't be added.
+func BuildGroupedTransactions(rows []BaseQueryListRow) ([]response.Detailed, error) {
+	var (
+		res    = make([]response.Detailed, 0, len(rows))
+		splits = make(map[int64]int)
+	)
+
+	for _, row := range rows {
+		if !row.SplitID.Valid {
+			res = append(res, BuildTransactions(row))
+			continue
+		}
+
+		leg := response.Leg{
+			ID:        row.ID,
+			Target:    buildTargetAccount(row),
+			Amount:    money.New(row.TargetAmount, row.TrgCurrency),
+			Notes:     row.Notes,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		}
+
+		i, ok := splits[row.SplitID.Val]
+		if !ok {
+			entry := BuildTransactions(row)
+
+			entry.Notes = row.SplitNotes
+			entry.Legs = []response.Leg{leg}
+
+			splits[row.SplitID.Val] = len(res)
+			res = append(res, entry)
+
+			continue
+		}
+
+		entry := res[i]
+
+		sourceAmount, err := entry.SourceAmount.Add(money.New(row.SourceAmount, row.SrcCurrency))
+		if err != nil {
+			return res, errors.Join(errors.New("failed to add leg source amount"), err)
+		}
+
+		targetAmount, err := entry.TargetAmount.Add(leg.Amount)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to add leg target amount"), err)
+		}
+
+		entry.SourceAmount = sourceAmount
+		entry.TargetAmount = targetAmount
+		entry.Legs = append(entry.Legs, leg)
+
+		res[i] = entry
+	}
+
+	return res, nil
+}
+
 func buildSourceAccount(row BaseQueryListRow) response.Account {
 	return response.Account{
 		ID:         row.SrcID,
@@ -0,0 +1,125 @@
+package queries
+
+import (
+	"financo/lib/currency"
+	"financo/lib/nullable"
+	"financo/models/account"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func row(id, amount int64, splitID ...int64) BaseQueryListRow {
+	r := BaseQueryListRow{
+		ID:           id,
+		SourceAmount: amount,
+		TargetAmount: amount,
+		Notes:        nullable.New("leg"),
+		SrcID:        1,
+		SrcKind:      account.CapitalNormal,
+		SrcCurrency:  currency.CAD,
+		TrgID:        100 + id,
+		TrgKind:      account.ExternalExpense,
+		TrgCurrency:  currency.CAD,
+	}
+
+	if len(splitID) > 0 {
+		r.SplitID = nullable.New(splitID[0])
+		r.SplitNotes = nullable.New("split")
+	}
+
+	return r
+}
+
+func TestBuildGroupedTransactions(t *testing.T) {
+	type entry struct {
+		id     int64
+		amount int64
+		legs   []int64
+	}
+
+	tests := []struct {
+		name string
+		rows []BaseQueryListRow
+		want []entry
+	}{
+		{
+			name: "no splits",
+			rows: []BaseQueryListRow{row(1, 100), row(2, 200)},
+			want: []entry{{id: 1, amount: 100}, {id: 2, amount: 200}},
+		},
+		{
+			name: "single leg",
+			rows: []BaseQueryListRow{row(1, 100, 7)},
+			want: []entry{{id: 1, amount: 100, legs: []int64{1}}},
+		},
+		{
+			name: "consecutive legs",
+			rows: []BaseQueryListRow{row(1, 100, 7), row(2, 200, 7)},
+			want: []entry{{id: 1, amount: 300, legs: []int64{1, 2}}},
+		},
+		{
+			name: "legs out of order",
+			rows: []BaseQueryListRow{row(3, 300, 7), row(1, 100, 7), row(2, 200, 7)},
+			want: []entry{{id: 3, amount: 600, legs: []int64{3, 1, 2}}},
+		},
+		{
+			name: "mixed rows",
+			rows: []BaseQueryListRow{
+				row(1, 100),
+				row(2, 200, 7),
+				row(3, 300, 8),
+				row(4, 400),
+				row(5, 500, 7),
+				row(6, 600, 8),
+			},
+			want: []entry{
+				{id: 1, amount: 100},
+				{id: 2, amount: 700, legs: []int64{2, 5}},
+				{id: 3, amount: 900, legs: []int64{3, 6}},
+				{id: 4, amount: 400},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := BuildGroupedTransactions(tt.rows)
+
+			assert.NoError(t, err)
+			assert.Len(t, res, len(tt.want))
+
+			for i, want := range tt.want {
+				got := res[i]
+
+				assert.Equal(t, want.id, got.ID)
+				assert.Equal(t, want.amount, got.SourceAmount.Amount())
+				assert.Equal(t, want.amount, got.TargetAmount.Amount())
+
+				if want.legs == nil {
+					assert.Empty(t, got.Legs)
+					assert.Equal(t, nullable.New("leg"), got.Notes)
+					continue
+				}
+
+				assert.Equal(t, nullable.New("split"), got.Notes)
+				assert.Len(t, got.Legs, len(want.legs))
+
+				for j, leg := range got.Legs {
+					assert.Equal(t, want.legs[j], leg.ID)
+					assert.Equal(t, 100+want.legs[j], leg.Target.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildGroupedTransactionsCurrencyMismatch(t *testing.T) {
+	other := row(2, 200, 7)
+	other.SrcCurrency = currency.USD
+	other.TrgCurrency = currency.USD
+
+	_, err := BuildGroupedTransactions([]BaseQueryListRow{row(1, 100, 7), other})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package split_query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/queries"
+	base "financo/server/transactions/queries"
+	"financo/server/transactions/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+// New returns the split transaction with the given id as a single entry
+// grouping all of its legs.
+func New(id int64) queries.Query[response.Detailed] {
+	return &query{
+		id: id,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Detailed, error) {
+	var (
+		query    = base.BaseQueryList + " AND tr.split_id = $1 ORDER BY tr.id"
+		found    = make([]base.BaseQueryListRow, 0, 5)
+		postgres = postgresql_database.New()
+
+		res response.Detailed
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, query, q.id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row base.BaseQueryListRow
+
+		err = rows.Scan(
+			&row.ID,
+			&row.IssuedAt,
+			&row.ExecutedAt,
+			&row.SourceAmount,
+			&row.TargetAmount,
+			&row.Notes,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+			&row.SrcID,
+			&row.SrcKind,
+			&row.SrcCurrency,
+			&row.SrcName,
+			&row.SrcColor,
+			&row.SrcIcon,
+			&row.SrcArchivedAt,
+			&row.SrcCreatedAt,
+			&row.SrcUpdatedAt,
+			&row.SrcParentID,
+			&row.SrcParentKind,
+			&row.SrcParentCurrency,
+			&row.SrcParentName,
+			&row.SrcParentColor,
+			&row.SrcParentIcon,
+			&row.SrcParentArchivedAt,
+			&row.SrcParentCreatedAt,
+			&row.SrcParentUpdatedAt,
+			&row.TrgID,
+			&row.TrgKind,
+			&row.TrgCurrency,
+			&row.TrgName,
+			&row.TrgColor,
+			&row.TrgIcon,
+			&row.TrgArchivedAt,
+			&row.TrgCreatedAt,
+			&row.TrgUpdatedAt,
+			&row.TrgParentID,
+			&row.TrgParentKind,
+			&row.TrgParentCurrency,
+			&row.TrgParentName,
+			&row.TrgParentColor,
+			&row.TrgParentIcon,
+			&row.TrgParentArchivedAt,
+			&row.TrgParentCreatedAt,
+			&row.TrgParentUpdatedAt,
+			&row.SplitID,
+			&row.SplitNotes,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		found = append(found, row)
+	}
+
+	grouped, err := base.BuildGroupedTransactions(found)
+	if err != nil {
+		return res, err
+	}
+
+	if len(grouped) == 0 {
+		return res, sql.ErrNoRows
+	}
+
+	grouped, err = base.LoadTags(ctx, conn, grouped)
+	if err != nil {
+		return res, err
+	}
+
+	return grouped[0], nil
+}
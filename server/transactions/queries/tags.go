@@ -0,0 +1,112 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/server/transactions/types/request"
+	"financo/server/transactions/types/response"
+	"fmt"
+)
+
+// TagFilterQuery returns the conditions filtering the transactions by filter,
+// its arguments and the next placeholder index after filter.
+func TagFilterQuery(filter request.TagFilter, index int) (string, []any, int) {
+	var (
+		query = ""
+		args  = make([]any, 0, 2)
+	)
+
+	if len(filter.Include) > 0 {
+		query += fmt.Sprintf(
+			" AND EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = tr.id AND tt.tag_id = ANY ($%d))",
+			index,
+		)
+		args = append(args, filter.Include)
+		index++
+	}
+
+	if len(filter.Exclude) > 0 {
+		query += fmt.Sprintf(
+			" AND NOT EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = tr.id AND tt.tag_id = ANY ($%d))",
+			index,
+		)
+		args = append(args, filter.Exclude)
+		index++
+	}
+
+	return query, args, index
+}
+
+// LoadTags sets the tags of every transaction of res, and of the legs of the
+// split ones.
+func LoadTags(ctx context.Context, conn *sql.Conn, res []response.Detailed) ([]response.Detailed, error) {
+	ids := make([]int64, 0, len(res))
+
+	for _, entry := range res {
+		ids = append(ids, entry.ID)
+
+		for _, leg := range entry.Legs {
+			ids = append(ids, leg.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				tt.transaction_id,
+				t.id,
+				t.name,
+				t.color
+			FROM transaction_tags tt
+				INNER JOIN tags t ON t.id = tt.tag_id
+			WHERE t.deleted_at IS NULL
+				AND tt.transaction_id = ANY ($1)
+			ORDER BY t.name
+		`,
+		ids,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute tags query"), err)
+	}
+	defer rows.Close()
+
+	tags := make(map[int64][]response.Tag, len(ids))
+
+	for rows.Next() {
+		var (
+			id  int64
+			tag response.Tag
+		)
+
+		err = rows.Scan(&id, &tag.ID, &tag.Name, &tag.Color)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan tags query row"), err)
+		}
+
+		tags[id] = append(tags[id], tag)
+	}
+
+	for i := range res {
+		res[i].Tags = withTags(tags[res[i].ID])
+
+		for j := range res[i].Legs {
+			res[i].Legs[j].Tags = withTags(tags[res[i].Legs[j].ID])
+		}
+	}
+
+	return res, nil
+}
+
+func withTags(tags []response.Tag) []response.Tag {
+	if tags == nil {
+		return []response.Tag{}
+	}
+
+	return tags
+}
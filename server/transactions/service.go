@@ -1,6 +1,7 @@
 package transactions
 
 import (
+	"financo/lib/message_bus"
 	"financo/server/transactions/brokers"
 	"sync"
 )
@@ -12,3 +13,9 @@ import (
 func NewBroker(wg *sync.WaitGroup) brokers.Broker {
 	return brokers.New(wg)
 }
+
+// NewRedisBroker is like [NewBroker] but the broker is backed by Redis
+// streams, see [brokers.NewRedis].
+func NewRedisBroker(wg *sync.WaitGroup, dial message_bus.Dialer) brokers.Broker {
+	return brokers.NewRedis(wg, dial)
+}
@@ -2,8 +2,24 @@ package message
 
 import (
 	"financo/models/transaction"
+	"strconv"
 )
 
+// CreatedTopic is the outbox topic of [Created] messages
+const CreatedTopic = "transaction_created"
+
 type Created struct {
 	Record transaction.Record
+	// RequestID identifies the HTTP request that produced the message, it is
+	// empty for background jobs.
+	RequestID string
+	// OutboxID is the id of the outbox message the message was relayed
+	// from, it orders the messages of every topic.
+	OutboxID int64
+}
+
+// AccountKey returns the id of the source account, so the messages of an
+// account can be consumed in the order they were published.
+func (m Created) AccountKey() string {
+	return strconv.FormatInt(m.Record.SourceID, 10)
 }
@@ -2,10 +2,22 @@ package message
 
 import (
 	"financo/models/transaction"
+	"strconv"
 )
 
+// DeletedTopic is the outbox topic of [Deleted] messages
+const DeletedTopic = "transaction_deleted"
+
 type Deleted struct {
 	ID            int64
 	PreviousState transaction.Record
 	CurrentState  transaction.Record
+	RequestID     string
+	OutboxID      int64
+}
+
+// AccountKey returns the id of the source account, so the messages of an
+// account can be consumed in the order they were published.
+func (m Deleted) AccountKey() string {
+	return strconv.FormatInt(m.CurrentState.SourceID, 10)
 }
@@ -2,10 +2,22 @@ package message
 
 import (
 	"financo/models/transaction"
+	"strconv"
 )
 
+// UpdatedTopic is the outbox topic of [Updated] messages
+const UpdatedTopic = "transaction_updated"
+
 type Updated struct {
 	ID            int64
 	PreviousState transaction.Record
 	CurrentState  transaction.Record
+	RequestID     string
+	OutboxID      int64
+}
+
+// AccountKey returns the id of the current source account, so the messages
+// of an account can be consumed in the order they were published.
+func (m Updated) AccountKey() string {
+	return strconv.FormatInt(m.CurrentState.SourceID, 10)
 }
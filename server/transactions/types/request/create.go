@@ -13,4 +13,13 @@ type Create struct {
 	TargetID     int64                    `json:"targetID"`
 	SourceAmount int64                    `json:"sourceAmount"`
 	TargetAmount int64                    `json:"targetAmount"`
+	// Legs turns the transaction into a split, TargetID and TargetAmount are
+	// ignored and SourceAmount must be the sum of the amounts of the legs.
+	Legs []Leg `json:"legs"`
+}
+
+type Leg struct {
+	TargetID int64                 `json:"targetID"`
+	Amount   int64                 `json:"amount"`
+	Notes    nullable.Type[string] `json:"notes"`
 }
@@ -0,0 +1,12 @@
+package request
+
+import (
+	"financo/lib/statement"
+)
+
+type Import struct {
+	ProfileID int64            `json:"profileID"`
+	AccountID int64            `json:"accountID"`
+	Format    statement.Format `json:"format"`
+	Preview   bool             `json:"preview"`
+}
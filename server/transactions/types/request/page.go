@@ -0,0 +1,67 @@
+package request
+
+import (
+	"errors"
+	"financo/lib/cursor"
+	"financo/lib/nullable"
+	"fmt"
+	"strconv"
+)
+
+type Sort string
+
+const (
+	Ascending  Sort = "asc"
+	Descending Sort = "desc"
+
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// Page selects a page of a transactions listing, the first one when Cursor
+// isn't set.
+type Page struct {
+	Cursor nullable.Type[cursor.Cursor]
+	Limit  int
+	Sort   Sort
+}
+
+// ParsePage builds a [Page] from the raw query parameters, the empty ones
+// take their default value: [DefaultLimit] and [Descending].
+//
+// It returns an error if the cursor is invalid, the limit isn't between 1
+// and [MaxLimit] or the sort is unknown.
+func ParsePage(rawCursor string, rawLimit string, rawSort string) (Page, error) {
+	page := Page{
+		Limit: DefaultLimit,
+		Sort:  Descending,
+	}
+
+	if rawCursor != "" {
+		c, err := cursor.Decode(rawCursor)
+		if err != nil {
+			return page, err
+		}
+
+		page.Cursor = nullable.New(c)
+	}
+
+	if rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return page, fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+		}
+
+		page.Limit = limit
+	}
+
+	switch Sort(rawSort) {
+	case "":
+	case Ascending, Descending:
+		page.Sort = Sort(rawSort)
+	default:
+		return page, errors.New("sort must be asc or desc")
+	}
+
+	return page, nil
+}
@@ -0,0 +1,6 @@
+package request
+
+type Tag struct {
+	ID   int64   `json:"id"`
+	Tags []int64 `json:"tags"`
+}
@@ -0,0 +1,47 @@
+package request
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TagFilter keeps the transactions tagged with any of Include and drops the
+// ones tagged with any of Exclude.
+type TagFilter struct {
+	Include []int64
+	Exclude []int64
+}
+
+// ParseTagFilter parses a comma separated list of tag IDs, the ones prefixed
+// with a minus sign are excluded.
+//
+// It returns an error if any of the IDs isn't a number.
+func ParseTagFilter(raw string) (TagFilter, error) {
+	filter := TagFilter{
+		Include: make([]int64, 0, 5),
+		Exclude: make([]int64, 0, 5),
+	}
+
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		excluded := strings.HasPrefix(value, "-")
+
+		id, err := strconv.ParseInt(strings.TrimPrefix(value, "-"), 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid tag \"%s\"", value)
+		}
+
+		if excluded {
+			filter.Exclude = append(filter.Exclude, id)
+		} else {
+			filter.Include = append(filter.Include, id)
+		}
+	}
+
+	return filter, nil
+}
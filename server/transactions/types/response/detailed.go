@@ -4,6 +4,7 @@ import (
 	"financo/lib/color"
 	"financo/lib/currency"
 	"financo/lib/icon"
+	"financo/lib/money"
 	"financo/lib/nullable"
 	"financo/models/account"
 	"time"
@@ -14,14 +15,35 @@ type Detailed struct {
 	IssuedAt     time.Time                `json:"issuedAt"`
 	ExecutedAt   nullable.Type[time.Time] `json:"executedAt"`
 	Source       Account                  `json:"source"`
-	SourceAmount int64                    `json:"sourceAmount"`
+	SourceAmount money.Money              `json:"sourceAmount"`
 	Target       Account                  `json:"target"`
-	TargetAmount int64                    `json:"targetAmount"`
+	TargetAmount money.Money              `json:"targetAmount"`
 	Notes        nullable.Type[string]    `json:"notes"`
+	SplitID      nullable.Type[int64]     `json:"splitID"`
+	Legs         []Leg                    `json:"legs"`
+	Tags         []Tag                    `json:"tags"`
 	CreatedAt    time.Time                `json:"createdAt"`
 	UpdatedAt    time.Time                `json:"updatedAt"`
 }
 
+// Leg is one of the transactions of a split, Amount is in the currency of
+// Target.
+type Leg struct {
+	ID        int64                 `json:"id"`
+	Target    Account               `json:"target"`
+	Amount    money.Money           `json:"amount"`
+	Notes     nullable.Type[string] `json:"notes"`
+	Tags      []Tag                 `json:"tags"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+type Tag struct {
+	ID    int64      `json:"id"`
+	Name  string     `json:"name"`
+	Color color.Type `json:"color"`
+}
+
 type Account struct {
 	ID         int64                        `json:"id"`
 	Kind       account.Kind                 `json:"kind"`
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"financo/lib/nullable"
+	"time"
+)
+
+type Import struct {
+	Preview      bool          `json:"preview"`
+	Entries      []ImportEntry `json:"entries"`
+	Transactions []Detailed    `json:"transactions"`
+	// Duplicated counts the statement entries skipped because they were
+	// already imported.
+	Duplicated int `json:"duplicated"`
+}
+
+type ImportEntry struct {
+	ExternalID   nullable.Type[string]    `json:"externalID"`
+	IssuedAt     time.Time                `json:"issuedAt"`
+	ExecutedAt   nullable.Type[time.Time] `json:"executedAt"`
+	Notes        nullable.Type[string]    `json:"notes"`
+	SourceID     int64                    `json:"sourceID"`
+	TargetID     int64                    `json:"targetID"`
+	SourceAmount int64                    `json:"sourceAmount"`
+	TargetAmount int64                    `json:"targetAmount"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"financo/lib/nullable"
+)
+
+// Page is a page of a transactions listing. Next and Prev are the cursors of
+// the following and previous pages, they aren't set on the last and first
+// pages.
+type Page struct {
+	Data []Detailed            `json:"data"`
+	Next nullable.Type[string] `json:"next"`
+	Prev nullable.Type[string] `json:"prev"`
+}
@@ -0,0 +1,123 @@
+package purge_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/trash/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	before time.Time
+}
+
+// New returns a command that hard deletes every deletion older than
+// retention. Each deletion is purged in its own transaction so a record still
+// referenced elsewhere only keeps its own deletion in the trash.
+func New(retention time.Duration) commands.Command[response.Purge] {
+	return &command{
+		before: time.Now().UTC().Add(-retention),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Purge, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res = response.Purge{Failed: make([]response.Failure, 0)}
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	ids, err := c.findExpired(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find expired deletions"), err)
+	}
+
+	for _, id := range ids {
+		err = c.purge(ctx, conn, id)
+		if err != nil {
+			res.Failed = append(res.Failed, response.Failure{ID: id, Error: err.Error()})
+			continue
+		}
+
+		res.Purged++
+	}
+
+	return res, nil
+}
+
+func (c *command) findExpired(ctx context.Context, conn *sql.Conn) ([]int64, error) {
+	var output = make([]int64, 0, 20)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		"SELECT id FROM deletions WHERE deleted_at < $1 ORDER BY deleted_at, id",
+		c.before,
+	)
+	if err != nil {
+		return output, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return output, err
+		}
+
+		output = append(output, id)
+	}
+
+	return output, rows.Err()
+}
+
+func (c *command) purge(ctx context.Context, conn *sql.Conn, id int64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	statements := []string{
+		`
+			DELETE FROM transaction_tags
+			WHERE transaction_id IN (SELECT id FROM transactions WHERE deletion_id = $1)
+		`,
+		`
+			WITH purged AS (
+				DELETE FROM transactions WHERE deletion_id = $1 RETURNING split_id
+			)
+			DELETE FROM transaction_splits
+			WHERE id IN (SELECT split_id FROM purged)
+				AND NOT EXISTS (
+					SELECT 1 FROM transactions tr
+					WHERE tr.split_id = transaction_splits.id AND tr.deletion_id IS DISTINCT FROM $1
+				)
+		`,
+		"DELETE FROM accounts WHERE deletion_id = $1",
+		"DELETE FROM deletions WHERE id = $1",
+	}
+
+	for _, statement := range statements {
+		_, err = tx.ExecContext(ctx, statement, id)
+		if err != nil {
+			return errors.Join(errors.New("failed to purge deletion"), err, tx.Rollback())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Join(errors.New("failed to commit transaction"), err)
+	}
+
+	return nil
+}
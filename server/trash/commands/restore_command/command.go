@@ -0,0 +1,354 @@
+package restore_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/lib/nullable"
+	"financo/lib/outbox"
+	"financo/lib/request_id"
+	"financo/models/account"
+	"financo/models/deletion"
+	"financo/models/transaction"
+	"financo/server/transactions/types/message"
+	"financo/server/trash/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("deleted record not found")
+	// ErrBlocked is returned when restoring would bring back records whose
+	// parent account or transaction accounts are still deleted by a different
+	// delete, which has to be restored first.
+	ErrBlocked = errors.New("restore is blocked by another deletion")
+)
+
+type command struct {
+	kind      deletion.Kind
+	recordID  int64
+	timestamp time.Time
+}
+
+func New(kind deletion.Kind, recordID int64) commands.Command[response.Item] {
+	return &command{
+		kind:      kind,
+		recordID:  recordID,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Item, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Item
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	record, err := c.findDeletion(ctx, conn)
+	if errors.Is(err, sql.ErrNoRows) {
+		return res, ErrNotFound
+	}
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find deletion"), err)
+	}
+
+	blockers, err := c.countBlockers(ctx, conn, record.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to check deletion blockers"), err)
+	}
+	if blockers > 0 {
+		return res, ErrBlocked
+	}
+
+	res.ID = record.ID
+	res.Kind = record.Kind
+	res.RecordID = record.RecordID
+	res.DeletedAt = record.DeletedAt
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	accounts, err := c.restoreAccounts(ctx, tx, record.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to restore accounts"), err, tx.Rollback())
+	}
+
+	for _, a := range accounts {
+		previous := a
+		previous.DeletedAt = nullable.New(record.DeletedAt)
+		previous.UpdatedAt = record.DeletedAt
+
+		err = outbox.Write(ctx, tx, messages.UpdatedTopic, messages.Updated{
+			Previous:  previous,
+			Current:   a,
+			RequestID: request_id.FromContext(ctx),
+		})
+		if err != nil {
+			return res, errors.Join(errors.New("failed to write account updated message"), err, tx.Rollback())
+		}
+	}
+
+	transactions, err := c.restoreTransactions(ctx, tx, record.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to restore transactions"), err, tx.Rollback())
+	}
+
+	err = c.restoreSplits(ctx, tx, transactions)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to restore splits"), err, tx.Rollback())
+	}
+
+	for _, t := range transactions {
+		previous := t
+		previous.DeletedAt = nullable.New(record.DeletedAt)
+		previous.UpdatedAt = record.DeletedAt
+
+		err = outbox.Write(ctx, tx, message.UpdatedTopic, message.Updated{
+			ID:            t.ID,
+			PreviousState: previous,
+			CurrentState:  t,
+			RequestID:     request_id.FromContext(ctx),
+		})
+		if err != nil {
+			return res, errors.Join(errors.New("failed to write updated message"), err, tx.Rollback())
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM deletions WHERE id = $1", record.ID)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to remove deletion"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to commit transaction"), err)
+	}
+
+	res.Accounts = int64(len(accounts))
+	res.Transactions = int64(len(transactions))
+
+	switch record.Kind {
+	case deletion.Account:
+		for _, a := range accounts {
+			if a.ID == record.RecordID {
+				res.Name = nullable.New(a.Name)
+			}
+		}
+	case deletion.Transaction:
+		for _, t := range transactions {
+			if t.ID == record.RecordID {
+				res.Name = t.Notes
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (c *command) findDeletion(ctx context.Context, conn *sql.Conn) (deletion.Record, error) {
+	var record deletion.Record
+
+	err := conn.QueryRowContext(
+		ctx,
+		`
+			SELECT id, kind, record_id, deleted_at, created_at
+			FROM deletions
+			WHERE kind = $1 AND record_id = $2
+			ORDER BY deleted_at DESC, id DESC
+			LIMIT 1
+		`,
+		c.kind,
+		c.recordID,
+	).Scan(
+		&record.ID,
+		&record.Kind,
+		&record.RecordID,
+		&record.DeletedAt,
+		&record.CreatedAt,
+	)
+
+	return record, err
+}
+
+func (c *command) countBlockers(ctx context.Context, conn *sql.Conn, deletionID int64) (int64, error) {
+	var count int64
+
+	err := conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				(
+					SELECT COUNT(*)
+					FROM accounts acc
+					JOIN accounts parent ON parent.id = acc.parent_id
+					WHERE acc.deletion_id = $1
+						AND parent.deleted_at IS NOT NULL
+						AND parent.deletion_id IS DISTINCT FROM $1
+				) + (
+					SELECT COUNT(*)
+					FROM transactions tr
+					JOIN accounts acc ON acc.id = tr.source_id OR acc.id = tr.target_id
+					WHERE tr.deletion_id = $1
+						AND acc.deleted_at IS NOT NULL
+						AND acc.deletion_id IS DISTINCT FROM $1
+				)
+		`,
+		deletionID,
+	).Scan(&count)
+
+	return count, err
+}
+
+func (c *command) restoreAccounts(ctx context.Context, tx *sql.Tx, deletionID int64) ([]account.Record, error) {
+	var output = make([]account.Record, 0, 10)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			UPDATE accounts
+			SET deleted_at = NULL, deletion_id = NULL, updated_at = $2
+			WHERE deletion_id = $1
+			RETURNING
+				id,
+				parent_id,
+				kind,
+				currency,
+				name,
+				description,
+				color,
+				icon,
+				capital,
+				archived_at,
+				deleted_at,
+				created_at,
+				updated_at
+		`,
+		deletionID,
+		c.timestamp,
+	)
+	if err != nil {
+		return output, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record account.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.ParentID,
+			&record.Kind,
+			&record.Currency,
+			&record.Name,
+			&record.Description,
+			&record.Color,
+			&record.Icon,
+			&record.Capital,
+			&record.ArchivedAt,
+			&record.DeletedAt,
+			&record.CreatedAt,
+			&record.UpdatedAt,
+		)
+		if err != nil {
+			return output, err
+		}
+
+		output = append(output, record)
+	}
+
+	return output, rows.Err()
+}
+
+func (c *command) restoreTransactions(ctx context.Context, tx *sql.Tx, deletionID int64) ([]transaction.Record, error) {
+	var output = make([]transaction.Record, 0, 10)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`
+			UPDATE transactions
+			SET deleted_at = NULL, deletion_id = NULL, updated_at = $2
+			WHERE deletion_id = $1
+			RETURNING
+				id,
+				source_id,
+				target_id,
+				source_amount,
+				target_amount,
+				notes,
+				issued_at,
+				executed_at,
+				split_id,
+				deleted_at,
+				created_at,
+				updated_at
+		`,
+		deletionID,
+		c.timestamp,
+	)
+	if err != nil {
+		return output, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record transaction.Record
+
+		err = rows.Scan(
+			&record.ID,
+			&record.SourceID,
+			&record.TargetID,
+			&record.SourceAmount,
+			&record.TargetAmount,
+			&record.Notes,
+			&record.IssuedAt,
+			&record.ExecutedAt,
+			&record.SplitID,
+			&record.DeletedAt,
+			&record.CreatedAt,
+			&record.UpdatedAt,
+		)
+		if err != nil {
+			return output, err
+		}
+
+		output = append(output, record)
+	}
+
+	return output, rows.Err()
+}
+
+// restoreSplits restores the splits of the restored legs, a split is always
+// deleted and restored along with every leg of it.
+func (c *command) restoreSplits(ctx context.Context, tx *sql.Tx, transactions []transaction.Record) error {
+	var ids = make([]int64, 0, len(transactions))
+
+	for _, t := range transactions {
+		if t.SplitID.Valid {
+			ids = append(ids, t.SplitID.Val)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		"UPDATE transaction_splits SET deleted_at = NULL, updated_at = $2 WHERE id = ANY ($1)",
+		ids,
+		c.timestamp,
+	)
+
+	return err
+}
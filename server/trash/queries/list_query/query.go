@@ -0,0 +1,71 @@
+package list_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/trash/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct{}
+
+func New() queries.Query[[]response.Item] {
+	return &query{}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Item, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Item, 0, 20)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				d.id,
+				d.kind,
+				d.record_id,
+				COALESCE(acc.name, tr.notes),
+				(SELECT COUNT(*) FROM accounts WHERE deletion_id = d.id),
+				(SELECT COUNT(*) FROM transactions WHERE deletion_id = d.id),
+				d.deleted_at
+			FROM deletions d
+			LEFT JOIN accounts acc ON d.kind = 'account' AND acc.id = d.record_id
+			LEFT JOIN transactions tr ON d.kind = 'transaction' AND tr.id = d.record_id
+			ORDER BY d.deleted_at DESC, d.id DESC
+		`,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row response.Item
+
+		err = rows.Scan(
+			&row.ID,
+			&row.Kind,
+			&row.RecordID,
+			&row.Name,
+			&row.Accounts,
+			&row.Transactions,
+			&row.DeletedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, nil
+}
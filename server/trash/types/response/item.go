@@ -0,0 +1,20 @@
+package response
+
+import (
+	"financo/lib/nullable"
+	"financo/models/deletion"
+	"time"
+)
+
+// Item is a single delete that can be restored, RecordID is the account or
+// transaction the user deleted and Accounts and Transactions count every
+// record removed alongside it.
+type Item struct {
+	ID           int64                 `json:"id"`
+	Kind         deletion.Kind         `json:"kind"`
+	RecordID     int64                 `json:"recordId"`
+	Name         nullable.Type[string] `json:"name"`
+	Accounts     int64                 `json:"accounts"`
+	Transactions int64                 `json:"transactions"`
+	DeletedAt    time.Time             `json:"deletedAt"`
+}
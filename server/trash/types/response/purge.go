@@ -0,0 +1,13 @@
+package response
+
+// Purge reports the outcome of a purge, a failed deletion is kept in the trash
+// and retried on the next run.
+type Purge struct {
+	Purged int       `json:"purged"`
+	Failed []Failure `json:"failed"`
+}
+
+type Failure struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error"`
+}
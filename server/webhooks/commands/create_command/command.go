@@ -0,0 +1,93 @@
+package create_command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/webhooks"
+	"financo/server/webhooks/queries/detailed_query"
+	"financo/server/webhooks/types/request"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+type command struct {
+	req       request.Create
+	timestamp time.Time
+}
+
+func New(req request.Create) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+// Run persists the webhook and returns it along with its secret, the only
+// time the secret is shown unless it is replaced.
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		secret   = strings.TrimSpace(c.req.Secret)
+		events   = c.req.Events
+
+		id  int64
+		res response.Detailed
+	)
+
+	err := webhooks.Validate(c.req.URL, events)
+	if err != nil {
+		return res, err
+	}
+
+	if secret == "" {
+		secret, err = webhooks.NewSecret()
+		if err != nil {
+			return res, err
+		}
+	}
+
+	if events == nil {
+		events = []string{}
+	}
+
+	encodedEvents, err := json.Marshal(events)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to marshal events"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			INSERT INTO webhooks(url, secret, events, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`,
+		c.req.URL,
+		secret,
+		encodedEvents,
+		c.timestamp,
+		c.timestamp,
+	).Scan(&id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to persist record"), err)
+	}
+
+	res, err = detailed_query.New(id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find persisted webhook"), err)
+	}
+
+	res.Secret = secret
+
+	return res, nil
+}
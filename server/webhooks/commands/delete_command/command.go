@@ -0,0 +1,46 @@
+package delete_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/webhooks/queries/detailed_query"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+)
+
+type command struct {
+	id int64
+}
+
+func New(id int64) commands.Command[response.Detailed] {
+	return &command{
+		id: id,
+	}
+}
+
+// Run deletes the webhook along with its delivery log, pending deliveries are
+// dropped.
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	res, err := detailed_query.New(c.id).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find webhook"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1", c.id)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to delete record"), err)
+	}
+
+	return res, nil
+}
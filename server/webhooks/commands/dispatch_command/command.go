@@ -0,0 +1,248 @@
+package dispatch_command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/lib/message_bus"
+	"financo/lib/nullable"
+	"financo/lib/webhook"
+	"financo/models/webhook_delivery"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+	"net/http"
+	"time"
+)
+
+// leaseMargin covers recording the attempts on top of sending them.
+const leaseMargin = time.Minute
+
+// Lease returns how long a batch of limit claimed deliveries is hidden from
+// other dispatchers when every send is cut off after timeout, if the process
+// dies while sending them, they are sent again once the lease expires.
+func Lease(limit int, timeout time.Duration) time.Duration {
+	return time.Duration(limit)*timeout + leaseMargin
+}
+
+// Retry spaces the attempts at a delivery, from 30 seconds up to an hour
+// apart, a delivery is failed after its last attempt.
+var Retry = message_bus.RetryPolicy{
+	MaxAttempts:    6,
+	InitialBackoff: 30 * time.Second,
+	MaxBackoff:     time.Hour,
+	Multiplier:     4,
+}
+
+type command struct {
+	client    *http.Client
+	limit     int
+	timeout   time.Duration
+	timestamp time.Time
+}
+
+type claimed struct {
+	delivery webhook_delivery.Record
+	url      string
+	secret   string
+}
+
+// New returns a command that sends up to limit due deliveries with client,
+// giving up on each after timeout, every attempt is stored in the delivery
+// log.
+func New(client *http.Client, limit int, timeout time.Duration) commands.Command[response.Dispatch] {
+	return &command{
+		client:    client,
+		limit:     limit,
+		timeout:   timeout,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Dispatch, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Dispatch
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	deliveries, err := c.claim(ctx, conn)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to claim deliveries"), err)
+	}
+
+	for _, d := range deliveries {
+		result, sendErr := c.send(ctx, d)
+		if sendErr != nil {
+			res.Failed++
+		} else {
+			res.Succeeded++
+		}
+
+		err = c.record(ctx, conn, d.delivery, result, sendErr)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to record attempt"), err)
+		}
+	}
+
+	return res, nil
+}
+
+// send delivers a claimed delivery, bounded by the timeout the [Lease] of the
+// batch was computed from.
+func (c *command) send(ctx context.Context, d claimed) (webhook.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return webhook.Send(ctx, c.client, webhook.Request{
+		URL:        d.url,
+		Secret:     d.secret,
+		Event:      d.delivery.Event,
+		DeliveryID: d.delivery.ID,
+		Body:       d.delivery.Payload,
+	})
+}
+
+// claim pushes the next attempt of the due deliveries past the [Lease] of the
+// batch, so they are sent outside of a database transaction without other dispatchers
+// picking them up.
+func (c *command) claim(ctx context.Context, conn *sql.Conn) ([]claimed, error) {
+	var output = make([]claimed, 0, c.limit)
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			UPDATE webhook_deliveries d
+			SET next_attempt_at = $1
+			FROM webhooks w
+			WHERE w.id = d.webhook_id
+				AND d.id IN (
+					SELECT id
+					FROM webhook_deliveries
+					WHERE status = $2 AND next_attempt_at <= $3
+					ORDER BY next_attempt_at, id
+					LIMIT $4
+					FOR UPDATE SKIP LOCKED
+				)
+			RETURNING d.id, d.webhook_id, d.event_id, d.event, d.payload, d.attempts, w.url, w.secret
+		`,
+		c.timestamp.Add(Lease(c.limit, c.timeout)),
+		webhook_delivery.Pending,
+		c.timestamp,
+		c.limit,
+	)
+	if err != nil {
+		return output, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			d       claimed
+			payload []byte
+		)
+
+		err = rows.Scan(
+			&d.delivery.ID,
+			&d.delivery.WebhookID,
+			&d.delivery.EventID,
+			&d.delivery.Event,
+			&payload,
+			&d.delivery.Attempts,
+			&d.url,
+			&d.secret,
+		)
+		if err != nil {
+			return output, err
+		}
+
+		d.delivery.Payload = payload
+		output = append(output, d)
+	}
+
+	return output, rows.Err()
+}
+
+// record stores the attempt and schedules the next one, unless the delivery
+// succeeded or ran out of attempts.
+func (c *command) record(
+	ctx context.Context,
+	conn *sql.Conn,
+	delivery webhook_delivery.Record,
+	result webhook.Result,
+	sendErr error,
+) error {
+	var (
+		attempts      = delivery.Attempts + 1
+		status        = webhook_delivery.Succeeded
+		nextAttemptAt = c.timestamp
+		statusCode    nullable.Type[int]
+		errMessage    nullable.Type[string]
+		attemptedAt   = time.Now().UTC()
+	)
+
+	if result.StatusCode != 0 {
+		statusCode = nullable.New(result.StatusCode)
+	}
+
+	if sendErr != nil {
+		errMessage = nullable.New(sendErr.Error())
+		status = webhook_delivery.Failed
+
+		if attempts < Retry.MaxAttempts {
+			status = webhook_delivery.Pending
+			nextAttemptAt = attemptedAt.Add(Retry.Backoff(attempts))
+		}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(errors.New("failed to begin database transaction"), err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`
+			INSERT INTO webhook_attempts (delivery_id, status_code, error, duration_ms, attempted_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`,
+		delivery.ID,
+		statusCode,
+		errMessage,
+		result.Duration.Milliseconds(),
+		attemptedAt,
+	)
+	if err != nil {
+		return errors.Join(errors.New("failed to persist attempt"), err, tx.Rollback())
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`
+			UPDATE webhook_deliveries
+			SET status = $2, attempts = $3, next_attempt_at = $4, updated_at = $5
+			WHERE id = $1
+		`,
+		delivery.ID,
+		status,
+		attempts,
+		nextAttemptAt,
+		attemptedAt,
+	)
+	if err != nil {
+		return errors.Join(errors.New("failed to update delivery"), err, tx.Rollback())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Join(errors.New("failed to commit database transaction"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package dispatch_command
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLease(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int
+		timeout time.Duration
+	}{
+		{name: "dispatcher batch", limit: 20, timeout: 10 * time.Second},
+		{name: "single delivery", limit: 1, timeout: 10 * time.Second},
+		{name: "slow receivers", limit: 100, timeout: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Greater(t, Lease(tt.limit, tt.timeout), time.Duration(tt.limit)*tt.timeout)
+		})
+	}
+}
+
+func TestSendBatchWithinLease(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer receiver.Close()
+
+	var (
+		limit   = 3
+		timeout = 50 * time.Millisecond
+		c       = &command{client: &http.Client{}, limit: limit, timeout: timeout}
+		batch   = time.Duration(limit) * timeout
+		started = time.Now()
+	)
+
+	for i := 0; i < limit; i++ {
+		_, err := c.send(context.Background(), claimed{url: receiver.URL, secret: "secret"})
+
+		assert.Error(t, err)
+	}
+
+	elapsed := time.Since(started)
+
+	assert.Less(t, elapsed, 2*batch)
+	assert.Less(t, elapsed, Lease(limit, timeout))
+}
@@ -0,0 +1,76 @@
+package enqueue_command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/events/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type command struct {
+	event     response.Event
+	timestamp time.Time
+}
+
+// New returns a command that queues a delivery of event for every webhook
+// whose filter accepts it, it returns how many were queued. Queuing the same
+// event twice is a no-op, so redelivered bus messages aren't sent twice.
+func New(event response.Event) commands.Command[int64] {
+	return &command{
+		event:     event,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (int64, error) {
+	var (
+		postgres = postgresql_database.New()
+	)
+
+	payload, err := json.Marshal(&c.event)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to marshal event"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(
+		ctx,
+		`
+			INSERT INTO webhook_deliveries (
+				webhook_id,
+				event_id,
+				event,
+				payload,
+				next_attempt_at,
+				created_at,
+				updated_at
+			)
+			SELECT id, $1, $2::TEXT, $3, $4, $4, $4
+			FROM webhooks
+			WHERE events = '[]'::JSONB OR events ? $2::TEXT
+			ON CONFLICT (webhook_id, event_id) DO NOTHING
+		`,
+		c.event.ID,
+		c.event.Type,
+		payload,
+		c.timestamp,
+	)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to persist deliveries"), err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to count deliveries"), err)
+	}
+
+	return n, nil
+}
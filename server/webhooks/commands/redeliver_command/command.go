@@ -0,0 +1,81 @@
+package redeliver_command
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/models/webhook_delivery"
+	"financo/server/webhooks/queries/deliveries_query"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+// ErrNotFound is returned when the webhook has no such delivery.
+var ErrNotFound = errors.New("webhook delivery not found")
+
+type command struct {
+	webhookID  int64
+	deliveryID int64
+	timestamp  time.Time
+}
+
+func New(webhookID, deliveryID int64) commands.Command[response.Delivery] {
+	return &command{
+		webhookID:  webhookID,
+		deliveryID: deliveryID,
+		timestamp:  time.Now().UTC(),
+	}
+}
+
+// Run queues the delivery to be sent right away, whatever its status. A
+// delivery that already used its attempts gets a single new one.
+func (c *command) Run(ctx context.Context) (response.Delivery, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res response.Delivery
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(
+		ctx,
+		`
+			UPDATE webhook_deliveries
+			SET status = $3, next_attempt_at = $4, updated_at = $4
+			WHERE webhook_id = $1 AND id = $2
+		`,
+		c.webhookID,
+		c.deliveryID,
+		webhook_delivery.Pending,
+		c.timestamp,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to queue delivery"), err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to count updated rows"), err)
+	}
+
+	if n == 0 {
+		return res, ErrNotFound
+	}
+
+	deliveries, err := deliveries_query.ForDelivery(c.webhookID, c.deliveryID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find delivery"), err)
+	}
+
+	if len(deliveries) == 0 {
+		return res, ErrNotFound
+	}
+
+	return deliveries[0], nil
+}
@@ -0,0 +1,98 @@
+package update_command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/commands"
+	"financo/server/webhooks"
+	"financo/server/webhooks/queries/detailed_query"
+	"financo/server/webhooks/types/request"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when the webhook doesn't exist.
+var ErrNotFound = errors.New("webhook not found")
+
+type command struct {
+	req       request.Update
+	timestamp time.Time
+}
+
+func New(req request.Update) commands.Command[response.Detailed] {
+	return &command{
+		req:       req,
+		timestamp: time.Now().UTC(),
+	}
+}
+
+func (c *command) Run(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		secret   = strings.TrimSpace(c.req.Secret)
+		events   = c.req.Events
+
+		res response.Detailed
+	)
+
+	err := webhooks.Validate(c.req.URL, events)
+	if err != nil {
+		return res, err
+	}
+
+	if events == nil {
+		events = []string{}
+	}
+
+	encodedEvents, err := json.Marshal(events)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to marshal events"), err)
+	}
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(
+		ctx,
+		`
+			UPDATE webhooks
+			SET url = $2,
+				secret = COALESCE(NULLIF($3, ''), secret),
+				events = $4,
+				updated_at = $5
+			WHERE id = $1
+		`,
+		c.req.ID,
+		c.req.URL,
+		secret,
+		encodedEvents,
+		c.timestamp,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to update record"), err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to count updated rows"), err)
+	}
+
+	if n == 0 {
+		return res, ErrNotFound
+	}
+
+	res, err = detailed_query.New(c.req.ID).Find(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to find updated webhook"), err)
+	}
+
+	res.Secret = secret
+
+	return res, nil
+}
@@ -0,0 +1,164 @@
+package deliveries_query
+
+import (
+	"context"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/lib/nullable"
+	"financo/models/webhook_delivery"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+	"time"
+)
+
+type query struct {
+	webhookID  int64
+	deliveryID int64
+	limit      int
+}
+
+// New returns a query finding the latest limit deliveries of a webhook, most
+// recent first, with their attempts.
+func New(webhookID int64, limit int) queries.Query[[]response.Delivery] {
+	return &query{
+		webhookID: webhookID,
+		limit:     limit,
+	}
+}
+
+// ForDelivery returns a query finding a single delivery of a webhook with its
+// attempts, the result is empty if the webhook has no such delivery.
+func ForDelivery(webhookID, deliveryID int64) queries.Query[[]response.Delivery] {
+	return &query{
+		webhookID:  webhookID,
+		deliveryID: deliveryID,
+		limit:      1,
+	}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Delivery, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Delivery, 0, q.limit)
+		indexes  = make(map[int64]int, q.limit)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				event_id,
+				event,
+				payload,
+				status,
+				next_attempt_at,
+				created_at
+			FROM webhook_deliveries
+			WHERE webhook_id = $1 AND ($3::BIGINT = 0 OR id = $3)
+			ORDER BY id DESC
+			LIMIT $2
+		`,
+		q.webhookID,
+		q.limit,
+		q.deliveryID,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row           response.Delivery
+			payload       []byte
+			nextAttemptAt time.Time
+		)
+
+		err = rows.Scan(
+			&row.ID,
+			&row.EventID,
+			&row.Event,
+			&payload,
+			&row.Status,
+			&nextAttemptAt,
+			&row.CreatedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		row.Payload = payload
+		row.Attempts = make([]response.Attempt, 0, 1)
+
+		if row.Status == webhook_delivery.Pending {
+			row.NextAttemptAt = nullable.New(nextAttemptAt)
+		}
+
+		indexes[row.ID] = len(res)
+		res = append(res, row)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return res, errors.Join(errors.New("failed to iterate deliveries"), err)
+	}
+
+	if len(res) == 0 {
+		return res, nil
+	}
+
+	ids := make([]int64, 0, len(res))
+	for _, delivery := range res {
+		ids = append(ids, delivery.ID)
+	}
+
+	attempts, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				delivery_id,
+				status_code,
+				error,
+				duration_ms,
+				attempted_at
+			FROM webhook_attempts
+			WHERE delivery_id = ANY($1)
+			ORDER BY id DESC
+		`,
+		ids,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute attempts query"), err)
+	}
+	defer attempts.Close()
+
+	for attempts.Next() {
+		var (
+			deliveryID int64
+			attempt    response.Attempt
+		)
+
+		err = attempts.Scan(
+			&deliveryID,
+			&attempt.StatusCode,
+			&attempt.Error,
+			&attempt.DurationMs,
+			&attempt.AttemptedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan attempt row"), err)
+		}
+
+		i := indexes[deliveryID]
+		res[i].Attempts = append(res[i].Attempts, attempt)
+	}
+
+	return res, attempts.Err()
+}
@@ -0,0 +1,66 @@
+package detailed_query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct {
+	id int64
+}
+
+func New(id int64) queries.Query[response.Detailed] {
+	return &query{
+		id: id,
+	}
+}
+
+func (q *query) Find(ctx context.Context) (response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+
+		res    response.Detailed
+		events []byte
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	err = conn.QueryRowContext(
+		ctx,
+		`
+			SELECT
+				id,
+				url,
+				events,
+				created_at,
+				updated_at
+			FROM webhooks
+			WHERE id = $1
+		`,
+		q.id,
+	).Scan(
+		&res.ID,
+		&res.URL,
+		&events,
+		&res.CreatedAt,
+		&res.UpdatedAt,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute and scan query"), err)
+	}
+
+	err = json.Unmarshal(events, &res.Events)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to unmarshal events"), err)
+	}
+
+	return res, nil
+}
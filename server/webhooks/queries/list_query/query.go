@@ -0,0 +1,74 @@
+package list_query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financo/core/domain/queries"
+	"financo/server/webhooks/types/response"
+	"financo/services/postgresql_database"
+)
+
+type query struct{}
+
+func New() queries.Query[[]response.Detailed] {
+	return &query{}
+}
+
+func (q *query) Find(ctx context.Context) ([]response.Detailed, error) {
+	var (
+		postgres = postgresql_database.New()
+		res      = make([]response.Detailed, 0, 10)
+	)
+
+	conn, err := postgres.Conn(ctx)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to retrieve database connection"), err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(
+		ctx,
+		`
+			SELECT
+				id,
+				url,
+				events,
+				created_at,
+				updated_at
+			FROM webhooks
+			ORDER BY id
+		`,
+	)
+	if err != nil {
+		return res, errors.Join(errors.New("failed to execute query"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row    response.Detailed
+			events []byte
+		)
+
+		err = rows.Scan(
+			&row.ID,
+			&row.URL,
+			&events,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+		)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to scan query row"), err)
+		}
+
+		err = json.Unmarshal(events, &row.Events)
+		if err != nil {
+			return res, errors.Join(errors.New("failed to unmarshal events"), err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,19 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+)
+
+// NewSecret returns a random secret to sign payloads with.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Join(errors.New("failed to generate secret"), err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,155 @@
+package webhooks
+
+import (
+	"context"
+	"financo/core/scope_accounts/domain/messages"
+	"financo/core/scope_accounts/infrastructure/broker_handler"
+	"financo/lib/message_bus"
+	"financo/lib/poll"
+	events "financo/server/events/types/response"
+	"financo/server/transactions/brokers"
+	"financo/server/transactions/types/message"
+	"financo/server/webhooks/commands/dispatch_command"
+	"financo/server/webhooks/commands/enqueue_command"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	enqueueTimeout   = 5 * time.Second
+	dispatchInterval = time.Second
+	dispatchLimit    = 20
+	sendTimeout      = 10 * time.Second
+	// consumerWorkers enqueue events concurrently, the events of an account
+	// are still enqueued in the order they were published.
+	consumerWorkers = 4
+)
+
+// consumerRetry keeps retrying an event for about a minute, so it outlives a
+// database restart before ending up as a dead letter.
+var consumerRetry = message_bus.RetryPolicy{
+	MaxAttempts:    6,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     3,
+}
+
+// Subscribe registers the webhook consumers on the accounts and transactions
+// brokers, every event published from then on is queued for the webhooks
+// whose filter accepts it.
+func Subscribe(accounts broker_handler.BrokerHandler, transactions brokers.Broker) error {
+	err := accounts.CreatedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Created](func(msg messages.Created) error {
+			return enqueue(events.FromAccountCreated(msg))
+		}),
+		message_bus.WithName("webhooks_account_created"),
+		message_bus.WithKey(messages.Created.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = accounts.UpdatedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Updated](func(msg messages.Updated) error {
+			return enqueue(events.FromAccountUpdated(msg))
+		}),
+		message_bus.WithName("webhooks_account_updated"),
+		message_bus.WithKey(messages.Updated.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = accounts.DeletedBroker().Subscribe(
+		message_bus.ConsumerFunc[messages.Deleted](func(msg messages.Deleted) error {
+			return enqueue(events.FromAccountDeleted(msg))
+		}),
+		message_bus.WithName("webhooks_account_deleted"),
+		message_bus.WithKey(messages.Deleted.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToCreated(
+		message_bus.ConsumerFunc[message.Created](func(msg message.Created) error {
+			return enqueue(events.FromTransactionCreated(msg))
+		}),
+		message_bus.WithName("webhooks_transaction_created"),
+		message_bus.WithKey(message.Created.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = transactions.SubscribeToUpdated(
+		message_bus.ConsumerFunc[message.Updated](func(msg message.Updated) error {
+			return enqueue(events.FromTransactionUpdated(msg))
+		}),
+		message_bus.WithName("webhooks_transaction_updated"),
+		message_bus.WithKey(message.Updated.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+	if err != nil {
+		return err
+	}
+
+	return transactions.SubscribeToDeleted(
+		message_bus.ConsumerFunc[message.Deleted](func(msg message.Deleted) error {
+			return enqueue(events.FromTransactionDeleted(msg))
+		}),
+		message_bus.WithName("webhooks_transaction_deleted"),
+		message_bus.WithKey(message.Deleted.AccountKey),
+		message_bus.WithWorkers(consumerWorkers),
+		message_bus.WithRetry(consumerRetry),
+	)
+}
+
+func enqueue(event events.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), enqueueTimeout)
+	defer cancel()
+
+	_, err := enqueue_command.New(event).Run(ctx)
+
+	return err
+}
+
+// StartDispatcher sends the queued deliveries to their webhooks every second
+// until ctx is canceled, a failed delivery is retried following
+// [dispatch_command.Retry].
+func StartDispatcher(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	client := &http.Client{Timeout: sendTimeout}
+
+	log.Println("Starting webhook dispatcher...")
+
+	poll.Loop(ctx, dispatchInterval, func(ctx context.Context) bool {
+		res, err := dispatch_command.New(client, dispatchLimit, sendTimeout).Run(ctx)
+		if err != nil {
+			log.Printf("failed to dispatch webhooks: %s\n", err)
+			return false
+		}
+
+		if res.Failed > 0 {
+			log.Printf("failed to deliver %d webhooks\n", res.Failed)
+		}
+
+		// Claimed deliveries are leased and failed ones rescheduled after
+		// their backoff, so the next batch only holds other due deliveries.
+		return res.Succeeded+res.Failed == dispatchLimit
+	})
+
+	log.Println("Webhook dispatcher stopped")
+}
@@ -0,0 +1,9 @@
+package request
+
+// Create subscribes URL to Events, every event when empty. A secret is
+// generated when Secret is empty.
+type Create struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// Update replaces the URL and Events of a webhook, the secret is only
+// replaced when Secret isn't empty.
+type Update struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
@@ -0,0 +1,28 @@
+package response
+
+import (
+	"encoding/json"
+	"financo/lib/nullable"
+	"financo/models/webhook_delivery"
+	"time"
+)
+
+// Delivery is an event sent to a webhook along with every attempt at sending
+// it, most recent first.
+type Delivery struct {
+	ID            int64                    `json:"id"`
+	EventID       int64                    `json:"eventId"`
+	Event         string                   `json:"event"`
+	Payload       json.RawMessage          `json:"payload"`
+	Status        webhook_delivery.Status  `json:"status"`
+	NextAttemptAt nullable.Type[time.Time] `json:"nextAttemptAt"`
+	Attempts      []Attempt                `json:"attempts"`
+	CreatedAt     time.Time                `json:"createdAt"`
+}
+
+type Attempt struct {
+	StatusCode  nullable.Type[int]    `json:"statusCode"`
+	Error       nullable.Type[string] `json:"error"`
+	DurationMs  int64                 `json:"durationMs"`
+	AttemptedAt time.Time             `json:"attemptedAt"`
+}
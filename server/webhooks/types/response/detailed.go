@@ -0,0 +1,16 @@
+package response
+
+import (
+	"time"
+)
+
+// Detailed is a webhook, Secret is only filled in when the webhook is
+// created or its secret replaced.
+type Detailed struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
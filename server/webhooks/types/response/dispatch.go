@@ -0,0 +1,8 @@
+package response
+
+// Dispatch reports the outcome of a dispatch, a failed delivery is retried
+// later unless it ran out of attempts.
+type Dispatch struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
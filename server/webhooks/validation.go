@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"errors"
+	"financo/server/events/types/response"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// ErrInvalid is returned when a webhook can't be persisted.
+var ErrInvalid = errors.New("invalid webhook")
+
+// Validate checks that a webhook can be persisted, events must be known
+// event types.
+//
+// It returns an error wrapping [ErrInvalid] describing the first invalid
+// field.
+func Validate(rawURL string, events []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalid)
+	}
+
+	for _, event := range events {
+		if !slices.Contains(response.Types, event) {
+			return fmt.Errorf("%w: unknown event %s", ErrInvalid, event)
+		}
+	}
+
+	return nil
+}
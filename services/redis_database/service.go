@@ -0,0 +1,65 @@
+package redis_database
+
+import (
+	"context"
+	"financo/lib/resp"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+)
+
+// Service represents a service that connects to Redis.
+type Service interface {
+	// Enabled reports whether the message buses are backed by Redis, which
+	// is the case when MESSAGE_BUS_DRIVER is "redis".
+	Enabled() bool
+
+	// Dial returns a new connection to Redis.
+	// It returns an error if the connection can't be established.
+	//
+	// Every Conn must be closed after use by calling [resp.Conn.Close].
+	Dial(ctx context.Context) (*resp.Conn, error)
+}
+
+type service struct {
+	addr   string
+	driver string
+}
+
+const (
+	defaultAddr   = "localhost:6379"
+	defaultDriver = "memory"
+)
+
+var instance *service
+
+// New returns the instance of the redis_database Service.
+// It will either return the exiting instance or initialize a new one.
+func New() Service {
+	if instance != nil {
+		return instance
+	}
+
+	instance = &service{
+		addr:   defaultAddr,
+		driver: defaultDriver,
+	}
+
+	if addr, ok := os.LookupEnv("REDIS_ADDR"); ok && addr != "" {
+		instance.addr = addr
+	}
+
+	if driver, ok := os.LookupEnv("MESSAGE_BUS_DRIVER"); ok && driver != "" {
+		instance.driver = driver
+	}
+
+	return instance
+}
+
+func (s *service) Enabled() bool {
+	return s.driver == "redis"
+}
+
+func (s *service) Dial(ctx context.Context) (*resp.Conn, error) {
+	return resp.Dial(ctx, s.addr)
+}
@@ -1,53 +1,69 @@
 import { Create, Transaction, Update } from "@/types/Transaction"
 import isEmptyParam from "@helpers/isEmptyParam"
 
+export interface Page<T> {
+    data: T[]
+    next: string | null
+    prev: string | null
+}
+
+// fetchPage loads one page of a paginated listing, its next and prev cursors
+// are sent back as the cursor filter to load the pages around it.
+async function fetchPage(path: string, params: URLSearchParams): Promise<Page<Transaction>> {
+    const response = await fetch(`${path}?${params.toString()}`)
+
+    if (!response.ok) throw response
+
+    return response.json()
+}
+
 export interface ListFilters {
     executedFrom?: string
     executedUntil?: string
 
     account?: number[]
     category?: number[]
+    // tags are tag IDs, the ones prefixed with a minus sign are excluded.
+    tags?: string[]
+
+    limit?: number
+    sort?: "asc" | "desc"
+    cursor?: string
 }
 
-export async function getTransactions(filters: ListFilters): Promise<Transaction[]> {
+export async function getTransactions(filters: ListFilters): Promise<Page<Transaction>> {
     const params = new URLSearchParams(Object.entries(filters).filter(([_, value]) => !isEmptyParam(value)))
-    const response = await fetch(`/api/transactions?${params.toString()}`)
-
-    if (!response.ok) throw response
 
-    return response.json()
+    return fetchPage("/api/transactions", params)
 }
 
-export async function getTransactionsForAccount(id: number, filters: ListFilters): Promise<Transaction[]> {
+export async function getTransactionsForAccount(id: number, filters: ListFilters): Promise<Page<Transaction>> {
     const params = new URLSearchParams(Object.entries(filters).filter(([_, value]) => !isEmptyParam(value)))
-    const response = await fetch(`/api/transactions/for_account/${id}?${params.toString()}`)
-
-    if (!response.ok) throw response
 
-    return response.json()
+    return fetchPage(`/api/transactions/for_account/${id}`, params)
 }
 
 export interface PendingFilters {
     account?: number[]
     category?: number[]
+    // tags are tag IDs, the ones prefixed with a minus sign are excluded.
+    tags?: string[]
+
+    limit?: number
+    sort?: "asc" | "desc"
+    cursor?: string
 }
 
-export async function getPendingTransactions(filters: PendingFilters): Promise<Transaction[]> {
+export async function getPendingTransactions(filters: PendingFilters): Promise<Page<Transaction>> {
     const params = new URLSearchParams(Object.entries(filters).filter(([_, value]) => !isEmptyParam(value)))
-    const response = await fetch(`/api/transactions/pending?${params.toString()}`)
 
-    if (!response.ok) throw response
-
-    return response.json()
+    return fetchPage("/api/transactions/pending", params)
 }
 
-export async function getPendingTransactionsForAccount(id: number, filters: ListFilters): Promise<Transaction[]> {
+export async function getPendingTransactionsForAccount(id: number, filters: ListFilters): Promise<Page<Transaction>> {
     const params = new URLSearchParams(Object.entries(filters).filter(([_, value]) => !isEmptyParam(value)))
-    const response = await fetch(`/api/transactions/for_account/${id}/pending?${params.toString()}`)
 
-    if (!response.ok) throw response
-
-    return response.json()
+    return fetchPage(`/api/transactions/for_account/${id}/pending`, params)
 }
 
 export async function createTransaction(data: Create): Promise<Transaction> {
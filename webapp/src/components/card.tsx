@@ -2,6 +2,7 @@ import { cn } from "@/lib/utils";
 import { Summary } from "@/types/Summary";
 import currencyAmountColor from "@helpers/currencyAmountColor";
 import currencyAmountToHuman from "@helpers/currencyAmountToHuman";
+import currencyExponent from "@helpers/currencyExponent";
 import { format } from "date-fns";
 import { Currency } from "dinero.js";
 import moment from "moment";
@@ -46,7 +47,7 @@ export function CardSummary({
                 <CardTitle className="text-sm font-medium">{title}</CardTitle>
                 <div className="flex flex-row gap-2">
                     {
-                        summaries.map(({ amount, currency }) => <div
+                        summaries.map(({ amount: { amount }, currency }) => <div
                             key={`${title.replace(" ", "").toLowerCase()}:${currency}`}
                             className={cn("text-2xl font-bold", currencyAmountColor(amount, false))}
                         >
@@ -102,7 +103,7 @@ export function CardSummary({
                                             dataKey="amount"
                                             data={
                                                 series?.map(({ date, amount }) => (
-                                                    { date: date, amount: amount / 100 }
+                                                    { date: date, amount: amount.amount / 10 ** currencyExponent(currency) }
                                                 )) || []
                                             }
                                             type="monotone"
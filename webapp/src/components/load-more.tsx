@@ -0,0 +1,25 @@
+import { cn } from "@/lib/utils"
+import { Throbber } from "@components/Throbber"
+import { Button } from "@components/ui/button"
+
+interface Props {
+    hasNextPage: boolean
+    isFetchingNextPage: boolean
+    fetchNextPage: () => unknown
+    className?: string
+}
+
+// LoadMore loads the next page of a paginated listing, it isn't rendered once
+// the last page is loaded.
+export function LoadMore({ hasNextPage, isFetchingNextPage, fetchNextPage, className }: Props) {
+    if (!hasNextPage) return null
+
+    return (
+        <div className={cn("flex justify-center py-4", className)}>
+            <Button variant="outline" disabled={isFetchingNextPage} onClick={() => fetchNextPage()}>
+                {isFetchingNextPage && <Throbber variant="small" className="mr-2" />}
+                Load more
+            </Button>
+        </div>
+    )
+}
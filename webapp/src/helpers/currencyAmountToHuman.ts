@@ -1,9 +1,10 @@
 import DineroFactory, { Currency } from "dinero.js";
+import currencyExponent from "./currencyExponent";
 
-export default function currencyAmountToHuman(
-    amount: number,
-    currency: Currency,
-    precision: number = 2
-): string {
-    return DineroFactory({ amount: Math.abs(amount), currency, precision }).toFormat()
-}
\ No newline at end of file
+export default function currencyAmountToHuman(amount: number, currency: Currency): string {
+    return DineroFactory({
+        amount: Math.abs(amount),
+        currency,
+        precision: currencyExponent(currency)
+    }).toFormat()
+}
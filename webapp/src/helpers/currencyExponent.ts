@@ -0,0 +1,13 @@
+import { Currency as ApiCurrency } from "@/types/currency";
+import Client from "@queries/client";
+import { currenciesQueryOptions } from "@queries/currencies";
+import { Currency } from "dinero.js";
+import { isNil } from "lodash";
+
+export default function currencyExponent(currency: Currency): number {
+    const found = Client.getQueryData<ApiCurrency[]>(currenciesQueryOptions.queryKey)?.find(({ code }) => code === currency)
+
+    if (isNil(found)) throw new Error(`currency ${currency} isn't loaded`)
+
+    return found.exponent
+}
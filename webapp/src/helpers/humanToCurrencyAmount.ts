@@ -0,0 +1,10 @@
+import { Currency } from "dinero.js";
+import { isNaN } from "lodash";
+import currencyExponent from "./currencyExponent";
+
+export default function humanToCurrencyAmount(value: number | string, currency: Currency): number {
+    const amount = Math.round(Number(value) * 10 ** currencyExponent(currency))
+
+    // Inputs hold partial values while typing, they're validated on submit.
+    return isNaN(amount) ? 0 : amount
+}
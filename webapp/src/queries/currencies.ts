@@ -0,0 +1,12 @@
+import { getCurrencies } from "@api/currencies"
+
+// The currencies don't change while the app runs, they are loaded once and
+// kept so the amount helpers can read their exponents.
+const currenciesQueryOptions = {
+    queryKey: ["currencies"],
+    queryFn: getCurrencies,
+    staleTime: Infinity,
+    gcTime: Infinity,
+}
+
+export { currenciesQueryOptions }
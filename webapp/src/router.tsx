@@ -5,6 +5,7 @@ import { createBrowserRouter } from "react-router-dom";
 const router = createBrowserRouter([
     {
         path: "/",
+        loader: App.loader(Client),
         element: <App.Layout />,
         errorElement: <App.ErrorBoundary />,
         children: [
@@ -1,7 +1,6 @@
 import { cn } from "@/lib/utils";
 import { Icon, Kind } from "@/types/Account";
 import { createAccount } from "@api/accounts";
-import { getCurrencies } from "@api/currencies";
 import { Button } from "@components/ui/button";
 import {
     Command,
@@ -27,8 +26,9 @@ import { useToast } from "@components/ui/use-toast";
 import isDebtAccount, { isCreditAccount } from "@helpers/account/isDebtAccount";
 import currencyAmountColor from "@helpers/currencyAmountColor";
 import currencyAmountToHuman from "@helpers/currencyAmountToHuman";
+import humanToCurrencyAmount from "@helpers/humanToCurrencyAmount";
 import { zodResolver } from "@hookform/resolvers/zod";
-import { staleTimeDefault } from "@queries/client";
+import { currenciesQueryOptions } from "@queries/currencies";
 import { CaretSortIcon } from "@radix-ui/react-icons";
 import { useQuery, useQueryClient } from "@tanstack/react-query";
 import { Currency } from "dinero.js";
@@ -83,7 +83,8 @@ export function NewAccountForm({ setOpen }: NewProps) {
         try {
             const created = await createAccount({
                 ...values,
-                capital: isDebtAccount(values.kind) ? values.capital : 0
+                // The capital is entered in units of the currency.
+                capital: isDebtAccount(values.kind) ? humanToCurrencyAmount(values.capital, values.currency) : 0
             })
 
             toast({
@@ -107,11 +108,7 @@ export function NewAccountForm({ setOpen }: NewProps) {
         }
     }
 
-    const { data: currencies, isError, error } = useQuery({
-        queryKey: ["currencies"],
-        queryFn: getCurrencies,
-        staleTime: staleTimeDefault
-    })
+    const { data: currencies, isError, error } = useQuery(currenciesQueryOptions)
 
     if (isError) throw error
 
@@ -356,7 +353,7 @@ export function NewAccountForm({ setOpen }: NewProps) {
                                                     currencyAmountColor(field.value ?? 0)
                                                 )}
                                             >
-                                                {currencyAmountToHuman(field.value ?? 0, currency)}
+                                                {currencyAmountToHuman(humanToCurrencyAmount(field.value ?? 0, currency), currency)}
                                             </span>
                                         </div>
                                     </FormControl>
@@ -2,7 +2,6 @@ import { cn } from "@/lib/utils";
 import { Detailed } from "@/types/Account";
 import { Currency as ApiCurrency } from "@/types/currency";
 import { updateAccount } from "@api/accounts";
-import { getCurrencies } from "@api/currencies";
 import { Throbber } from "@components/Throbber";
 import { Alert, AlertDescription, AlertTitle } from "@components/ui/alert";
 import { Button } from "@components/ui/button";
@@ -42,8 +41,10 @@ import isExternalAccount from "@helpers/account/isExternalAccount";
 import kindToHuman from "@helpers/account/kindToHuman";
 import currencyAmountColor from "@helpers/currencyAmountColor";
 import currencyAmountToHuman from "@helpers/currencyAmountToHuman";
+import currencyExponent from "@helpers/currencyExponent";
+import humanToCurrencyAmount from "@helpers/humanToCurrencyAmount";
 import { zodResolver } from "@hookform/resolvers/zod";
-import { staleTimeDefault } from "@queries/client";
+import { currenciesQueryOptions } from "@queries/currencies";
 import { CaretSortIcon } from "@radix-ui/react-icons";
 import { useQuery, useQueryClient } from "@tanstack/react-query";
 import { format } from "date-fns";
@@ -57,17 +58,20 @@ import { Form as RouterForm } from "react-router-dom";
 import { z } from "zod";
 import { schema } from "./schema";
 
+// The amounts are entered in units of the currency of the account.
 function mapAccountToUpdateForm(account: Detailed): z.infer<typeof schema> {
+    const unit = 10 ** currencyExponent(account.currency)
+
     return {
         id: account.id,
         kind: account.kind,
         currency: account.currency,
         name: account.name,
         description: account.description ?? undefined,
-        capital: account.capital,
+        capital: account.capital / unit,
         history: {
             present: !isNil(account.history),
-            balance: isNil(account.history?.balance) ? undefined : account.history.balance,
+            balance: isNil(account.history?.balance) ? undefined : account.history.balance / unit,
             at: isNil(account.history?.at) ? undefined : moment(account.history.at).toDate()
         },
         color: account.color,
@@ -81,12 +85,12 @@ function mapAccountToUpdateForm(account: Detailed): z.infer<typeof schema> {
                     currency: child.currency,
                     name: child.name,
                     description: child.description ?? undefined,
-                    capital: child.capital,
+                    capital: child.capital / unit,
                     history: {
                         present: !isNil(child.history),
                         balance: isNil(child.history?.balance)
                             ? undefined
-                            : child.history.balance,
+                            : child.history.balance / unit,
                         at: isNil(child.history?.at)
                             ? undefined
                             : moment(child.history.at).toDate()
@@ -106,11 +110,7 @@ export function UpdateAccountForm({
 }: { account: Detailed, loading: boolean, className?: string }) {
     const queryClient = useQueryClient()
     const { toast } = useToast()
-    const { data: currencies, isError, error } = useQuery({
-        queryKey: ["currencies"],
-        queryFn: getCurrencies,
-        staleTime: staleTimeDefault
-    })
+    const { data: currencies, isError, error } = useQuery(currenciesQueryOptions)
 
     const form = useForm<z.infer<typeof schema>>({
         resolver: zodResolver(schema),
@@ -119,17 +119,22 @@ export function UpdateAccountForm({
 
     const onSubmitUpdate = async (values: z.infer<typeof schema>) => {
         try {
+            const toAmount = (value: number) => humanToCurrencyAmount(value, values.currency)
             const updated = await updateAccount(values.id, {
                 ...values,
+                capital: toAmount(values.capital),
                 history: {
                     ...values.history,
+                    balance: isNil(values.history?.balance) ? undefined : toAmount(values.history.balance),
                     at: values.history?.at?.toISOString()
                 },
                 children: values.children?.map((child) => {
                     return {
                         ...child,
+                        capital: toAmount(child.capital),
                         history: {
                             ...child.history,
+                            balance: isNil(child.history?.balance) ? undefined : toAmount(child.history.balance),
                             at: child.history?.at?.toISOString()
                         }
                     }
@@ -139,8 +144,8 @@ export function UpdateAccountForm({
             await queryClient.invalidateQueries({
                 predicate: ({ queryKey }) => {
                     return isEqual(queryKey, ["accounts", "account", account.id]) ||
-                        isEqual(queryKey, ["transactions", "pending", "account", account.id]) ||
-                        isEqual(queryKey, ["transactions", "upcoming", "account", account.id])
+                        isEqual(queryKey.slice(0, 4), ["transactions", "pending", "account", account.id]) ||
+                        isEqual(queryKey.slice(0, 4), ["transactions", "upcoming", "account", account.id])
                 }
             })
 
@@ -480,7 +485,7 @@ function History({
                                         currencyAmountColor(field.value ?? 0)
                                     )}
                                 >
-                                    {currencyAmountToHuman(field.value ?? 0, currency)}
+                                    {currencyAmountToHuman(humanToCurrencyAmount(field.value ?? 0, currency), currency)}
                                 </span>
                             </div>
                         </FormControl>
@@ -578,7 +583,7 @@ function Capital({
                                         currencyAmountColor(field.value ?? 0)
                                     )}
                                 >
-                                    {currencyAmountToHuman(field.value ?? 0, currency)}
+                                    {currencyAmountToHuman(humanToCurrencyAmount(field.value ?? 0, currency), currency)}
                                 </span>
                             </div>
                         </FormControl>
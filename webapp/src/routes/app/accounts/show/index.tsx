@@ -62,8 +62,8 @@ export default function Show() {
                         title={account.kind === Kind.DebtCredit ? "Credit" : "Amount"}
                         summaries={
                             account.kind === Kind.DebtCredit
-                                ? [{ amount: account.capital, currency: account.currency, series: null }]
-                                : [{ amount: -account.capital, currency: account.currency, series: null }]
+                                ? [{ amount: { amount: account.capital, currency: account.currency }, currency: account.currency, series: null }]
+                                : [{ amount: { amount: -account.capital, currency: account.currency }, currency: account.currency, series: null }]
                         }
                         className="grow"
                     />
@@ -5,10 +5,10 @@ import { accountContrastColor } from "@helpers/account/accountContrastColor";
 import kindToHuman from "@helpers/account/kindToHuman";
 import currencyAmountColor from "@helpers/currencyAmountColor";
 import currencyAmountToHuman from "@helpers/currencyAmountToHuman";
-import { useMutation } from "@tanstack/react-query";
+import { useInfiniteQuery } from "@tanstack/react-query";
 import { groupBy, isEmpty, isNil } from "lodash";
 import moment from "moment";
-import { Suspense, useEffect } from "react";
+import { Suspense, useMemo } from "react";
 import { Link } from "react-router-dom";
 
 import {
@@ -20,6 +20,7 @@ import {
 
 import * as TransactionsFilters from "@components/filters/transactions";
 import { useTransactionsFiltersCtx } from "@components/filters/use-transactions-filters";
+import { LoadMore } from "@components/load-more";
 import { Throbber } from "@components/Throbber";
 import { Accordion, AccordionContent, AccordionItem, AccordionTrigger } from "@components/ui/accordion";
 import { Button } from "@components/ui/button";
@@ -74,12 +75,16 @@ export function Transactions({ account, className }: Props) {
 
 function Pending({ account }: { account: Detailed }) {
     const { filters } = useTransactionsFiltersCtx()
-    const { data: transactions, isPending, isError, error, mutate } = useMutation({
-        mutationKey: ["transactions", "pending", "account", account.id],
-        mutationFn: (filters: PendingFilters) => getPendingTransactionsForAccount(account.id, filters)
+    const pendingFilters: PendingFilters = { account: filters.accounts, category: filters.categories }
+    const {
+        data, isPending, isError, error, hasNextPage, isFetchingNextPage, fetchNextPage
+    } = useInfiniteQuery({
+        queryKey: ["transactions", "pending", "account", account.id, pendingFilters, account.updatedAt],
+        queryFn: ({ pageParam }) => getPendingTransactionsForAccount(account.id, { ...pendingFilters, cursor: pageParam }),
+        initialPageParam: undefined as string | undefined,
+        getNextPageParam: ({ next }) => next ?? undefined
     })
-
-    useEffect(() => mutate({ account: filters.accounts, category: filters.categories }), [filters, account.updatedAt])
+    const transactions = data?.pages.flatMap(({ data }) => data)
 
     if (isError) throw error
     if ((isEmpty(transactions) || isNil(transactions)) && isPending) return null
@@ -107,6 +112,11 @@ function Pending({ account }: { account: Detailed }) {
                             withUpcoming={true}
                         />
                 }
+                <LoadMore
+                    hasNextPage={hasNextPage}
+                    isFetchingNextPage={isFetchingNextPage}
+                    fetchNextPage={fetchNextPage}
+                />
             </AccordionContent>
         </AccordionItem>
     )
@@ -114,19 +124,21 @@ function Pending({ account }: { account: Detailed }) {
 
 function Upcoming({ account }: { account: Detailed }) {
     const { filters } = useTransactionsFiltersCtx()
-    const { data: transactions, isPending, isError, error, mutate } = useMutation({
-        mutationKey: ["transactions", "upcoming", "account", account.id],
-        mutationFn: (filters: ListFilters) => getTransactionsForAccount(account.id, filters)
+    const upcomingFilters: ListFilters = useMemo(() => ({
+        executedFrom: moment().add({ days: 1 }).toISOString(),
+        executedUntil: moment().add({ month: 1 }).toISOString(),
+        account: filters.accounts,
+        category: filters.categories
+    }), [filters, account.updatedAt])
+    const {
+        data, isPending, isError, error, hasNextPage, isFetchingNextPage, fetchNextPage
+    } = useInfiniteQuery({
+        queryKey: ["transactions", "upcoming", "account", account.id, upcomingFilters],
+        queryFn: ({ pageParam }) => getTransactionsForAccount(account.id, { ...upcomingFilters, cursor: pageParam }),
+        initialPageParam: undefined as string | undefined,
+        getNextPageParam: ({ next }) => next ?? undefined
     })
-
-    useEffect(() => {
-        mutate({
-            executedFrom: moment().add({ days: 1 }).toISOString(),
-            executedUntil: moment().add({ month: 1 }).toISOString(),
-            account: filters.accounts,
-            category: filters.categories
-        })
-    }, [filters, account.updatedAt])
+    const transactions = data?.pages.flatMap(({ data }) => data)
 
     if (isError) throw error
     if ((isEmpty(transactions) || isNil(transactions)) && isPending) return null
@@ -154,6 +166,11 @@ function Upcoming({ account }: { account: Detailed }) {
                             withUpcoming={false}
                         />
                 }
+                <LoadMore
+                    hasNextPage={hasNextPage}
+                    isFetchingNextPage={isFetchingNextPage}
+                    fetchNextPage={fetchNextPage}
+                />
             </AccordionContent>
         </AccordionItem>
     )
@@ -161,19 +178,21 @@ function Upcoming({ account }: { account: Detailed }) {
 
 function History({ account }: { account: Detailed }) {
     const { filters } = useTransactionsFiltersCtx()
-    const { data: transactions, isPending, isError, error, mutate } = useMutation({
-        mutationKey: ["transactions", "account", account.id],
-        mutationFn: (filters: ListFilters) => getTransactionsForAccount(account.id, filters)
+    const historyFilters: ListFilters = {
+        executedFrom: filters.from?.toISOString(),
+        executedUntil: filters.to?.toISOString(),
+        account: filters.accounts,
+        category: filters.categories
+    }
+    const {
+        data, isPending, isError, error, hasNextPage, isFetchingNextPage, fetchNextPage
+    } = useInfiniteQuery({
+        queryKey: ["transactions", "account", account.id, historyFilters, account.updatedAt],
+        queryFn: ({ pageParam }) => getTransactionsForAccount(account.id, { ...historyFilters, cursor: pageParam }),
+        initialPageParam: undefined as string | undefined,
+        getNextPageParam: ({ next }) => next ?? undefined
     })
-
-    useEffect(() => {
-        mutate({
-            executedFrom: filters.from?.toISOString(),
-            executedUntil: filters.to?.toISOString(),
-            account: filters.accounts,
-            category: filters.categories
-        })
-    }, [filters, account.updatedAt])
+    const transactions = data?.pages.flatMap(({ data }) => data)
 
     if (isError) throw error
     if (isPending) return null
@@ -187,13 +206,20 @@ function History({ account }: { account: Detailed }) {
     )
 
     return (
-        <TransactionsTable
-            account={account}
-            transactions={transactions}
-            sortByFn={(a, b) => Date.parse(b.executedAt!) - Date.parse(a.executedAt!)}
-            groupByFn={({ executedAt }) => executedAt!}
-            withUpcoming={true}
-        />
+        <>
+            <TransactionsTable
+                account={account}
+                transactions={transactions}
+                sortByFn={(a, b) => Date.parse(b.executedAt!) - Date.parse(a.executedAt!)}
+                groupByFn={({ executedAt }) => executedAt!}
+                withUpcoming={true}
+            />
+            <LoadMore
+                hasNextPage={hasNextPage}
+                isFetchingNextPage={isFetchingNextPage}
+                fetchNextPage={fetchNextPage}
+            />
+        </>
     )
 }
 
@@ -260,7 +286,7 @@ function SectionRow({ account: { id: accountID }, date, transactions, withUpcomi
                 transactions.map(({ id, source, target, sourceAmount, targetAmount }) => {
                     const external = source.id === accountID ? target : source
                     const account = source.id === accountID ? source : target
-                    const amount = source.id === accountID ? sourceAmount : targetAmount
+                    const amount = (source.id === accountID ? sourceAmount : targetAmount).amount
 
                     return (
                         <TableRow
@@ -4,6 +4,7 @@ import Dashboard from "./dashboard";
 import ErrorBoundary from "./error-boundary";
 import Layout from "./layout";
 import Ledger from "./ledger";
+import { loader } from "./loader";
 import NotFound from "./not-found";
 
 export default {
@@ -13,5 +14,6 @@ export default {
     ErrorBoundary,
     Layout,
     Ledger,
+    loader,
     NotFound,
 }
\ No newline at end of file
@@ -1,21 +1,26 @@
 import { Transaction } from "@/types/Transaction"
-import { ListFilters } from "@api/transactions"
+import { Page } from "@api/transactions"
+import { LoadMore } from "@components/load-more"
 import { Throbber } from "@components/Throbber"
 import { CardContent } from "@components/ui/card"
-import { UseMutationResult } from "@tanstack/react-query"
+import { InfiniteData, UseInfiniteQueryResult } from "@tanstack/react-query"
 import { isEmpty, isNil } from "lodash"
 import { Dispatch, SetStateAction } from "react"
 import { TransactionTable } from "./table"
 
 interface Props {
-    mutation: UseMutationResult<Transaction[], Error, ListFilters, unknown>
+    query: UseInfiniteQueryResult<InfiniteData<Page<Transaction>>, Error>
     setOpen: Dispatch<SetStateAction<boolean>>
     setTransaction: Dispatch<SetStateAction<Transaction | NonNullable<unknown>>>
 }
 
-export function TransactionsHistory({ mutation: { data, isPending, isError, error }, setOpen, setTransaction }: Props) {
+export function TransactionsHistory({
+    query: { data: pages, isPending, isError, error, hasNextPage, isFetchingNextPage, fetchNextPage }, setOpen, setTransaction
+}: Props) {
     if (isError) throw error
 
+    const data = pages?.pages.flatMap(({ data }) => data)
+
     if (isPending) return (
         <CardContent className="flex flex-row gap-4 justify-center items-center py-4">
             <Throbber variant="small" /> <p>Fetching</p>
@@ -29,13 +34,20 @@ export function TransactionsHistory({ mutation: { data, isPending, isError, erro
     )
 
     return (
-        <TransactionTable
-            transactions={data}
-            sortByFn={(a, b) => Date.parse(b.executedAt!) - Date.parse(a.executedAt!)}
-            groupByFn={({ executedAt }) => executedAt!}
-            setOpen={setOpen}
-            setTransaction={setTransaction}
-            withUpcoming={true}
-        />
+        <>
+            <TransactionTable
+                transactions={data}
+                sortByFn={(a, b) => Date.parse(b.executedAt!) - Date.parse(a.executedAt!)}
+                groupByFn={({ executedAt }) => executedAt!}
+                setOpen={setOpen}
+                setTransaction={setTransaction}
+                withUpcoming={true}
+            />
+            <LoadMore
+                hasNextPage={hasNextPage}
+                isFetchingNextPage={isFetchingNextPage}
+                fetchNextPage={fetchNextPage}
+            />
+        </>
     )
 }
@@ -8,9 +8,9 @@ import {
 import { useTransactionsFiltersCtx } from "@components/filters/use-transactions-filters";
 import { Accordion } from "@components/ui/accordion";
 import { Card } from "@components/ui/card";
-import { useMutation } from "@tanstack/react-query";
+import { useInfiniteQuery } from "@tanstack/react-query";
 import moment from "moment";
-import { Dispatch, SetStateAction, useEffect } from "react";
+import { Dispatch, SetStateAction } from "react";
 import { useLoaderData, useOutletContext } from "react-router-dom";
 import { TransactionsHistory } from "./history";
 import { loader } from "./loader";
@@ -27,63 +27,63 @@ export default function Index() {
     const { timestamp } = useLoaderData() as Awaited<ReturnType<ReturnType<typeof loader>>>
     const { filters } = useTransactionsFiltersCtx()
 
-    const pendingTransactions = useMutation({
-        mutationKey: ['transactions', 'pending'],
-        mutationFn: (data: PendingFilters) => getPendingTransactions(data)
-    })
+    const pendingFilters: PendingFilters = {
+        account: filters.accounts,
+        category: filters.categories
+    }
 
-    const upcomingTransactions = useMutation({
-        mutationKey: ['transactions', 'upcoming'],
-        mutationFn: (data: ListFilters) => getTransactions(data)
+    const pendingTransactions = useInfiniteQuery({
+        queryKey: ['transactions', 'pending', pendingFilters, timestamp],
+        queryFn: ({ pageParam }) => getPendingTransactions({ ...pendingFilters, cursor: pageParam }),
+        initialPageParam: undefined as string | undefined,
+        getNextPageParam: ({ next }) => next ?? undefined
     })
 
-    const historyTransactions = useMutation({
-        mutationKey: ["transactions", "history"],
-        mutationFn: (data: ListFilters) => getTransactions(data)
-    })
+    const upcomingFilters: ListFilters = {
+        executedFrom: moment(timestamp).add({ days: 1 }).toISOString(),
+        executedUntil: moment(timestamp).add({ month: 1 }).toISOString(),
+        account: filters.accounts,
+        category: filters.categories
+    }
 
-    useEffect(() => {
-        pendingTransactions.mutate({
-            account: filters.accounts,
-            category: filters.categories
-        })
-    }, [filters, timestamp])
+    const upcomingTransactions = useInfiniteQuery({
+        queryKey: ['transactions', 'upcoming', upcomingFilters],
+        queryFn: ({ pageParam }) => getTransactions({ ...upcomingFilters, cursor: pageParam }),
+        initialPageParam: undefined as string | undefined,
+        getNextPageParam: ({ next }) => next ?? undefined
+    })
 
-    useEffect(() => {
-        upcomingTransactions.mutate({
-            executedFrom: moment().add({ days: 1 }).toISOString(),
-            executedUntil: moment().add({ month: 1 }).toISOString(),
-            account: filters.accounts,
-            category: filters.categories
-        })
-    }, [filters, timestamp])
+    const historyFilters: ListFilters = {
+        executedFrom: filters.from?.toISOString(),
+        executedUntil: filters.to?.toISOString(),
+        account: filters.accounts,
+        category: filters.categories
+    }
 
-    useEffect(() => {
-        historyTransactions.mutate({
-            executedFrom: filters.from?.toISOString(),
-            executedUntil: filters.to?.toISOString(),
-            account: filters.accounts,
-            category: filters.categories
-        })
-    }, [filters, timestamp])
+    const historyTransactions = useInfiniteQuery({
+        queryKey: ["transactions", "history", historyFilters, timestamp],
+        queryFn: ({ pageParam }) => getTransactions({ ...historyFilters, cursor: pageParam }),
+        initialPageParam: undefined as string | undefined,
+        getNextPageParam: ({ next }) => next ?? undefined
+    })
 
     return (
         <div className="flex flex-col">
             <Accordion type="multiple" className="flex flex-col">
                 <TransactionsUpcoming
-                    mutation={upcomingTransactions}
+                    query={upcomingTransactions}
                     setOpen={setOpen}
                     setTransaction={setTransaction}
                 />
                 <TransactionsPending
-                    mutation={pendingTransactions}
+                    query={pendingTransactions}
                     setOpen={setOpen}
                     setTransaction={setTransaction}
                 />
             </Accordion>
             <Card>
                 <TransactionsHistory
-                    mutation={historyTransactions}
+                    query={historyTransactions}
                     setOpen={setOpen}
                     setTransaction={setTransaction}
                 />
@@ -119,7 +119,7 @@ function Section({ date, transactions, withUpcoming, upcomingDate, setOpen, setT
                                 transaction.target.currency !== transaction.source.currency && (
                                     <span>
                                         {currencyAmountToHuman(
-                                            transaction.sourceAmount, transaction.source.currency
+                                            transaction.sourceAmount.amount, transaction.sourceAmount.currency
                                         )}
                                     </span>
                                 )
@@ -144,6 +144,13 @@ function Section({ date, transactions, withUpcoming, upcomingDate, setOpen, setT
                                             : `${transaction.target.parent.name} (${transaction.target.name})`
                                     }
                                 </span>
+                                {
+                                    !isNil(transaction.legs) && transaction.legs.length > 1 && (
+                                        <span className="text-muted-foreground">
+                                            {`+${transaction.legs.length - 1}`}
+                                        </span>
+                                    )
+                                }
                                 {
                                     !isNil(transaction.target.archivedAt) && <ArchiveIcon />
                                 }
@@ -156,7 +163,7 @@ function Section({ date, transactions, withUpcoming, upcomingDate, setOpen, setT
                             )}
                         >
                             <span>
-                                {currencyAmountToHuman(transaction.targetAmount, transaction.target.currency)}
+                                {currencyAmountToHuman(transaction.targetAmount.amount, transaction.targetAmount.currency)}
                             </span>
                         </TableCell>
                     </TableRow >
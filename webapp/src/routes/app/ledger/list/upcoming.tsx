@@ -1,24 +1,27 @@
 import { Transaction } from "@/types/Transaction"
-import { ListFilters } from "@api/transactions"
+import { Page } from "@api/transactions"
+import { LoadMore } from "@components/load-more"
 import { Throbber } from "@components/Throbber"
 import { AccordionContent, AccordionItem, AccordionTrigger } from "@components/ui/accordion"
 import { Card, CardContent, CardDescription } from "@components/ui/card"
-import { UseMutationResult } from "@tanstack/react-query"
+import { InfiniteData, UseInfiniteQueryResult } from "@tanstack/react-query"
 import { isEmpty, isNil } from "lodash"
 import { Dispatch, SetStateAction } from "react"
 import { TransactionTable } from "./table"
 
 interface Props {
-    mutation: UseMutationResult<Transaction[], Error, ListFilters, unknown>
+    query: UseInfiniteQueryResult<InfiniteData<Page<Transaction>>, Error>
     setOpen: Dispatch<SetStateAction<boolean>>
     setTransaction: Dispatch<SetStateAction<Transaction | NonNullable<unknown>>>
 }
 
 export function TransactionsUpcoming({
-    mutation: { data, isPending, isError, error }, setOpen, setTransaction
+    query: { data: pages, isPending, isError, error, hasNextPage, isFetchingNextPage, fetchNextPage }, setOpen, setTransaction
 }: Props) {
     if (isError) throw error
 
+    const data = pages?.pages.flatMap(({ data }) => data)
+
     if (isPending) return (
         <Card className="mb-4 overflow-clip">
             <CardContent className="flex flex-row gap-4 justify-center items-center py-4">
@@ -47,6 +50,11 @@ export function TransactionsUpcoming({
                         setTransaction={setTransaction}
                         withUpcoming={false}
                     />
+                    <LoadMore
+                        hasNextPage={hasNextPage}
+                        isFetchingNextPage={isFetchingNextPage}
+                        fetchNextPage={fetchNextPage}
+                    />
                 </AccordionContent>
             </AccordionItem>
         </Card>
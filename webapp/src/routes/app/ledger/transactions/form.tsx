@@ -34,6 +34,8 @@ import { useToast } from "@components/ui/use-toast";
 import { accountContrastColor } from "@helpers/account/accountContrastColor";
 import kindToHuman from "@helpers/account/kindToHuman";
 import currencyAmountToHuman from "@helpers/currencyAmountToHuman";
+import currencyExponent from "@helpers/currencyExponent";
+import humanToCurrencyAmount from "@helpers/humanToCurrencyAmount";
 import { normalizeDateForServer } from "@helpers/normalizeDate";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { staleTimeDefault } from "@queries/client";
@@ -99,8 +101,8 @@ function mapTransactionToForm(transaction: Transactionable): z.infer<typeof sche
         notes: notes ? notes : undefined,
         issuedAt: moment(issuedAt).toDate(),
         executedAt: executedAt ? moment(executedAt).toDate() : undefined,
-        sourceAmount,
-        targetAmount
+        sourceAmount: sourceAmount.amount / 10 ** currencyExponent(sourceAmount.currency),
+        targetAmount: targetAmount.amount / 10 ** currencyExponent(targetAmount.currency)
     } as z.infer<typeof schema>
 }
 
@@ -175,7 +177,10 @@ export default function TransactionForm({ transaction, setOpen }: Props) {
 
     const onSubmit = async (values: z.infer<typeof schema>) => {
         try {
-            const { id, sourceID, targetID, issuedAt, executedAt, sourceAmount, targetAmount, notes } = values
+            const { id, sourceID, targetID, issuedAt, executedAt, notes } = values
+            // The amounts are entered in units of their currency.
+            const sourceAmount = humanToCurrencyAmount(values.sourceAmount, source!.currency)
+            const targetAmount = humanToCurrencyAmount(values.targetAmount, target!.currency)
 
             const tr = !id
                 ? await createTransaction({
@@ -522,7 +527,8 @@ export default function TransactionForm({ transaction, setOpen }: Props) {
                                     >
                                         {
                                             currencyAmountToHuman(
-                                                field.value ?? 0, source?.currency || "EUR"
+                                                humanToCurrencyAmount(field.value ?? 0, source?.currency || "EUR"),
+                                                source?.currency || "EUR"
                                             )
                                         }
                                     </span>
@@ -554,7 +560,8 @@ export default function TransactionForm({ transaction, setOpen }: Props) {
                                             >
                                                 {
                                                     currencyAmountToHuman(
-                                                        field.value ?? 0, target?.currency || "EUR"
+                                                        humanToCurrencyAmount(field.value ?? 0, target?.currency || "EUR"),
+                                                        target?.currency || "EUR"
                                                     )
                                                 }
                                             </span>
@@ -0,0 +1,11 @@
+import { currenciesQueryOptions } from "@queries/currencies"
+import { QueryClient } from "@tanstack/react-query"
+import { LoaderFunctionArgs } from "react-router-dom"
+
+// The amounts are formatted with the exponent of their currency, so the
+// currencies are loaded before any page.
+export const loader = (queryClient: QueryClient) => async (_props: LoaderFunctionArgs) => {
+    await queryClient.ensureQueryData(currenciesQueryOptions)
+
+    return {}
+}
@@ -1,7 +1,8 @@
 import { Currency } from "dinero.js"
+import { Money } from "./money"
 
 export interface Summary {
-    amount: number
+    amount: Money
     currency: Currency,
-    series: { date: string, amount: number }[] | null
+    series: { date: string, amount: Money }[] | null
 }
\ No newline at end of file
@@ -1,5 +1,7 @@
 import { Currency } from "dinero.js"
 import { Icon, Kind } from "./Account"
+import { Money } from "./money"
+import { Tag } from "./tag"
 
 export interface Create {
     issuedAt: string
@@ -9,6 +11,13 @@ export interface Create {
     targetID: number
     sourceAmount: number
     targetAmount: number
+    legs?: CreateLeg[]
+}
+
+export interface CreateLeg {
+    targetID: number
+    amount: number
+    notes: string | null
 }
 
 export interface Update {
@@ -42,8 +51,21 @@ export interface Transaction {
     source: Account
     target: Account
     notes: string | null
-    sourceAmount: number
-    targetAmount: number
+    sourceAmount: Money
+    targetAmount: Money
+    splitID: number | null
+    legs: Leg[] | null
+    tags: Tag[]
+    updatedAt: string
+    createdAt: string
+}
+
+export interface Leg {
+    id: number
+    target: Account
+    amount: Money
+    notes: string | null
+    tags: Tag[]
     updatedAt: string
     createdAt: string
 }
\ No newline at end of file
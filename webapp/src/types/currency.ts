@@ -3,4 +3,7 @@ import { Currency as Code } from "dinero.js"
 export interface Currency {
     name: string
     code: Code
-}
\ No newline at end of file
+    numeric: string
+    exponent: number
+    symbol: string
+}
@@ -0,0 +1,6 @@
+import { Currency } from "dinero.js"
+
+export interface Money {
+    amount: number
+    currency: Currency
+}
@@ -0,0 +1,5 @@
+export interface Tag {
+    id: number
+    name: string
+    color: string
+}